@@ -3,14 +3,19 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/carapace-sh/carapace"
 	"github.com/spf13/cobra"
 
+	"gitlab.com/amoconst/germinator/internal/batch"
 	"gitlab.com/amoconst/germinator/internal/cmdutil"
 	"gitlab.com/amoconst/germinator/internal/core"
 	"gitlab.com/amoconst/germinator/internal/iostreams"
+	"gitlab.com/amoconst/germinator/internal/output"
 	"gitlab.com/amoconst/germinator/internal/parser"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 	"gitlab.com/amoconst/germinator/internal/renderer"
 	"gitlab.com/amoconst/germinator/internal/transform"
 )
@@ -27,7 +32,8 @@ type Transformer interface {
 
 // adaptOptions holds the runtime state for an `adapt` invocation. IO
 // and Ctx come from the Factory; the rest come from parsed flags and
-// positional args. The Transformer lazy field is the per-call
+// positional args. InputPaths and OutputDir are set instead of
+// InputPath and OutputPath for --output-dir. The Transformer lazy field is the per-call
 // injection seam for tests — production wires it to a closure that
 // invokes transform.NewService(parser.NewParser(),
 // renderer.NewSerializer()); tests substitute a fake.
@@ -37,34 +43,89 @@ type adaptOptions struct {
 	Ctx         context.Context
 	InputPath   string
 	OutputPath  string
+	InputPaths  []string
+	OutputDir   string
 	Platform    string
+	Strict      bool
+	Templates   string
+	DocType     string
+	Sets        []string
+	ValuesFile  string
+	Models      core.ModelAliases
+	Output      string
 }
 
 // NewCmdAdapt creates the `adapt` command via the canonical
 // NewCmdXxx(f, runF) pattern. runF is the test-injection seam;
 // production wires it to runAdapt, tests substitute a stub.
 func NewCmdAdapt(f *cmdutil.Factory, runF func(*adaptOptions) error) *cobra.Command {
-	var platform string
+	var (
+		platform   string
+		docType    string
+		strict     bool
+		format     string
+		outputDir  string
+		sets       []string
+		valuesFile string
+	)
 
 	cmd := &cobra.Command{
-		Use:   "adapt <input> <output>",
+		Use:   "adapt <input> <output> | adapt <input>... --output-dir <dir>",
 		Short: "Transform a document to another platform",
 		Long: `Transform a document from Germinator source format to another platform's format.
 
 Supported platforms:
-  claude-code  - Claude Code document format
-  opencode     - OpenCode document format
+` + platformsHelp() + `
+
+The document type is read from a type: (or kind:) frontmatter key, the
+filename (agent-*.md, *-skill.yaml, ...), or fields only one type has;
+--type sets it explicitly. --verbose reports which rule decided.
+
+An input of - reads the document from stdin, which requires --type; an
+output of - writes the rendered document to stdout.
+
+With --output-dir, every input may be a file, a directory (walked for
+.md, .yaml, and .yml documents), or a quoted glob where ** matches any
+number of directories. Each document is written where the platform's
+layout puts it under the directory, as init does, and files are adapted
+in parallel. Documents that fail are reported together after the rest
+are written.
+
+Fields the platform cannot represent are reported as dropped; --strict
+turns any dropped field into an error and writes nothing.
 
 Example:
-  germinator adapt agent.yaml opencode-agent.md --platform opencode`,
-		Args: cobra.ExactArgs(2),
+  germinator adapt agent.yaml opencode-agent.md --platform opencode
+  germinator adapt agent.yaml opencode-agent.md --platform opencode --strict -o json
+  germinator adapt reviewer.md .claude/agents/reviewer.md --platform claude-code --type agent
+  git show HEAD:agent.md | germinator adapt - - --platform opencode --type agent
+  germinator adapt ./canonical/ --output-dir . --platform opencode
+  germinator adapt 'agents/**/*.md' --output-dir . --platform claude-code -o json`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
 			opts := &adaptOptions{
 				IO:         f.IOStreams,
 				Ctx:        c.Context(),
-				InputPath:  args[0],
-				OutputPath: args[1],
 				Platform:   platform,
+				DocType:    docType,
+				Strict:     strict,
+				Sets:       sets,
+				ValuesFile: valuesFile,
+				Output:     format,
+				Models:     configuredModels(f),
+			}
+			switch {
+			case outputDir != "":
+				opts.InputPaths, opts.OutputDir = args, outputDir
+			case len(args) == 2:
+				opts.InputPath, opts.OutputPath = args[0], args[1]
+			default:
+				return core.NewUsageError("output-dir", "expected <input> <output>, or --output-dir <dir> for several inputs")
+			}
+			if f.Config != nil {
+				if cfg, cfgErr := f.Config(); cfgErr == nil && cfg != nil {
+					opts.Templates = cfg.Templates
+				}
 			}
 			if runF != nil {
 				return runF(opts)
@@ -73,11 +134,17 @@ Example:
 		},
 	}
 
-	cmd.Flags().StringVar(&platform, "platform", "", "Target platform (required: claude-code, opencode)")
+	cmd.Flags().StringVar(&platform, "platform", "", "Target platform (required: "+strings.Join(platforms.IDs(), ", ")+")")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail instead of writing when the platform drops any field")
+	cmd.Flags().StringVar(&outputDir, "output-dir", "", "Write each input into the platform's layout under this directory")
+	addVarsFlags(cmd, &sets, &valuesFile)
+	addTypeFlag(cmd, &docType)
 	_ = cmd.MarkFlagRequired("platform")
+	output.AddOutputFlags(cmd, &format)
 
 	carapace.Gen(cmd).FlagCompletion(carapace.ActionMap{
-		"platform": actionPlatforms(f),
+		"platform":   actionPlatforms(f),
+		"output-dir": carapace.ActionDirectories(),
 	})
 
 	return cmd
@@ -86,38 +153,126 @@ Example:
 // runAdapt executes the adapt logic against the resolved options.
 // It is the production wiring for NewCmdAdapt's runF parameter.
 //
+// Dropped fields are printed as warnings on ErrOut after the "wrote"
+// line, or included in the --output json/table report. An input path
+// of - is read from stdin; an output path of - sends the document to
+// Out in place of the "wrote" line. With
+// opts.OutputDir the inputs are expanded and adapted by runAdaptBatch.
+//
 // Transformer resolution: production wires opts.Transformer to a
 // closure that calls transform.NewService(parser.NewParser(),
-// renderer.NewSerializer()); tests may inject a fake via the same
+// renderer.NewSerializer(...)) with the configured template override
+// directory; tests may inject a fake via the same
 // field. A nil opts.Transformer falls back to the production
 // constructor so callers that don't populate the field still get
 // correct behavior.
 func runAdapt(opts *adaptOptions) error {
-	if err := core.ValidatePlatform(opts.Platform); err != nil {
+	if err := platforms.Validate(opts.Platform); err != nil {
 		return fmt.Errorf("validating platform: %w", err)
 	}
-
-	opts.IO.Verbosef("transforming %s → %s", opts.InputPath, opts.OutputPath)
+	if err := validateTypeFlag(opts.DocType); err != nil {
+		return err
+	}
+	if opts.OutputDir == "" && batch.IsMulti([]string{opts.InputPath}) {
+		return core.NewUsageError("output-dir", opts.InputPath+" names several documents").
+			WithSuggestions([]string{"pass --output-dir <dir> instead of an output path"})
+	}
+	if opts.OutputPath == stdioPath && opts.Output != "" && opts.Output != output.DefaultOutputFormat {
+		return core.NewUsageError("output", "cannot write a "+opts.Output+" report to stdout along with the document")
+	}
+	vars, err := resolveVars(opts.Ctx, opts.Sets, opts.ValuesFile)
+	if err != nil {
+		return err
+	}
+	var content []byte
+	if opts.OutputDir == "" && opts.InputPath == stdioPath {
+		if content, err = readStdinDocument(opts.IO, opts.DocType); err != nil {
+			return err
+		}
+	}
 
 	resolve := opts.Transformer
 	if resolve == nil {
 		resolve = func() (Transformer, error) {
-			return transform.NewService(parser.NewParser(), renderer.NewSerializer()), nil
+			return transform.NewService(parser.NewParser(), renderer.NewSerializer(templateDirs(opts.Templates)...).WithModels(opts.Models)), nil
 		}
 	}
 	t, err := resolve()
 	if err != nil {
 		return fmt.Errorf("resolving transformer: %w", err)
 	}
+	if opts.OutputDir != "" {
+		return runAdaptBatch(opts, t, vars)
+	}
 
-	if _, err := t.Transform(opts.Ctx, &transform.Request{
+	opts.IO.Verbosef("transforming %s → %s", opts.InputPath, opts.OutputPath)
+	req := &transform.Request{
 		InputPath:  opts.InputPath,
 		OutputPath: opts.OutputPath,
 		Platform:   opts.Platform,
-	}); err != nil {
+		DocType:    opts.DocType,
+		Strict:     opts.Strict,
+		Content:    content,
+		Vars:       vars,
+	}
+	if opts.OutputPath == stdioPath {
+		req.Output = opts.IO.Out
+	}
+	result, err := t.Transform(opts.Ctx, req)
+	if err != nil {
 		return fmt.Errorf("transforming document: %w", err)
 	}
+	reportDocType(opts.IO, result.DocType, result.DetectedBy)
 
-	_, _ = fmt.Fprintf(opts.IO.Out, "wrote %s\n", opts.OutputPath)
+	report := []droppedDocument{newDroppedDocument(opts.InputPath, opts.OutputPath, result.Dropped, nil)}
+	if len(result.Warnings) > 0 {
+		report[0].Warnings = errorStrings(result.Warnings)
+	}
+	if done, err := writeDroppedReport(opts.IO, opts.Output, report); done {
+		return err
+	}
+	if req.Output == nil {
+		_, _ = fmt.Fprintf(opts.IO.Out, "wrote %s\n", opts.OutputPath)
+	}
+	warnDropped(opts.IO, opts.InputPath, result.Dropped)
+	warnAll(opts.IO, result.Warnings)
 	return nil
 }
+
+// runAdaptBatch adapts every document opts.InputPaths name into the
+// platform's layout under opts.OutputDir, several at a time
+// (batch.Run), and reports them as runConvert does a directory: one
+// entry per document, failures aggregated into a
+// *core.PartialSuccessError.
+func runAdaptBatch(opts *adaptOptions, t Transformer, vars map[string]string) error {
+	if slices.Contains(opts.InputPaths, stdioPath) {
+		return stdinUsageError()
+	}
+	files, err := batch.Expand(opts.InputPaths)
+	if err != nil {
+		return fmt.Errorf("expanding inputs: %w", err)
+	}
+	opts.IO.Verbosef("transforming %d documents → %s", len(files), opts.OutputDir)
+
+	docs, err := batch.Run(opts.Ctx, files, func(ctx context.Context, file batch.File) core.ConvertedDocument {
+		doc := core.ConvertedDocument{InputPath: file.Path}
+		result, err := t.Transform(ctx, &transform.Request{
+			InputPath: file.Path,
+			OutputDir: opts.OutputDir,
+			Platform:  opts.Platform,
+			DocType:   opts.DocType,
+			Strict:    opts.Strict,
+			Vars:      vars,
+		})
+		if err != nil {
+			doc.Error = err
+			return doc
+		}
+		doc.OutputPath, doc.DocType, doc.Dropped, doc.Warnings = result.OutputPath, result.DocType, result.Dropped, result.Warnings
+		return doc
+	})
+	if err != nil {
+		return fmt.Errorf("transforming documents: %w", err)
+	}
+	return reportDocuments(opts.IO, opts.Output, docs)
+}
@@ -3,7 +3,11 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -213,6 +217,42 @@ func TestRunAdapt_TransformerReceivesExactRequest(t *testing.T) {
 	assert.Equal(t, core.PlatformClaudeCode, fake.lastReq.Platform)
 }
 
+func TestRunAdapt_Vars(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "command-test.md")
+	require.NoError(t, os.WriteFile(input, []byte("---\nname: test\ndescription: Test {{ .Vars.project }}\n"+
+		"vars:\n  project:\n---\nRun the {{ .Vars.project }} tests.\n"), 0o600))
+	values := filepath.Join(dir, "values.yaml")
+	require.NoError(t, os.WriteFile(values, []byte("project: from-file\n"), 0o600))
+	output := filepath.Join(dir, "test.md")
+
+	io, _, _ := newAdaptTestIO()
+	require.NoError(t, runAdapt(&adaptOptions{
+		IO:         io,
+		Ctx:        context.Background(),
+		InputPath:  input,
+		OutputPath: output,
+		Platform:   core.PlatformOpenCode,
+		Sets:       []string{"project=germinator"},
+		ValuesFile: values,
+	}))
+	written, err := os.ReadFile(output) //nolint:gosec // G304: test temp file
+	require.NoError(t, err)
+	assert.Contains(t, string(written), "Run the germinator tests.", "--set wins over --values")
+
+	err = runAdapt(&adaptOptions{
+		IO:         io,
+		Ctx:        context.Background(),
+		InputPath:  input,
+		OutputPath: output,
+		Platform:   core.PlatformOpenCode,
+	})
+	var verr *core.ValidationError
+	require.ErrorAs(t, err, &verr, "a required variable without a value fails")
+}
+
 func TestNewCmdAdapt_RunFInjectionCapturesOpts(t *testing.T) {
 	var captured *adaptOptions
 	runF := func(opts *adaptOptions) error { //nolint:unparam // runF is a test callback; success is the only meaningful return
@@ -277,3 +317,303 @@ func TestNewCmdAdapt_RequiresPlatformFlag(t *testing.T) {
 // transform.NewService(parser.NewParser(), renderer.NewSerializer());
 // test fakes are injected via runAdapt directly (see fakeTransformer
 // at the top of this file).
+
+func TestRunAdapt_DroppedFields(t *testing.T) {
+	t.Parallel()
+
+	dropped := []core.FieldLoss{{Field: "targets.claude-code.skills", Value: "commit", Reason: "settings for claude-code do not apply to opencode"}}
+
+	tests := []struct {
+		name    string
+		output  string
+		wantOut string
+		wantErr string
+	}{
+		{
+			name:    "plain warns on stderr",
+			output:  "plain",
+			wantOut: "wrote /tmp/out.md\n",
+			wantErr: "Warning: /tmp/in.md: dropped targets.claude-code.skills=commit (settings for claude-code do not apply to opencode)\n",
+		},
+		{
+			name:   "json reports on stdout",
+			output: "json",
+			wantOut: `{
+  "documents": [
+    {
+      "input": "/tmp/in.md",
+      "output": "/tmp/out.md",
+      "dropped": [
+        {
+          "field": "targets.claude-code.skills",
+          "value": "commit",
+          "reason": "settings for claude-code do not apply to opencode"
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			io, out, errOut := newAdaptTestIO()
+			fake := &fakeTransformer{result: &core.TransformResult{OutputPath: "/tmp/out.md", Dropped: dropped}}
+			opts := &adaptOptions{
+				IO:          io,
+				Transformer: func() (Transformer, error) { return fake, nil },
+				Ctx:         context.Background(),
+				InputPath:   "/tmp/in.md",
+				OutputPath:  "/tmp/out.md",
+				Platform:    core.PlatformOpenCode,
+				Strict:      true,
+				Output:      tt.output,
+			}
+
+			require.NoError(t, runAdapt(opts))
+			assert.True(t, fake.lastReq.Strict, "--strict must reach the transform request")
+			assert.Equal(t, tt.wantOut, out.String())
+			assert.Equal(t, tt.wantErr, errOut.String())
+		})
+	}
+}
+
+func TestRunAdapt_DocType(t *testing.T) {
+	t.Parallel()
+
+	io, _, errOut := newAdaptTestIO()
+	io.Verbose = true
+	fake := &fakeTransformer{result: &core.TransformResult{OutputPath: "/tmp/out.md", DocType: "skill", DetectedBy: "frontmatter type: skill"}}
+	opts := &adaptOptions{
+		IO:          io,
+		Transformer: func() (Transformer, error) { return fake, nil },
+		Ctx:         context.Background(),
+		InputPath:   "/tmp/release.md",
+		OutputPath:  "/tmp/out.md",
+		Platform:    core.PlatformClaudeCode,
+	}
+
+	require.NoError(t, runAdapt(opts))
+	assert.Empty(t, fake.lastReq.DocType, "no --type leaves detection to the service")
+	assert.Contains(t, errOut.String(), "document type: skill (frontmatter type: skill)")
+
+	captured := &adaptOptions{}
+	f := cmdutil.NewFactory(context.Background(), iostreams.Test())
+	require.NoError(t, executeCmd(t, func() any {
+		cmd := NewCmdAdapt(f, func(o *adaptOptions) error { *captured = *o; return nil })
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		return cmd
+	}, "/tmp/in.md", "/tmp/out.md", "--platform", "opencode", "--type", "agent"))
+	assert.Equal(t, "agent", captured.DocType)
+}
+
+// writeBatchTree writes a canonical tree for --output-dir: two agents
+// in nested directories and a file no rule can type.
+func writeBatchTree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"agents/reviewer.md":  "---\nname: reviewer\ndescription: Reviews code\nbehavior:\n  mode: primary\n---\nReview.\n",
+		"agents/team/lead.md": "---\ntype: agent\nname: lead\ndescription: Leads\n---\nLead.\n",
+		"README.md":           "Notes.\n",
+	}
+	for rel, body := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+	}
+	return root
+}
+
+func TestRunAdapt_OutputDir(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes each document into the platform layout", func(t *testing.T) {
+		t.Parallel()
+		root := writeBatchTree(t)
+		outDir := t.TempDir()
+		io, out, _ := newAdaptTestIO()
+		opts := &adaptOptions{
+			IO:         io,
+			Ctx:        context.Background(),
+			InputPaths: []string{filepath.Join(root, "agents")},
+			OutputDir:  outDir,
+			Platform:   core.PlatformOpenCode,
+		}
+
+		require.NoError(t, runAdapt(opts))
+		assert.FileExists(t, filepath.Join(outDir, ".opencode", "agents", "reviewer.md"))
+		assert.FileExists(t, filepath.Join(outDir, ".opencode", "agents", "lead.md"))
+		assert.Equal(t, 2, strings.Count(out.String(), "wrote "))
+	})
+
+	t.Run("failures are aggregated", func(t *testing.T) {
+		t.Parallel()
+		root := writeBatchTree(t)
+		outDir := t.TempDir()
+		io, out, _ := newAdaptTestIO()
+		opts := &adaptOptions{
+			IO:         io,
+			Ctx:        context.Background(),
+			InputPaths: []string{filepath.Join(root, "**", "*.md")},
+			OutputDir:  outDir,
+			Platform:   core.PlatformClaudeCode,
+			Output:     "json",
+		}
+
+		err := runAdapt(opts)
+		var partial *core.PartialSuccessError
+		require.ErrorAs(t, err, &partial)
+		assert.Equal(t, 2, partial.Succeeded())
+		assert.Equal(t, 1, partial.Failed())
+
+		var report struct {
+			Documents []droppedDocument `json:"documents"`
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+		require.Len(t, report.Documents, 3)
+		assert.Contains(t, report.Documents[0].Error, "cannot detect document type", "README.md sorts first")
+		assert.Equal(t, "agent", report.Documents[1].Type)
+		assert.Equal(t, filepath.Join(outDir, ".claude", "agents", "reviewer.md"), report.Documents[1].Output)
+	})
+
+	t.Run("directory without --output-dir", func(t *testing.T) {
+		t.Parallel()
+		root := writeBatchTree(t)
+		io, _, _ := newAdaptTestIO()
+		fake := &fakeTransformer{}
+		opts := &adaptOptions{
+			IO:          io,
+			Transformer: func() (Transformer, error) { return fake, nil },
+			Ctx:         context.Background(),
+			InputPath:   root,
+			OutputPath:  filepath.Join(root, "out.md"),
+			Platform:    core.PlatformOpenCode,
+		}
+
+		err := runAdapt(opts)
+		var uerr *core.UsageError
+		require.ErrorAs(t, err, &uerr)
+		assert.Zero(t, fake.calls)
+	})
+}
+
+func TestNewCmdAdapt_OutputDirArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantErr    bool
+		wantInputs []string
+	}{
+		{
+			name:       "several inputs with --output-dir",
+			args:       []string{"a.md", "b.md", "--output-dir", "out", "--platform", "opencode"},
+			wantInputs: []string{"a.md", "b.md"},
+		},
+		{
+			name:    "one input without --output-dir",
+			args:    []string{"a.md", "--platform", "opencode"},
+			wantErr: true,
+		},
+		{
+			name:    "three inputs without --output-dir",
+			args:    []string{"a.md", "b.md", "c.md", "--platform", "opencode"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var captured *adaptOptions
+			f := cmdutil.NewFactory(context.Background(), iostreams.Test())
+			err := executeCmd(t, func() any {
+				cmd := NewCmdAdapt(f, func(opts *adaptOptions) error {
+					captured = opts
+					return nil
+				})
+				cmd.SetOut(&bytes.Buffer{})
+				cmd.SetErr(&bytes.Buffer{})
+				return cmd
+			}, tt.args...)
+			if tt.wantErr {
+				var uerr *core.UsageError
+				require.ErrorAs(t, err, &uerr)
+				assert.Nil(t, captured, "runF must not run on a usage error")
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, captured)
+			assert.Equal(t, tt.wantInputs, captured.InputPaths)
+			assert.Equal(t, "out", captured.OutputDir)
+		})
+	}
+}
+
+func TestRunAdapt_Stdio(t *testing.T) {
+	t.Parallel()
+
+	const agent = "---\nname: reviewer\ndescription: Reviews code\n---\nReview.\n"
+
+	t.Run("stdin to stdout", func(t *testing.T) {
+		t.Parallel()
+		io, out, errOut := newAdaptTestIO()
+		io.In = strings.NewReader(agent)
+		opts := &adaptOptions{
+			IO:         io,
+			Ctx:        context.Background(),
+			InputPath:  "-",
+			OutputPath: "-",
+			Platform:   core.PlatformOpenCode,
+			DocType:    "agent",
+		}
+
+		require.NoError(t, runAdapt(opts))
+		assert.True(t, strings.HasPrefix(out.String(), "---\ndescription: Reviews code\n"), "stdout holds the rendered document")
+		assert.NotContains(t, out.String(), "wrote ")
+		assert.Empty(t, errOut.String())
+	})
+
+	tests := []struct {
+		name    string
+		in      string
+		output  string
+		docType string
+		inputs  []string
+	}{
+		{name: "stdin without --type", in: agent},
+		{name: "empty stdin", in: "\n", docType: "agent"},
+		{name: "json report to stdout", in: agent, docType: "agent", output: "json"},
+		{name: "stdin among --output-dir inputs", in: agent, inputs: []string{"-", "agents"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			io, out, _ := newAdaptTestIO()
+			io.In = strings.NewReader(tt.in)
+			fake := &fakeTransformer{}
+			opts := &adaptOptions{
+				IO:          io,
+				Transformer: func() (Transformer, error) { return fake, nil },
+				Ctx:         context.Background(),
+				InputPath:   "-",
+				OutputPath:  "-",
+				Platform:    core.PlatformOpenCode,
+				DocType:     tt.docType,
+				Output:      tt.output,
+			}
+			if tt.inputs != nil {
+				opts.InputPaths, opts.OutputDir = tt.inputs, t.TempDir()
+			}
+
+			err := runAdapt(opts)
+			var uerr *core.UsageError
+			require.ErrorAs(t, err, &uerr)
+			assert.Zero(t, fake.calls)
+			assert.Empty(t, out.String())
+		})
+	}
+}
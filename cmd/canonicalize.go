@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/carapace-sh/carapace"
 	"github.com/spf13/cobra"
@@ -11,6 +12,7 @@ import (
 	"gitlab.com/amoconst/germinator/internal/cmdutil"
 	"gitlab.com/amoconst/germinator/internal/core"
 	"gitlab.com/amoconst/germinator/internal/iostreams"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 )
 
 // Canonicalizer is the local command-side contract for document
@@ -36,6 +38,8 @@ type canonicalizeOptions struct {
 	OutputPath    string
 	Platform      string
 	DocType       string
+	Name          string
+	Models        core.ModelAliases
 }
 
 // NewCmdCanonicalize creates the `canonicalize` command via the
@@ -43,7 +47,7 @@ type canonicalizeOptions struct {
 // runF is the test-injection seam; production wires it to
 // runCanonicalize, tests substitute a stub.
 func NewCmdCanonicalize(f *cmdutil.Factory, runF func(*canonicalizeOptions) error) *cobra.Command {
-	var platform, docType string
+	var platform, docType, name string
 
 	cmd := &cobra.Command{
 		Use:   "canonicalize <input> <output>",
@@ -51,17 +55,22 @@ func NewCmdCanonicalize(f *cmdutil.Factory, runF func(*canonicalizeOptions) erro
 		Long: fmt.Sprintf(`Convert a platform document to canonical YAML format.
 
 Supported platforms:
-  %s - Claude Code document format
-  %s    - OpenCode document format
+%s
 
 Supported document types:
   agent   - Agent configuration
   command - Command configuration
   skill   - Skill configuration
   memory  - Memory configuration
+  mcp     - MCP server (pass --name when the file defines several)
 
-Example:
-  germinator canonicalize agent.md canonical-agent.yaml --platform %s --type agent`, core.PlatformClaudeCode, core.PlatformOpenCode, core.PlatformOpenCode),
+An input of - reads the platform document from stdin, and an output of
+- writes the canonical document to stdout.
+
+Examples:
+  germinator canonicalize agent.md canonical-agent.yaml --platform %s --type agent
+  germinator canonicalize .mcp.json mcp-github.md --platform %s --type mcp --name github
+  cat .claude/agents/reviewer.md | germinator canonicalize - - --platform %s --type agent`, platformsHelp(), core.PlatformOpenCode, core.PlatformClaudeCode, core.PlatformClaudeCode),
 		Args: cobra.ExactArgs(2),
 		RunE: func(c *cobra.Command, args []string) error {
 			opts := &canonicalizeOptions{
@@ -71,6 +80,8 @@ Example:
 				OutputPath: args[1],
 				Platform:   platform,
 				DocType:    docType,
+				Name:       name,
+				Models:     configuredModels(f),
 			}
 			if runF != nil {
 				return runF(opts)
@@ -79,14 +90,15 @@ Example:
 		},
 	}
 
-	cmd.Flags().StringVar(&platform, "platform", "", fmt.Sprintf("Source platform (required: %s, %s)", core.PlatformClaudeCode, core.PlatformOpenCode))
-	cmd.Flags().StringVar(&docType, "type", "", "Document type (required: agent, command, skill, memory)")
+	cmd.Flags().StringVar(&platform, "platform", "", fmt.Sprintf("Source platform (required: %s)", strings.Join(platforms.IDs(), ", ")))
+	cmd.Flags().StringVar(&docType, "type", "", "Document type (required: agent, command, skill, memory, mcp)")
+	cmd.Flags().StringVar(&name, "name", "", "MCP server to canonicalize when the input file defines several")
 	_ = cmd.MarkFlagRequired("platform")
 	_ = cmd.MarkFlagRequired("type")
 
 	carapace.Gen(cmd).FlagCompletion(carapace.ActionMap{
 		"platform": actionPlatforms(f),
-		"type":     carapace.ActionValuesDescribed("agent", "command", "skill", "memory"),
+		"type":     carapace.ActionValues("agent", "command", "skill", "memory", "mcp"),
 	})
 
 	return cmd
@@ -104,12 +116,15 @@ Example:
 // "skills" or empty string); MarkFlagRequired only catches the
 // missing-flag case.
 //
+// An input path of - is read from stdin; an output path of - sends the
+// canonical document to Out in place of the success line.
+//
 // Canonicalizer resolution: production wires opts.Canonicalizer to a
 // closure that calls canonicalize.NewService(); tests may inject a
 // fake via the same field. A nil opts.Canonicalizer falls back to
 // the production constructor.
 func runCanonicalize(opts *canonicalizeOptions) error {
-	if err := core.ValidatePlatform(opts.Platform); err != nil {
+	if err := platforms.Validate(opts.Platform); err != nil {
 		return fmt.Errorf("validating platform: %w", err)
 	}
 	if err := core.ValidateDocumentType(opts.DocType); err != nil {
@@ -119,6 +134,25 @@ func runCanonicalize(opts *canonicalizeOptions) error {
 	opts.IO.Verbosef("canonicalizing %s → %s (platform: %s, type: %s)",
 		opts.InputPath, opts.OutputPath, opts.Platform, opts.DocType)
 
+	req := &canonicalize.Request{
+		InputPath:  opts.InputPath,
+		OutputPath: opts.OutputPath,
+		Platform:   opts.Platform,
+		DocType:    opts.DocType,
+		Name:       opts.Name,
+		Models:     opts.Models,
+	}
+	if opts.InputPath == stdioPath {
+		content, err := readStdin(opts.IO)
+		if err != nil {
+			return err
+		}
+		req.Content = content
+	}
+	if opts.OutputPath == stdioPath {
+		req.Output = opts.IO.Out
+	}
+
 	resolve := opts.Canonicalizer
 	if resolve == nil {
 		resolve = func() (Canonicalizer, error) { return canonicalize.NewService(), nil }
@@ -128,15 +162,13 @@ func runCanonicalize(opts *canonicalizeOptions) error {
 		return fmt.Errorf("resolving canonicalizer: %w", err)
 	}
 
-	if _, err := c.Canonicalize(opts.Ctx, &canonicalize.Request{
-		InputPath:  opts.InputPath,
-		OutputPath: opts.OutputPath,
-		Platform:   opts.Platform,
-		DocType:    opts.DocType,
-	}); err != nil {
+	if _, err := c.Canonicalize(opts.Ctx, req); err != nil {
 		return fmt.Errorf("canonicalizing document: %w", err)
 	}
 
+	if req.Output != nil {
+		return nil
+	}
 	_, _ = fmt.Fprintf(opts.IO.Out, "Successfully canonicalized document to: %s\n", opts.OutputPath)
 	return nil
 }
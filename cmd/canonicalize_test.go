@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -397,3 +398,24 @@ func TestCanonicalizeService_AdapterSatisfiesInterface(t *testing.T) {
 	require.True(t, errors.As(err, &perr),
 		"fatal error must wrap *core.ParseError")
 }
+
+func TestRunCanonicalize_Stdio(t *testing.T) {
+	t.Parallel()
+
+	io, out, errOut := newCanonicalizeTestIO()
+	io.In = strings.NewReader("---\nname: reviewer\ndescription: Reviews code\n---\nReview.\n")
+	opts := &canonicalizeOptions{
+		IO:         io,
+		Ctx:        context.Background(),
+		InputPath:  "-",
+		OutputPath: "-",
+		Platform:   core.PlatformClaudeCode,
+		DocType:    "agent",
+	}
+
+	require.NoError(t, runCanonicalize(opts))
+	assert.Contains(t, out.String(), "name: reviewer\n")
+	assert.Contains(t, out.String(), "description: Reviews code\n")
+	assert.NotContains(t, out.String(), "Successfully canonicalized")
+	assert.Empty(t, errOut.String())
+}
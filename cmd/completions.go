@@ -10,9 +10,9 @@ import (
 
 	"gitlab.com/amoconst/germinator/internal/cmdutil"
 	"gitlab.com/amoconst/germinator/internal/config"
-	"gitlab.com/amoconst/germinator/internal/core"
 	"gitlab.com/amoconst/germinator/internal/library"
 	"gitlab.com/amoconst/germinator/internal/paths"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 )
 
 // getCompletionTimeout parses the timeout from config, returning a default if invalid.
@@ -78,13 +78,16 @@ func expandTildeForCompletion(path string) string {
 	return expanded
 }
 
-// actionPlatforms returns a static completion action for platform values.
-// The Factory parameter is reserved for future use; static values today.
+// actionPlatforms returns a completion action listing every registered
+// platform with its description. The Factory parameter is reserved for
+// future use.
 func actionPlatforms(_ *cmdutil.Factory) carapace.Action {
-	return carapace.ActionValuesDescribed(
-		core.PlatformClaudeCode, "Claude Code document format",
-		core.PlatformOpenCode, "OpenCode document format",
-	)
+	all := platforms.All()
+	values := make([]string, 0, 2*len(all))
+	for _, p := range all {
+		values = append(values, p.ID(), p.Description())
+	}
+	return carapace.ActionValuesDescribed(values...)
 }
 
 // loadLibraryForCompletion returns the library for libPath, consulting
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/carapace-sh/carapace"
+	"github.com/spf13/cobra"
+
+	"gitlab.com/amoconst/germinator/internal/cmdutil"
+	"gitlab.com/amoconst/germinator/internal/convert"
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/iostreams"
+	"gitlab.com/amoconst/germinator/internal/output"
+	"gitlab.com/amoconst/germinator/internal/platforms"
+)
+
+// Converter is the local command-side contract for platform-to-platform
+// conversion. The method signature matches convert.Service exactly so
+// the production service satisfies it via structural typing.
+type Converter interface {
+	Convert(ctx context.Context, req *convert.Request) (*core.ConvertResult, error)
+}
+
+// convertOptions holds the runtime state for a `convert` invocation.
+// The Converter lazy field is the per-call injection seam for tests;
+// production wires it to convert.NewService().
+type convertOptions struct {
+	IO         *iostreams.IOStreams
+	Converter  func() (Converter, error)
+	Ctx        context.Context
+	InputPath  string
+	OutputPath string
+	From       string
+	To         string
+	DocType    string
+	Name       string
+	Strict     bool
+	Sets       []string
+	ValuesFile string
+	Output     string
+	Models     core.ModelAliases
+}
+
+// NewCmdConvert creates the `convert` command via the canonical
+// NewCmdXxx(f, runF) pattern. runF is the test-injection seam;
+// production wires it to runConvert, tests substitute a stub.
+func NewCmdConvert(f *cmdutil.Factory, runF func(*convertOptions) error) *cobra.Command {
+	var (
+		from, to, docType, name, format string
+		strict                          bool
+		sets                            []string
+		valuesFile                      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "convert <input> <output>",
+		Short: "Convert a document directly between platforms",
+		Long: fmt.Sprintf(`Convert platform documents directly to another platform, without an
+intermediate canonical file.
+
+When <input> is a file, it is converted to the file <output>. The document
+type is inferred from the source platform's layout (e.g. .claude/agents/);
+pass --type when the file lives elsewhere or the layout is shared.
+
+When <input> is a directory, it is read as a project root: every document in
+the source platform's layout is converted into the target platform's layout
+under the <output> directory.
+
+Fields the target platform cannot represent are reported as dropped;
+--strict turns any dropped field into an error for that document.
+
+Supported platforms:
+%s
+
+Examples:
+  germinator convert .claude/agents/reviewer.md reviewer.md --from %s --to %s
+  germinator convert . . --from %s --to %s`,
+			platformsHelp(), core.PlatformClaudeCode, core.PlatformOpenCode, core.PlatformClaudeCode, core.PlatformCursor),
+		Args: cobra.ExactArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := &convertOptions{
+				IO:         f.IOStreams,
+				Ctx:        c.Context(),
+				InputPath:  args[0],
+				OutputPath: args[1],
+				From:       from,
+				To:         to,
+				DocType:    docType,
+				Name:       name,
+				Strict:     strict,
+				Sets:       sets,
+				ValuesFile: valuesFile,
+				Output:     format,
+				Models:     configuredModels(f),
+			}
+			if runF != nil {
+				return runF(opts)
+			}
+			return runConvert(opts)
+		},
+	}
+
+	ids := strings.Join(platforms.IDs(), ", ")
+	cmd.Flags().StringVar(&from, "from", "", "Source platform (required: "+ids+")")
+	cmd.Flags().StringVar(&to, "to", "", "Target platform (required: "+ids+")")
+	cmd.Flags().StringVar(&docType, "type", "", "Document type (agent, command, skill, memory, mcp); inferred from the source layout when omitted")
+	cmd.Flags().StringVar(&name, "name", "", "MCP server to convert when the input file defines several")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail a document instead of writing it when the target drops any field")
+	addVarsFlags(cmd, &sets, &valuesFile)
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	output.AddOutputFlags(cmd, &format)
+
+	carapace.Gen(cmd).FlagCompletion(carapace.ActionMap{
+		"from": actionPlatforms(f),
+		"to":   actionPlatforms(f),
+		"type": carapace.ActionValues("agent", "command", "skill", "memory", "mcp"),
+	})
+
+	return cmd
+}
+
+// runConvert executes the convert logic against the resolved options.
+// Converted files go to Out and dropped fields to ErrOut as warnings,
+// or both into the --output json/table report. In directory mode
+// per-document failures are aggregated into a *core.PartialSuccessError,
+// as init does.
+func runConvert(opts *convertOptions) error {
+	if err := platforms.Validate(opts.From); err != nil {
+		return fmt.Errorf("validating source platform: %w", err)
+	}
+	if err := platforms.Validate(opts.To); err != nil {
+		return fmt.Errorf("validating target platform: %w", err)
+	}
+	if opts.DocType != "" {
+		if err := core.ValidateDocumentType(opts.DocType); err != nil {
+			return fmt.Errorf("validating document type: %w", err)
+		}
+	}
+
+	vars, err := resolveVars(opts.Ctx, opts.Sets, opts.ValuesFile)
+	if err != nil {
+		return err
+	}
+
+	opts.IO.Verbosef("converting %s → %s (%s → %s)", opts.InputPath, opts.OutputPath, opts.From, opts.To)
+
+	resolve := opts.Converter
+	if resolve == nil {
+		resolve = func() (Converter, error) { return convert.NewService(), nil }
+	}
+	c, err := resolve()
+	if err != nil {
+		return fmt.Errorf("resolving converter: %w", err)
+	}
+
+	result, err := c.Convert(opts.Ctx, &convert.Request{
+		InputPath:  opts.InputPath,
+		OutputPath: opts.OutputPath,
+		From:       opts.From,
+		To:         opts.To,
+		DocType:    opts.DocType,
+		Name:       opts.Name,
+		Strict:     opts.Strict,
+		Models:     opts.Models,
+		Vars:       vars,
+	})
+	if err != nil {
+		return fmt.Errorf("converting document: %w", err)
+	}
+
+	return reportDocuments(opts.IO, opts.Output, result.Documents)
+}
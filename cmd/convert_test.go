@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/amoconst/germinator/internal/cmdutil"
+	"gitlab.com/amoconst/germinator/internal/convert"
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/iostreams"
+)
+
+// fakeConverter satisfies the local cmd.Converter interface, recording
+// the last request and returning the configured result.
+type fakeConverter struct {
+	calls   int
+	lastReq *convert.Request
+	result  *core.ConvertResult
+	err     error
+}
+
+func (f *fakeConverter) Convert(_ context.Context, req *convert.Request) (*core.ConvertResult, error) {
+	f.calls++
+	f.lastReq = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.result, nil
+}
+
+func newConvertOptions(fake *fakeConverter) *convertOptions {
+	io, _, _ := newAdaptTestIO()
+	return &convertOptions{
+		IO:         io,
+		Converter:  func() (Converter, error) { return fake, nil },
+		Ctx:        context.Background(),
+		InputPath:  "in.md",
+		OutputPath: "out.md",
+		From:       core.PlatformClaudeCode,
+		To:         core.PlatformGemini,
+	}
+}
+
+func TestRunConvert_ReportsDroppedFields(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeConverter{result: &core.ConvertResult{Documents: []core.ConvertedDocument{{
+		DocType:    "command",
+		InputPath:  "in.md",
+		OutputPath: "out.md",
+		Dropped:    []core.FieldLoss{{Field: "model", Value: "sonnet", Reason: "not supported"}},
+	}}}}
+	opts := newConvertOptions(fake)
+	io, out, errOut := newAdaptTestIO()
+	opts.IO = io
+
+	require.NoError(t, runConvert(opts))
+
+	require.NotNil(t, fake.lastReq)
+	assert.Equal(t, core.PlatformClaudeCode, fake.lastReq.From)
+	assert.Equal(t, core.PlatformGemini, fake.lastReq.To)
+	assert.Equal(t, "wrote out.md\n", out.String())
+	assert.Equal(t, "Warning: in.md: dropped model=sonnet (not supported)\n", errOut.String())
+}
+
+func TestRunConvert_PartialFailure(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeConverter{result: &core.ConvertResult{Documents: []core.ConvertedDocument{
+		{DocType: "memory", InputPath: "a.md", OutputPath: "AGENTS.md"},
+		{DocType: "agent", InputPath: "b.md", Error: errors.New("codex has no agents")},
+	}}}
+
+	err := runConvert(newConvertOptions(fake))
+
+	var partial *core.PartialSuccessError
+	require.True(t, errors.As(err, &partial))
+	assert.Equal(t, 1, partial.Succeeded())
+	assert.Equal(t, 1, partial.Failed())
+}
+
+func TestRunConvert_InvalidInputs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(*convertOptions)
+	}{
+		{"unknown source platform", func(o *convertOptions) { o.From = "vim" }},
+		{"unknown target platform", func(o *convertOptions) { o.To = "" }},
+		{"unknown document type", func(o *convertOptions) { o.DocType = "agents" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fake := &fakeConverter{}
+			opts := newConvertOptions(fake)
+			tt.modify(opts)
+
+			var valErr *core.ValidationError
+			require.True(t, errors.As(runConvert(opts), &valErr))
+			assert.Zero(t, fake.calls, "converter must not run on invalid input")
+		})
+	}
+}
+
+func TestNewCmdConvert_RunFInjectionCapturesOpts(t *testing.T) {
+	var captured *convertOptions
+	runF := func(opts *convertOptions) error { //nolint:unparam // runF is a test callback; success is the only meaningful return
+		captured = opts
+		return nil
+	}
+
+	f := cmdutil.NewFactory(context.Background(), iostreams.Test())
+	require.NoError(t, executeCmd(t, func() any {
+		cmd := NewCmdConvert(f, runF)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		return cmd
+	}, "in", "out", "--from", "cursor", "--to", "copilot", "--type", "memory"))
+	require.NotNil(t, captured, "runF must be invoked")
+	assert.Equal(t, "in", captured.InputPath)
+	assert.Equal(t, "out", captured.OutputPath)
+	assert.Equal(t, core.PlatformCursor, captured.From)
+	assert.Equal(t, core.PlatformCopilot, captured.To)
+	assert.Equal(t, "memory", captured.DocType)
+}
@@ -5,6 +5,7 @@
 //	adapt         - Transform a document to another platform format
 //	validate      - Validate a document against platform rules
 //	canonicalize  - Convert a platform document to canonical format
+//	convert       - Convert documents directly between platforms
 //	version       - Display version, commit, and build date
 //	library       - Manage the canonical resource library
 //	init          - Install resources from library to project
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/carapace-sh/carapace"
+	"github.com/spf13/cobra"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/iostreams"
+)
+
+// addTypeFlag registers --type, which skips document type detection.
+func addTypeFlag(cmd *cobra.Command, docType *string) {
+	cmd.Flags().StringVar(docType, "type", "", "Document type ("+strings.Join(core.ResourceTypes(), ", ")+"); detected from the frontmatter or filename when omitted")
+	carapace.Gen(cmd).FlagCompletion(carapace.ActionMap{
+		"type": carapace.ActionValues(core.ResourceTypes()...),
+	})
+}
+
+// validateTypeFlag checks a --type value; an empty one is valid.
+func validateTypeFlag(docType string) error {
+	if docType == "" {
+		return nil
+	}
+	if err := core.ValidateDocumentType(docType); err != nil {
+		return fmt.Errorf("validating document type: %w", err)
+	}
+	return nil
+}
+
+// reportDocType tells verbose users which type the document was read as
+// and which detection rule decided it.
+func reportDocType(io *iostreams.IOStreams, docType, detectedBy string) {
+	if docType == "" {
+		return
+	}
+	if detectedBy == "" {
+		detectedBy = "--type"
+	}
+	io.Verbosef("document type: %s (%s)", docType, detectedBy)
+}
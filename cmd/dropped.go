@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/iostreams"
+	"gitlab.com/amoconst/germinator/internal/output"
+)
+
+// droppedDocument is the --output json shape for one rendered document
+// of adapt, convert, and init. Dropped is always present (possibly
+// empty) so consumers can test for loss without a nil check.
+type droppedDocument struct {
+	Ref      string           `json:"ref,omitempty"`
+	Type     string           `json:"type,omitempty"`
+	Input    string           `json:"input"`
+	Output   string           `json:"output,omitempty"`
+	Dropped  []core.FieldLoss `json:"dropped"`
+	Warnings []string         `json:"warnings,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// droppedRow is the --output table shape: one row per dropped field.
+type droppedRow struct {
+	Source string `tab:"SOURCE"`
+	Field  string `tab:"FIELD"`
+	Value  string `tab:"VALUE"`
+	Reason string `tab:"REASON"`
+}
+
+// newDroppedDocument builds the JSON entry for one document.
+func newDroppedDocument(input, outputPath string, dropped []core.FieldLoss, err error) droppedDocument {
+	doc := droppedDocument{Input: input, Output: outputPath, Dropped: dropped}
+	if doc.Dropped == nil {
+		doc.Dropped = []core.FieldLoss{}
+	}
+	if err != nil {
+		doc.Error = err.Error()
+	}
+	return doc
+}
+
+// writeDroppedReport renders docs in a structured format: the JSON
+// payload {"documents": [...]} or a table of dropped fields. It returns
+// false for plain output, which callers print line by line instead.
+func writeDroppedReport(io *iostreams.IOStreams, format string, docs []droppedDocument) (bool, error) {
+	switch format {
+	case "json":
+		if err := output.NewJSONExporter().Write(io, struct {
+			Documents []droppedDocument `json:"documents"`
+		}{Documents: docs}); err != nil {
+			return true, fmt.Errorf("writing json output: %w", err)
+		}
+		return true, nil
+	case "table":
+		rows := []droppedRow{}
+		for _, d := range docs {
+			for _, l := range d.Dropped {
+				rows = append(rows, droppedRow{Source: d.Input, Field: l.Field, Value: l.Value, Reason: l.Reason})
+			}
+		}
+		if err := output.NewTableExporter().Write(io, rows); err != nil {
+			return true, fmt.Errorf("writing table output: %w", err)
+		}
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// warnDropped writes one warning per dropped field of source to ErrOut.
+func warnDropped(io *iostreams.IOStreams, source string, dropped []core.FieldLoss) {
+	for _, l := range dropped {
+		io.Warnf("%s: dropped %s=%s (%s)", source, l.Field, l.Value, l.Reason)
+	}
+}
+
+// warnAll writes each of warnings, such as the unknown frontmatter keys
+// of a document (parser.Options.Warn), to ErrOut.
+func warnAll(io *iostreams.IOStreams, warnings []error) {
+	for _, w := range warnings {
+		io.Warnf("%v", w)
+	}
+}
+
+// errorStrings returns the messages of errs, an empty slice for none.
+func errorStrings(errs []error) []string {
+	out := make([]string, 0, len(errs))
+	for _, e := range errs {
+		out = append(out, e.Error())
+	}
+	return out
+}
+
+// reportDocuments reports a multi-document run: the --output json/table
+// report, or for plain output a "wrote" line and the dropped-field and
+// unknown-field warnings of every written document. Failed documents are aggregated
+// into a *core.PartialSuccessError.
+func reportDocuments(io *iostreams.IOStreams, format string, docs []core.ConvertedDocument) error {
+	var succeeded, failed int
+	var errs []core.InitializeError
+	report := make([]droppedDocument, 0, len(docs))
+	for _, doc := range docs {
+		entry := newDroppedDocument(doc.InputPath, doc.OutputPath, doc.Dropped, doc.Error)
+		entry.Type = doc.DocType
+		if len(doc.Warnings) > 0 {
+			entry.Warnings = errorStrings(doc.Warnings)
+		}
+		report = append(report, entry)
+		if doc.Error != nil {
+			failed++
+			errs = append(errs, *core.NewInitializeError(doc.InputPath, doc.InputPath, doc.OutputPath, doc.Error))
+			continue
+		}
+		succeeded++
+	}
+
+	done, err := writeDroppedReport(io, format, report)
+	if err != nil {
+		return err
+	}
+	if !done {
+		for _, doc := range docs {
+			if doc.Error == nil {
+				_, _ = fmt.Fprintf(io.Out, "wrote %s\n", doc.OutputPath)
+				warnDropped(io, doc.InputPath, doc.Dropped)
+				warnAll(io, doc.Warnings)
+			}
+		}
+	}
+	if failed > 0 {
+		return core.NewPartialSuccessError(succeeded, failed, errs)
+	}
+	return nil
+}
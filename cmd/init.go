@@ -14,7 +14,9 @@ import (
 	"gitlab.com/amoconst/germinator/internal/install"
 	"gitlab.com/amoconst/germinator/internal/iostreams"
 	"gitlab.com/amoconst/germinator/internal/library"
+	"gitlab.com/amoconst/germinator/internal/output"
 	"gitlab.com/amoconst/germinator/internal/parser"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 	"gitlab.com/amoconst/germinator/internal/renderer"
 )
 
@@ -43,6 +45,13 @@ type initOptions struct {
 	Preset      string
 	DryRun      bool
 	Force       bool
+	Strict      bool
+	Scope       string
+	Templates   string
+	Output      string
+	Sets        []string
+	ValuesFile  string
+	Models      core.ModelAliases
 }
 
 // NewCmdInit creates the `init` command via the canonical
@@ -60,6 +69,11 @@ func NewCmdInit(f *cmdutil.Factory, runF func(*initOptions) error) *cobra.Comman
 		outputDir   string
 		dryRun      bool
 		force       bool
+		strict      bool
+		scope       string
+		format      string
+		sets        []string
+		valuesFile  string
 	)
 
 	cmd := &cobra.Command{
@@ -72,6 +86,24 @@ format and written to platform-specific output paths.
 
 Either --resources or --preset must be specified (mutually exclusive).
 
+Fields a platform cannot represent are reported as dropped; with --strict
+a resource that would drop any field fails and is not written.
+
+Settings and hooks are merged into the platform's existing settings file.
+A setting that differs from the file is reported as a conflict and the file
+is left unchanged; --force takes the resource's value instead. With
+--scope local they go to the personal settings file where the platform has
+one (Claude Code's .claude/settings.local.json).
+
+Output is rendered from the bundled templates unless overridden: a resource's
+own template in library.yaml comes first, then the library's templates
+directory, then the templates directory from the config file (see
+"germinator templates").
+
+Resources that declare variables under vars: take their values from --set
+and --values (--set wins), falling back to the declared defaults; a required
+variable without a value fails the resource.
+
 Examples:
   # Install specific resources
   germinator init --platform opencode --resources skill/commit,skill/merge-request
@@ -83,7 +115,16 @@ Examples:
   germinator init --platform opencode --preset git-workflow --dry-run
 
   # Overwrite existing files
-  germinator init --platform opencode --resources skill/commit --force`,
+  germinator init --platform opencode --resources skill/commit --force
+
+  # Install personal settings that stay out of version control
+  germinator init --platform claude-code --resources settings/team --scope local
+
+  # Fill in the variables resources declare
+  germinator init --platform claude-code --preset go --set test_command="make test"
+
+  # Refuse resources that lose fields on the platform, report as JSON
+  germinator init --platform cursor --preset git-workflow --strict -o json`,
 		Args: cobra.NoArgs,
 		RunE: func(c *cobra.Command, _ []string) error {
 			opts := &initOptions{
@@ -96,11 +137,18 @@ Examples:
 				Preset:      preset,
 				DryRun:      dryRun,
 				Force:       force,
+				Strict:      strict,
+				Scope:       scope,
+				Output:      format,
+				Sets:        sets,
+				ValuesFile:  valuesFile,
+				Models:      configuredModels(f),
 			}
 			var cfgPath string
 			if f.Config != nil {
 				if cfg, cfgErr := f.Config(); cfgErr == nil && cfg != nil {
 					cfgPath = cfg.Library
+					opts.Templates = cfg.Templates
 				}
 			}
 			resolved := library.FindLibrary(libraryPath, os.Getenv("GERMINATOR_LIBRARY"), cfgPath)
@@ -114,13 +162,17 @@ Examples:
 		},
 	}
 
-	cmd.Flags().StringVar(&platform, "platform", "", "Target platform (required: opencode, claude-code)")
+	cmd.Flags().StringVar(&platform, "platform", "", "Target platform (required: "+strings.Join(platforms.IDs(), ", ")+")")
 	cmd.Flags().StringSliceVar(&resources, "resources", nil, "Comma-separated list of resources to install (e.g., skill/commit,skill/merge-request)")
 	cmd.Flags().StringVar(&preset, "preset", "", "Preset name for bundled resources")
 	cmd.Flags().StringVar(&libraryPath, "library", "", "Path to library directory (default: "+library.DefaultLibraryPath()+")")
 	cmd.Flags().StringVar(&outputDir, "output-dir", ".", "Output directory (default: current directory)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview changes without writing files")
 	cmd.Flags().BoolVar(&force, "force", false, "Overwrite existing files")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail resources the platform would drop fields from")
+	cmd.Flags().StringVar(&scope, "scope", string(core.ScopeProject), "Settings file for settings and hooks (project, local)")
+	addVarsFlags(cmd, &sets, &valuesFile)
+	output.AddOutputFlags(cmd, &format)
 
 	_ = cmd.MarkFlagRequired("platform")
 
@@ -128,6 +180,7 @@ Examples:
 		"platform":  actionPlatforms(f),
 		"resources": actionResources(f, cmd),
 		"preset":    actionPresets(f, cmd),
+		"scope":     carapace.ActionValues(string(core.ScopeProject), string(core.ScopeLocal)),
 	})
 
 	return cmd
@@ -138,14 +191,15 @@ Examples:
 //
 // Validation order (matches proposal.md decision matrix):
 //  1. Refs XOR Preset (mutex per base spec).
-//  2. Platform validated via core.ValidatePlatform.
+//  2. Platform validated via platforms.Validate, then Scope (when set)
+//     via core.ValidateScope.
 //  3. If Preset != "", expand via (*Library).ResolvePreset; on miss
 //     (*Library).ResolvePreset returns *core.NotFoundError directly
 //     (Phase 3.3 migration); runInit returns it as-is so
 //     cmdutil.ExitCodeFor maps it to ExitCodeError (1).
 //  4. Build *install.Request; invoke
 //     install.NewService(parser.NewParser(),
-//     renderer.NewSerializer()).Initialize.
+//     renderer.NewSerializer(templateDirs(opts.Templates)...)).Initialize.
 //  5. Count successes/failures from the result slice.
 //  6. Render per-resource status; return nil or *core.PartialSuccessError.
 func runInit(opts *initOptions) error {
@@ -158,9 +212,19 @@ func runInit(opts *initOptions) error {
 		return core.NewValidationError("init", "resources/preset", "", "either --resources or --preset is required")
 	}
 
-	if err := core.ValidatePlatform(opts.Platform); err != nil {
+	if err := platforms.Validate(opts.Platform); err != nil {
 		return fmt.Errorf("validating platform: %w", err)
 	}
+	if opts.Scope != "" {
+		if err := core.ValidateScope(opts.Scope); err != nil {
+			return fmt.Errorf("validating scope: %w", err)
+		}
+	}
+
+	vars, err := resolveVars(opts.Ctx, opts.Sets, opts.ValuesFile)
+	if err != nil {
+		return err
+	}
 
 	lib, err := opts.Library()
 	if err != nil {
@@ -181,7 +245,7 @@ func runInit(opts *initOptions) error {
 
 	opts.IO.Verbosef("installing resources: %s", strings.Join(refs, ", "))
 
-	svc := install.NewService(parser.NewParser(), renderer.NewSerializer())
+	svc := install.NewService(parser.NewParser(), renderer.NewSerializer(templateDirs(opts.Templates)...).WithModels(opts.Models))
 	results, err := svc.Initialize(opts.Ctx, &install.Request{
 		Library:   lib,
 		Platform:  opts.Platform,
@@ -189,6 +253,9 @@ func runInit(opts *initOptions) error {
 		Refs:      refs,
 		DryRun:    opts.DryRun,
 		Force:     opts.Force,
+		Strict:    opts.Strict,
+		Scope:     core.Scope(opts.Scope),
+		Vars:      vars,
 	})
 	if err != nil {
 		return fmt.Errorf("initializing resources: %w", err)
@@ -196,7 +263,9 @@ func runInit(opts *initOptions) error {
 
 	succeeded, failed, initErrs := classifyResults(results)
 
-	renderResults(opts, results)
+	if err := renderResults(opts, results); err != nil {
+		return err
+	}
 
 	switch {
 	case failed == 0:
@@ -231,15 +300,30 @@ func classifyResults(results []core.InitializeResult) (succeeded, failed int, er
 // return; main.go renders the returned *core.PartialSuccessError
 // once via output.FormatError (single-handling rule per
 // cmd/AGENTS.md).
-func renderResults(opts *initOptions, results []core.InitializeResult) {
+//
+// Dropped fields are written as warnings to ErrOut, one per field and
+// prefixed with the resource ref. With --output json or table the
+// per-resource report replaces the plain lines.
+func renderResults(opts *initOptions, results []core.InitializeResult) error {
+	report := make([]droppedDocument, 0, len(results))
+	for _, r := range results {
+		entry := newDroppedDocument(r.InputPath, r.OutputPath, r.Dropped, r.Error)
+		entry.Ref = r.Ref
+		report = append(report, entry)
+	}
+	if done, err := writeDroppedReport(opts.IO, opts.Output, report); done {
+		return err
+	}
+
 	for _, r := range results {
 		if r.Error == nil {
 			if opts.DryRun {
 				_, _ = fmt.Fprintf(opts.IO.Out, "Would write: %s\n  from: %s\n", r.OutputPath, r.InputPath)
-				continue
+			} else {
+				_, _ = fmt.Fprintf(opts.IO.Out, "Installed: %s -> %s\n", r.Ref, r.OutputPath)
 			}
-			_, _ = fmt.Fprintf(opts.IO.Out, "Installed: %s -> %s\n", r.Ref, r.OutputPath)
 		}
+		warnDropped(opts.IO, r.Ref, r.Dropped)
 	}
 	if opts.DryRun && len(results) > 0 {
 		_, _ = fmt.Fprintln(opts.IO.Out, "Dry run complete. No files were written.")
@@ -257,4 +341,5 @@ func renderResults(opts *initOptions, results []core.InitializeResult) {
 		_, _ = fmt.Fprintf(opts.IO.Out, ", %d failed.", f)
 	}
 	_, _ = fmt.Fprintln(opts.IO.Out)
+	return nil
 }
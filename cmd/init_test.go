@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
@@ -664,6 +665,13 @@ func TestInitOptions_StructShape(t *testing.T) {
 		"Preset":      true,
 		"DryRun":      true,
 		"Force":       true,
+		"Strict":      true,
+		"Scope":       true,
+		"Templates":   true,
+		"Output":      true,
+		"Sets":        true,
+		"ValuesFile":  true,
+		"Models":      true,
 	}
 
 	got := make(map[string]bool, typ.NumField())
@@ -739,3 +747,134 @@ func TestRunInit_ForcePropagatesToRequest(t *testing.T) {
 	assert.True(t, init.lastReq.Force,
 		"Force flag must propagate to install.Request.Force")
 }
+
+// initLossyOptions returns options installing a "commit" skill whose
+// model field OpenCode cannot represent.
+func initLossyOptions(t *testing.T) (*initOptions, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	libDir, _ := initFixtureSkill(t)
+	body := "---\nname: commit\ndescription: commit fixture\nmodel: sonnet\n---\nBody\n"
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "skills", "commit-skill.md"), []byte(body), 0o600))
+
+	io, out, errOut := newInitTestIO()
+	return &initOptions{
+		IO:        io,
+		Ctx:       context.Background(),
+		Platform:  core.PlatformOpenCode,
+		OutputDir: t.TempDir(),
+		Refs:      []string{"skill/commit"},
+		Library: func() (*library.Library, error) {
+			return library.LoadLibrary(context.Background(), libDir)
+		},
+	}, out, errOut
+}
+
+func TestRunInit_ReportsDroppedFields(t *testing.T) {
+	t.Parallel()
+
+	opts, out, errOut := initLossyOptions(t)
+
+	require.NoError(t, runInit(opts))
+	assert.Contains(t, out.String(), "Installed: skill/commit")
+	assert.Equal(t, "Warning: skill/commit: dropped model=sonnet (OpenCode skills run on the invoking agent's model)\n", errOut.String())
+}
+
+func TestRunInit_DroppedFieldsJSON(t *testing.T) {
+	t.Parallel()
+
+	opts, out, errOut := initLossyOptions(t)
+	opts.Output = "json"
+
+	require.NoError(t, runInit(opts))
+	assert.Empty(t, errOut.String(), "json output carries dropped fields instead of warnings")
+
+	var payload struct {
+		Documents []struct {
+			Ref     string           `json:"ref"`
+			Dropped []core.FieldLoss `json:"dropped"`
+		} `json:"documents"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &payload))
+	require.Len(t, payload.Documents, 1)
+	assert.Equal(t, "skill/commit", payload.Documents[0].Ref)
+	assert.Equal(t, []core.FieldLoss{{Field: "model", Value: "sonnet", Reason: "OpenCode skills run on the invoking agent's model"}}, payload.Documents[0].Dropped)
+}
+
+func TestRunInit_StrictFailsOnDroppedFields(t *testing.T) {
+	t.Parallel()
+
+	opts, _, _ := initLossyOptions(t)
+	opts.Strict = true
+
+	err := runInit(opts)
+	var partial *core.PartialSuccessError
+	require.ErrorAs(t, err, &partial)
+	assert.Equal(t, 1, partial.Failed())
+	assert.NoFileExists(t, filepath.Join(opts.OutputDir, ".opencode", "skills", "commit", "SKILL.md"))
+}
+
+func TestRunInit_RejectsUnknownScope(t *testing.T) {
+	t.Parallel()
+
+	opts, _, _ := initLossyOptions(t)
+	opts.Scope = "global"
+
+	err := runInit(opts)
+	var cfgErr *core.ConfigError
+	require.ErrorAs(t, err, &cfgErr)
+	assert.Equal(t, "scope", cfgErr.Field())
+}
+
+func TestRunInit_Vars(t *testing.T) {
+	t.Parallel()
+
+	libDir, _ := initFixtureSkill(t)
+	body := "---\nname: commit\ndescription: Commit {{ .Vars.project }}\nvars:\n  project:\n  style: conventional\n---\nUse {{ .Vars.style }} commits.\n"
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "skills", "commit-skill.md"), []byte(body), 0o600))
+	values := filepath.Join(t.TempDir(), "values.yaml")
+	require.NoError(t, os.WriteFile(values, []byte("project: germinator\nstyle: gitmoji\n"), 0o600))
+
+	newOpts := func() *initOptions {
+		io, _, _ := newInitTestIO()
+		return &initOptions{
+			IO:        io,
+			Ctx:       context.Background(),
+			Platform:  core.PlatformOpenCode,
+			OutputDir: t.TempDir(),
+			Refs:      []string{"skill/commit"},
+			Library: func() (*library.Library, error) {
+				return library.LoadLibrary(context.Background(), libDir)
+			},
+		}
+	}
+
+	t.Run("missing required variable fails the resource", func(t *testing.T) {
+		t.Parallel()
+		err := runInit(newOpts())
+		var partial *core.PartialSuccessError
+		require.ErrorAs(t, err, &partial)
+		var verr *core.ValidationError
+		require.ErrorAs(t, partial.Errors()[0].Cause(), &verr)
+		assert.Equal(t, "project", verr.Value())
+	})
+
+	t.Run("set overrides values file and defaults", func(t *testing.T) {
+		t.Parallel()
+		opts := newOpts()
+		opts.ValuesFile = values
+		opts.Sets = []string{"style=conventional"}
+		require.NoError(t, runInit(opts))
+		content, err := os.ReadFile(filepath.Join(opts.OutputDir, ".opencode", "skills", "commit", "SKILL.md"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Commit germinator")
+		assert.Contains(t, string(content), "Use conventional commits.")
+	})
+
+	t.Run("malformed set", func(t *testing.T) {
+		t.Parallel()
+		opts := newOpts()
+		opts.Sets = []string{"project"}
+		var uerr *core.UsageError
+		require.ErrorAs(t, runInit(opts), &uerr)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"gitlab.com/amoconst/germinator/internal/iostreams"
 	"gitlab.com/amoconst/germinator/internal/library"
 	"gitlab.com/amoconst/germinator/internal/output"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 )
 
 // addOptions holds the runtime state for a `library add` invocation.
@@ -171,8 +172,8 @@ Examples:
 	}
 	cmd.Flags().StringVar(&name, "name", "", "Resource name")
 	cmd.Flags().StringVar(&description, "description", "", "Resource description")
-	cmd.Flags().StringVar(&resType, "type", "", "Resource type (skill, agent, command, memory)")
-	cmd.Flags().StringVar(&platform, "platform", "", "Source platform (opencode, claude-code)")
+	cmd.Flags().StringVar(&resType, "type", "", "Resource type (skill, agent, command, memory, mcp, hook, settings)")
+	cmd.Flags().StringVar(&platform, "platform", "", "Source platform ("+strings.Join(platforms.IDs(), ", ")+")")
 	cmd.Flags().BoolVar(&discover, "discover", false, "Discover orphaned resource files not in library.yaml")
 	cmd.Flags().BoolVar(&batch, "batch", false, "Batch mode: process all orphans continuously (use with --discover --force)")
 	cmd.Flags().BoolVar(&force, "force", false, "Overwrite existing resource")
@@ -230,13 +231,13 @@ func runAdd(opts *addOptions) error {
 // output.FormatError renders the returned *core.PartialSuccessError
 // once (single-handling rule per cmd/AGENTS.md).
 //
-// Pre-flight: core.ValidatePlatform + core.CanInstallResource ensure
+// Pre-flight: platforms.Validate + core.CanInstallResource ensure
 // malformed refs short-circuit before any I/O. The library load is
 // lazy (per runAdd call) so the failure mode of a missing library is
 // surfaced with a typed OperationError rather than a panic.
 func runAddExplicit(opts *addOptions) error {
 	if opts.Platform != "" {
-		if err := core.ValidatePlatform(opts.Platform); err != nil {
+		if err := platforms.Validate(opts.Platform); err != nil {
 			return fmt.Errorf("validating platform: %w", err)
 		}
 	}
@@ -370,10 +371,16 @@ func cmdLayerDetect(path string) (docType, name string) {
 		{"skill-", "skill"},
 		{"command-", "command"},
 		{"memory-", "memory"},
+		{"mcp-", "mcp"},
+		{"hook-", "hook"},
+		{"settings-", "settings"},
 		{"-agent", "agent"},
 		{"-skill", "skill"},
 		{"-command", "command"},
 		{"-memory", "memory"},
+		{"-mcp", "mcp"},
+		{"-hook", "hook"},
+		{"-settings", "settings"},
 	}
 	for _, p := range patterns {
 		if strings.HasPrefix(stripped, p.prefix) {
@@ -444,7 +451,7 @@ func quietPlainOnAllFailure(succeeded int, initErrs []core.InitializeError) bool
 // exercise "library add --batch a.md b.md" keep working.
 func runAddBatchFiles(opts *addOptions) error {
 	if opts.Platform != "" {
-		if err := core.ValidatePlatform(opts.Platform); err != nil {
+		if err := platforms.Validate(opts.Platform); err != nil {
 			return fmt.Errorf("validating platform: %w", err)
 		}
 	}
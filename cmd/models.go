@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"gitlab.com/amoconst/germinator/internal/cmdutil"
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+// configuredModels returns the model aliases documents are rendered,
+// read back, and validated with: the built-in aliases overlaid with the
+// config file's [models.<alias>] tables. A missing or unloadable config
+// leaves the built-in aliases.
+func configuredModels(f *cmdutil.Factory) core.ModelAliases {
+	models := core.DefaultModelAliases()
+	if f.Config == nil {
+		return models
+	}
+	if cfg, err := f.Config(); err == nil && cfg != nil {
+		return models.Merge(cfg.Models)
+	}
+	return models
+}
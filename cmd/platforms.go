@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"gitlab.com/amoconst/germinator/internal/cmdutil"
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/iostreams"
+	"gitlab.com/amoconst/germinator/internal/output"
+	"gitlab.com/amoconst/germinator/internal/platforms"
+)
+
+// platformsOptions holds the runtime state for a `platforms` invocation.
+type platformsOptions struct {
+	IO        *iostreams.IOStreams
+	Platforms func() []platforms.Platform
+	Output    string
+}
+
+// platformsRow is the exporter representation of a single registered
+// platform. The tab struct tags drive the TableExporter column order.
+type platformsRow struct {
+	ID          string   `tab:"ID"          json:"id"`
+	Description string   `tab:"DESCRIPTION" json:"description"`
+	Resources   []string `tab:"RESOURCES"   json:"resources"`
+}
+
+// NewCmdPlatforms creates the `platforms` command, which lists the
+// target platforms known to the registry.
+func NewCmdPlatforms(f *cmdutil.Factory, runF func(*platformsOptions) error) *cobra.Command {
+	opts := &platformsOptions{}
+	cmd := &cobra.Command{
+		Use:   "platforms",
+		Short: "List supported target platforms",
+		Long: `List the target platforms accepted by --platform, with the resource
+types each platform can install.
+
+Example:
+  germinator platforms
+  germinator platforms --output json
+  germinator platforms --output table`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			opts.IO = f.IOStreams
+			opts.Platforms = platforms.All
+			if runF != nil {
+				return runF(opts)
+			}
+			return runPlatforms(opts)
+		},
+	}
+
+	output.AddOutputFlags(cmd, &opts.Output)
+
+	return cmd
+}
+
+// runPlatforms renders the registry listing in the requested format.
+func runPlatforms(opts *platformsOptions) error {
+	rows := flattenPlatforms(opts.Platforms())
+
+	switch opts.Output {
+	case "json":
+		// Wrapped-object shape, consistent with `library presets`.
+		if err := output.NewJSONExporter().Write(opts.IO, struct {
+			Platforms []platformsRow `json:"platforms"`
+		}{Platforms: rows}); err != nil {
+			return fmt.Errorf("writing json output: %w", err)
+		}
+		return nil
+	case "table":
+		if err := output.NewTableExporter().Write(opts.IO, rows); err != nil {
+			return fmt.Errorf("writing table output: %w", err)
+		}
+		return nil
+	default:
+		if _, err := fmt.Fprint(opts.IO.Out, formatPlatformsList(rows)); err != nil {
+			return fmt.Errorf("writing plain output: %w", err)
+		}
+		return nil
+	}
+}
+
+// flattenPlatforms converts registry entries to rows, preserving
+// registration order. Resources lists the installable resource types in
+// core.ResourceTypes order.
+func flattenPlatforms(ps []platforms.Platform) []platformsRow {
+	rows := make([]platformsRow, 0, len(ps))
+	for _, p := range ps {
+		resources := []string{}
+		for _, docType := range core.ResourceTypes() {
+			if _, ok := p.OutputPath(docType); ok {
+				resources = append(resources, docType)
+			}
+		}
+		rows = append(rows, platformsRow{
+			ID:          p.ID(),
+			Description: p.Description(),
+			Resources:   resources,
+		})
+	}
+	return rows
+}
+
+// formatPlatformsList renders rows as plain text, one platform per line.
+func formatPlatformsList(rows []platformsRow) string {
+	var sb strings.Builder
+	for _, r := range rows {
+		fmt.Fprintf(&sb, "%s - %s (%s)\n", r.ID, r.Description, strings.Join(r.Resources, ", "))
+	}
+	return sb.String()
+}
+
+// platformsHelp renders the registry as an indented "id - description"
+// block for command long help.
+func platformsHelp() string {
+	all := platforms.All()
+	width := 0
+	for _, p := range all {
+		width = max(width, len(p.ID()))
+	}
+	lines := make([]string, 0, len(all))
+	for _, p := range all {
+		lines = append(lines, fmt.Sprintf("  %-*s - %s", width, p.ID(), p.Description()))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/amoconst/germinator/internal/cmdutil"
+	"gitlab.com/amoconst/germinator/internal/iostreams"
+	"gitlab.com/amoconst/germinator/internal/platforms"
+)
+
+func newPlatformsOpts(output string) (*platformsOptions, *iostreams.IOStreams) {
+	io := iostreams.Test()
+	return &platformsOptions{IO: io, Platforms: platforms.All, Output: output}, io
+}
+
+func TestRunPlatforms_Plain(t *testing.T) {
+	t.Parallel()
+
+	opts, io := newPlatformsOpts("")
+	require.NoError(t, runPlatforms(opts))
+
+	out := io.Out.(interface{ String() string }).String()
+	assert.Contains(t, out, "claude-code - Claude Code document format (skill, agent, command, memory, mcp, hook, settings)\n")
+	assert.Contains(t, out, "opencode - OpenCode document format (skill, agent, command, memory, mcp, hook, settings)\n")
+	assert.Contains(t, out, "cursor - Cursor project rules (.mdc) (skill, command, memory)\n")
+	assert.Contains(t, out, "copilot - GitHub Copilot instructions, prompts, and chat modes (agent, command, memory)\n")
+	assert.Contains(t, out, "gemini - Gemini CLI commands (TOML) and GEMINI.md (command, memory)\n")
+	assert.Contains(t, out, "codex - AGENTS.md instructions (OpenAI Codex CLI and compatible agents) (memory)\n")
+}
+
+func TestRunPlatforms_JSON(t *testing.T) {
+	t.Parallel()
+
+	opts, io := newPlatformsOpts("json")
+	require.NoError(t, runPlatforms(opts))
+
+	var got struct {
+		Platforms []platformsRow `json:"platforms"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(io.Out.(interface{ String() string }).String()), &got))
+
+	ids := make([]string, 0, len(got.Platforms))
+	for _, p := range got.Platforms {
+		ids = append(ids, p.ID)
+	}
+	assert.Equal(t, platforms.IDs(), ids, "JSON listing must follow registry order")
+}
+
+func TestRunPlatforms_Table(t *testing.T) {
+	t.Parallel()
+
+	opts, io := newPlatformsOpts("table")
+	require.NoError(t, runPlatforms(opts))
+
+	out := io.Out.(interface{ String() string }).String()
+	assert.Contains(t, out, "ID")
+	assert.Contains(t, out, "DESCRIPTION")
+	assert.Contains(t, out, "claude-code")
+}
+
+func TestNewCmdPlatforms_RunF(t *testing.T) {
+	t.Parallel()
+
+	f := &cmdutil.Factory{IOStreams: iostreams.Test()}
+	var captured *platformsOptions
+	cmd := NewCmdPlatforms(f, func(o *platformsOptions) error {
+		captured = o
+		return nil
+	})
+	cmd.SetArgs([]string{"--output", "json"})
+	require.NoError(t, cmd.Execute())
+
+	require.NotNil(t, captured)
+	assert.Equal(t, "json", captured.Output)
+	assert.Len(t, captured.Platforms(), len(platforms.IDs()))
+}
+
+func TestPlatformsHelp(t *testing.T) {
+	t.Parallel()
+
+	help := platformsHelp()
+	for _, id := range platforms.IDs() {
+		assert.Contains(t, help, "  "+id)
+	}
+	assert.Contains(t, help, "cursor      - Cursor project rules (.mdc)", "IDs are padded to a common width")
+}
@@ -144,6 +144,9 @@ func flattenResources(lib *library.Library) []resourcesRow {
 		string(library.ResourceTypeAgent),
 		string(library.ResourceTypeCommand),
 		string(library.ResourceTypeMemory),
+		string(library.ResourceTypeMCP),
+		string(library.ResourceTypeHook),
+		string(library.ResourceTypeSettings),
 	}
 
 	rows := make([]resourcesRow, 0)
@@ -45,11 +45,15 @@ Germinator source format and adapts it for target platforms like Claude Code and
 	cmd.AddCommand(NewCmdValidate(f, nil))
 	cmd.AddCommand(NewCmdAdapt(f, nil))
 	cmd.AddCommand(NewCmdCanonicalize(f, nil))
+	cmd.AddCommand(NewCmdConvert(f, nil))
+	cmd.AddCommand(NewCmdRoundtrip(f, nil))
+	cmd.AddCommand(NewCmdPlatforms(f, nil))
 	cmd.AddCommand(NewCmdVersion(f, nil))
 	cmd.AddCommand(NewLibraryCommand(f, nil))
 	cmd.AddCommand(NewCmdInit(f, nil))
 	cmd.AddCommand(NewCmdCompletion(f, nil))
 	cmd.AddCommand(NewConfigCommand(f))
+	cmd.AddCommand(NewTemplatesCommand(f))
 
 	// Initialize carapace for enhanced completions
 	carapace.Gen(cmd)
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/carapace-sh/carapace"
+	"github.com/spf13/cobra"
+
+	"gitlab.com/amoconst/germinator/internal/cmdutil"
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/iostreams"
+	"gitlab.com/amoconst/germinator/internal/library"
+	"gitlab.com/amoconst/germinator/internal/output"
+	"gitlab.com/amoconst/germinator/internal/platforms"
+	"gitlab.com/amoconst/germinator/internal/roundtrip"
+)
+
+// RoundTripper is the local command-side contract for round-trip
+// checks. The method signature matches roundtrip.Service exactly so the
+// production service satisfies it via structural typing.
+type RoundTripper interface {
+	Check(ctx context.Context, req *roundtrip.Request) (*core.RoundtripResult, error)
+}
+
+// roundtripOptions holds the runtime state for a `roundtrip`
+// invocation. Library is a lazy closure, as for init, so file mode
+// never loads one. The RoundTripper lazy field is the per-call
+// injection seam for tests; production wires it to
+// roundtrip.NewService().
+type roundtripOptions struct {
+	IO           *iostreams.IOStreams
+	RoundTripper func() (RoundTripper, error)
+	Library      func() (*library.Library, error)
+	Ctx          context.Context
+	Paths        []string
+	Refs         []string
+	Platforms    []string
+	Templates    string
+	Sets         []string
+	ValuesFile   string
+	Models       core.ModelAliases
+	Output       string
+}
+
+// roundtripDocument is the --output json shape for one document and
+// platform. Dropped and Drift are always present (possibly empty).
+type roundtripDocument struct {
+	Ref      string            `json:"ref"`
+	Input    string            `json:"input"`
+	Platform string            `json:"platform"`
+	Dropped  []core.FieldLoss  `json:"dropped"`
+	Drift    []core.FieldDrift `json:"drift"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// roundtripRow is the --output table shape: one row per drifting field.
+type roundtripRow struct {
+	Ref      string `tab:"REF"`
+	Platform string `tab:"PLATFORM"`
+	Field    string `tab:"FIELD"`
+	Want     string `tab:"WANT"`
+	Got      string `tab:"GOT"`
+}
+
+// NewCmdRoundtrip creates the `roundtrip` command via the canonical
+// NewCmdXxx(f, runF) pattern. runF is the test-injection seam;
+// production wires it to runRoundtrip, tests substitute a stub.
+func NewCmdRoundtrip(f *cmdutil.Factory, runF func(*roundtripOptions) error) *cobra.Command {
+	var (
+		platformIDs []string
+		resources   []string
+		libraryPath string
+		format      string
+		sets        []string
+		valuesFile  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "roundtrip [file...]",
+		Short: "Check that documents survive rendering and parsing back",
+		Long: `Render canonical documents for each platform, parse the output back, and
+report every field that comes back different.
+
+Fields a platform cannot represent are expected to be lost and are not
+reported as drift. Any other difference means the document cannot be
+maintained in that platform's format without losing information, or that
+the platform's adapter has a bug.
+
+Given files, each canonical document is checked. Without files, every
+resource in the library is checked (hooks and settings excepted: they
+cannot be read back), or only --resources.
+
+Supported platforms:
+` + platformsHelp() + `
+
+Examples:
+  germinator roundtrip agent-reviewer.md --platform opencode
+  germinator roundtrip --library ./library
+  germinator roundtrip --resources agent/reviewer,skill/commit -o table`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := &roundtripOptions{
+				IO:         f.IOStreams,
+				Ctx:        c.Context(),
+				Paths:      args,
+				Refs:       resources,
+				Platforms:  platformIDs,
+				Sets:       sets,
+				ValuesFile: valuesFile,
+				Output:     format,
+				Models:     configuredModels(f),
+			}
+			var cfgPath string
+			if f.Config != nil {
+				if cfg, cfgErr := f.Config(); cfgErr == nil && cfg != nil {
+					cfgPath = cfg.Library
+					opts.Templates = cfg.Templates
+				}
+			}
+			resolved := library.FindLibrary(libraryPath, os.Getenv("GERMINATOR_LIBRARY"), cfgPath)
+			opts.Library = cmdutil.OnceValuesFunc(func() (*library.Library, error) {
+				return library.LoadLibrary(c.Context(), resolved)
+			})
+			if runF != nil {
+				return runF(opts)
+			}
+			return runRoundtrip(opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&platformIDs, "platform", nil, "Platforms to check (default: every platform supporting the document type: "+strings.Join(platforms.IDs(), ", ")+")")
+	cmd.Flags().StringSliceVar(&resources, "resources", nil, "Comma-separated library resources to check (default: all)")
+	cmd.Flags().StringVar(&libraryPath, "library", "", "Path to library directory (default: "+library.DefaultLibraryPath()+")")
+	addVarsFlags(cmd, &sets, &valuesFile)
+	output.AddOutputFlags(cmd, &format)
+
+	carapace.Gen(cmd).FlagCompletion(carapace.ActionMap{
+		"platform":  actionPlatforms(f),
+		"resources": actionResources(f, cmd),
+	})
+	carapace.Gen(cmd).PositionalAnyCompletion(carapace.ActionFiles())
+
+	return cmd
+}
+
+// runRoundtrip executes the roundtrip logic against the resolved
+// options. A clean run prints one "ok" line per document and platform;
+// any drift or failure makes the command fail with a
+// *core.TransformError after the report is written.
+func runRoundtrip(opts *roundtripOptions) error {
+	for _, p := range opts.Platforms {
+		if err := platforms.Validate(p); err != nil {
+			return fmt.Errorf("validating platform: %w", err)
+		}
+	}
+	if len(opts.Paths) > 0 && len(opts.Refs) > 0 {
+		return core.NewUsageError("resources", "cannot be combined with document files").
+			WithSuggestions([]string{"pass either files or --resources"})
+	}
+
+	vars, err := resolveVars(opts.Ctx, opts.Sets, opts.ValuesFile)
+	if err != nil {
+		return err
+	}
+
+	req := &roundtrip.Request{
+		Paths:        opts.Paths,
+		Refs:         opts.Refs,
+		Platforms:    opts.Platforms,
+		TemplateDirs: templateDirs(opts.Templates),
+		Models:       opts.Models,
+		Vars:         vars,
+	}
+	if len(opts.Paths) == 0 {
+		lib, err := opts.Library()
+		if err != nil {
+			return fmt.Errorf("loading library: %w", err)
+		}
+		req.Library = lib
+	}
+
+	resolve := opts.RoundTripper
+	if resolve == nil {
+		resolve = func() (RoundTripper, error) { return roundtrip.NewService(), nil }
+	}
+	rt, err := resolve()
+	if err != nil {
+		return fmt.Errorf("resolving round-tripper: %w", err)
+	}
+
+	result, err := rt.Check(opts.Ctx, req)
+	if err != nil {
+		return fmt.Errorf("checking round trips: %w", err)
+	}
+
+	if err := writeRoundtripReport(opts.IO, opts.Output, result.Documents); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, doc := range result.Documents {
+		if doc.Error != nil || len(doc.Drift) > 0 {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return core.NewTransformError("roundtrip", "",
+			fmt.Sprintf("%d of %d round trip(s) did not come back unchanged", failed, len(result.Documents)), nil)
+	}
+	return nil
+}
+
+// writeRoundtripReport renders docs as JSON, a table of drifting
+// fields, or plain text: one line per document and platform, followed
+// by its drifting fields.
+func writeRoundtripReport(io *iostreams.IOStreams, format string, docs []core.RoundtripDocument) error {
+	switch format {
+	case "json":
+		entries := make([]roundtripDocument, 0, len(docs))
+		for _, d := range docs {
+			entry := roundtripDocument{Ref: d.Ref, Input: d.InputPath, Platform: d.Platform, Dropped: d.Dropped, Drift: d.Drift}
+			if entry.Dropped == nil {
+				entry.Dropped = []core.FieldLoss{}
+			}
+			if entry.Drift == nil {
+				entry.Drift = []core.FieldDrift{}
+			}
+			if d.Error != nil {
+				entry.Error = d.Error.Error()
+			}
+			entries = append(entries, entry)
+		}
+		if err := output.NewJSONExporter().Write(io, struct {
+			Documents []roundtripDocument `json:"documents"`
+		}{Documents: entries}); err != nil {
+			return fmt.Errorf("writing json output: %w", err)
+		}
+		return nil
+	case "table":
+		rows := []roundtripRow{}
+		for _, d := range docs {
+			for _, drift := range d.Drift {
+				rows = append(rows, roundtripRow{Ref: d.Ref, Platform: d.Platform, Field: drift.Field, Want: drift.Want, Got: drift.Got})
+			}
+		}
+		if err := output.NewTableExporter().Write(io, rows); err != nil {
+			return fmt.Errorf("writing table output: %w", err)
+		}
+		return nil
+	default:
+		if _, err := fmt.Fprint(io.Out, formatRoundtrip(docs)); err != nil {
+			return fmt.Errorf("writing plain output: %w", err)
+		}
+		for _, d := range docs {
+			if d.Error != nil {
+				io.Warnf("%s → %s: %v", d.Ref, d.Platform, d.Error)
+			}
+		}
+		return nil
+	}
+}
+
+// formatRoundtrip renders the plain report. Failed documents are only
+// marked here; their errors go to ErrOut.
+func formatRoundtrip(docs []core.RoundtripDocument) string {
+	var sb strings.Builder
+	for _, d := range docs {
+		switch {
+		case d.Error != nil:
+			fmt.Fprintf(&sb, "error  %s → %s\n", d.Ref, d.Platform)
+		case len(d.Drift) > 0:
+			fmt.Fprintf(&sb, "drift  %s → %s\n", d.Ref, d.Platform)
+			for _, drift := range d.Drift {
+				fmt.Fprintf(&sb, "         %s: %q → %q\n", drift.Field, drift.Want, drift.Got)
+			}
+		default:
+			fmt.Fprintf(&sb, "ok     %s → %s\n", d.Ref, d.Platform)
+		}
+	}
+	return sb.String()
+}
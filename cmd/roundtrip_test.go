@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/amoconst/germinator/internal/cmdutil"
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/iostreams"
+	"gitlab.com/amoconst/germinator/internal/library"
+	"gitlab.com/amoconst/germinator/internal/roundtrip"
+)
+
+// fakeRoundTripper satisfies the local cmd.RoundTripper interface,
+// recording the last request and returning the configured result.
+type fakeRoundTripper struct {
+	lastReq *roundtrip.Request
+	result  *core.RoundtripResult
+}
+
+func (f *fakeRoundTripper) Check(_ context.Context, req *roundtrip.Request) (*core.RoundtripResult, error) {
+	f.lastReq = req
+	return f.result, nil
+}
+
+func newRoundtripOptions(fake *fakeRoundTripper) *roundtripOptions {
+	io, _, _ := newAdaptTestIO()
+	return &roundtripOptions{
+		IO:           io,
+		RoundTripper: func() (RoundTripper, error) { return fake, nil },
+		Library: func() (*library.Library, error) {
+			return &library.Library{RootPath: "/lib"}, nil
+		},
+		Ctx: context.Background(),
+	}
+}
+
+func TestRunRoundtrip_Clean(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeRoundTripper{result: &core.RoundtripResult{Documents: []core.RoundtripDocument{
+		{Ref: "agent/reviewer", Platform: core.PlatformClaudeCode},
+	}}}
+	opts := newRoundtripOptions(fake)
+	io, out, _ := newAdaptTestIO()
+	opts.IO = io
+	opts.Platforms = []string{core.PlatformClaudeCode}
+
+	require.NoError(t, runRoundtrip(opts))
+	assert.Equal(t, "ok     agent/reviewer → claude-code\n", out.String())
+	require.NotNil(t, fake.lastReq.Library, "without files the library is checked")
+	assert.Equal(t, []string{core.PlatformClaudeCode}, fake.lastReq.Platforms)
+}
+
+func TestRunRoundtrip_DriftFails(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeRoundTripper{result: &core.RoundtripResult{Documents: []core.RoundtripDocument{
+		{Ref: "agent/reviewer", Platform: core.PlatformOpenCode, Drift: []core.FieldDrift{{Field: "behavior.steps", Want: "5"}}},
+		{Ref: "skill/commit", Platform: core.PlatformOpenCode, Error: errors.New("boom")},
+		{Ref: "skill/commit", Platform: core.PlatformClaudeCode},
+	}}}
+	opts := newRoundtripOptions(fake)
+	io, out, errOut := newAdaptTestIO()
+	opts.IO = io
+	opts.Paths = []string{"agent-reviewer.md"}
+
+	err := runRoundtrip(opts)
+	var transformErr *core.TransformError
+	require.ErrorAs(t, err, &transformErr)
+	assert.Contains(t, err.Error(), "2 of 3 round trip(s)")
+	assert.Equal(t, cmdutil.ExitCodeError, cmdutil.ExitCodeFor(err))
+	assert.Nil(t, fake.lastReq.Library, "files do not load the library")
+
+	assert.Contains(t, out.String(), "drift  agent/reviewer → opencode\n")
+	assert.Contains(t, out.String(), `behavior.steps: "5" → ""`)
+	assert.Contains(t, out.String(), "error  skill/commit → opencode\n")
+	assert.Contains(t, errOut.String(), "skill/commit → opencode: boom")
+}
+
+func TestRunRoundtrip_JSON(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeRoundTripper{result: &core.RoundtripResult{Documents: []core.RoundtripDocument{
+		{Ref: "agent/reviewer", InputPath: "agent-reviewer.md", Platform: core.PlatformOpenCode},
+	}}}
+	opts := newRoundtripOptions(fake)
+	io, out, _ := newAdaptTestIO()
+	opts.IO = io
+	opts.Output = "json"
+
+	require.NoError(t, runRoundtrip(opts))
+	var got struct {
+		Documents []roundtripDocument `json:"documents"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	require.Len(t, got.Documents, 1)
+	assert.NotNil(t, got.Documents[0].Drift, "drift is always present")
+	assert.NotNil(t, got.Documents[0].Dropped)
+}
+
+func TestRunRoundtrip_Usage(t *testing.T) {
+	t.Parallel()
+
+	opts := newRoundtripOptions(&fakeRoundTripper{})
+	opts.Paths = []string{"agent-reviewer.md"}
+	opts.Refs = []string{"agent/reviewer"}
+	var usageErr *core.UsageError
+	require.ErrorAs(t, runRoundtrip(opts), &usageErr)
+
+	opts = newRoundtripOptions(&fakeRoundTripper{})
+	opts.Platforms = []string{"nope"}
+	var valErr *core.ValidationError
+	require.ErrorAs(t, runRoundtrip(opts), &valErr)
+}
+
+func TestNewCmdRoundtrip_Flags(t *testing.T) {
+	t.Parallel()
+
+	f := &cmdutil.Factory{IOStreams: iostreams.Test()}
+	var got *roundtripOptions
+	cmd := NewCmdRoundtrip(f, func(o *roundtripOptions) error { got = o; return nil })
+	cmd.SetArgs([]string{"a.md", "b.md", "--platform", "opencode,cursor"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"a.md", "b.md"}, got.Paths)
+	assert.Equal(t, []string{"opencode", "cursor"}, got.Platforms)
+}
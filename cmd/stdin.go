@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/iostreams"
+)
+
+// stdioPath is the path argument that reads a document from stdin or,
+// as an output path, writes it to stdout.
+const stdioPath = "-"
+
+// stdinChunkSize is the size stdin is read in (cli-stdin-composability).
+const stdinChunkSize = 64 << 10
+
+// readStdin reads the document piped to io.In. An interactive stdin is
+// refused instead of waited on, and empty input is an error.
+func readStdin(io *iostreams.IOStreams) ([]byte, error) {
+	if io.IsStdinTTY() {
+		return nil, core.NewUsageError("stdin", "no input: provide a file path or pipe data via stdin")
+	}
+	var buf bytes.Buffer
+	if io.In != nil {
+		if _, err := buf.ReadFrom(bufio.NewReaderSize(io.In, stdinChunkSize)); err != nil {
+			return nil, core.NewFileError(stdioPath, "read", "failed to read stdin", err)
+		}
+	}
+	if len(bytes.TrimSpace(buf.Bytes())) == 0 {
+		return nil, core.NewUsageError("stdin", "no input: empty stdin")
+	}
+	return buf.Bytes(), nil
+}
+
+// readStdinDocument reads a canonical document from stdin for an input
+// path of -. Its type cannot be detected without a file name or a
+// look at the content before it is read, so docType is required.
+func readStdinDocument(io *iostreams.IOStreams, docType string) ([]byte, error) {
+	if docType == "" {
+		return nil, core.NewUsageError("type", "required when reading from stdin").
+			WithSuggestions([]string{"pass --type <" + strings.Join(core.ResourceTypes(), "|") + ">"})
+	}
+	return readStdin(io)
+}
+
+// stdinUsageError reports - among several inputs: stdin holds one
+// document.
+func stdinUsageError() *core.UsageError {
+	return core.NewUsageError("stdin", "- reads a single document and cannot be combined with other inputs")
+}
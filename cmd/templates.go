@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"gitlab.com/amoconst/germinator/internal/cmdutil"
+	"gitlab.com/amoconst/germinator/internal/platforms"
+	"gitlab.com/amoconst/germinator/internal/renderer"
+)
+
+// NewTemplatesCommand creates the templates command group with list,
+// export, and diff subcommands. The Factory is forwarded to each
+// subcommand constructor.
+func NewTemplatesCommand(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "templates",
+		Short: "Inspect, export, and compare rendering templates",
+		Long: `Commands for working with the templates germinator renders documents from:
+
+  germinator templates list     List the bundled templates and their overrides
+  germinator templates export   Copy the bundled templates into an override directory
+  germinator templates diff     Show how overrides differ from the bundled templates
+
+An override directory is laid out like the bundled set, one
+<platform>/<type>.tmpl file per template (e.g. claude-code/agent.tmpl).
+Templates it does not hold fall back to the bundled ones. Overrides are
+looked up in this order:
+
+  1. A resource's own template in library.yaml:
+       resources:
+         agent:
+           reviewer:
+             path: agents/reviewer.md
+             templates:
+               claude-code: templates/reviewer-claude.tmpl
+  2. The library's templates directory (templates: <dir> in library.yaml)
+  3. The templates directory from the config file (templates = "<dir>")
+     or GERMINATOR_TEMPLATES`,
+	}
+
+	cmd.AddCommand(NewCmdTemplatesList(f, nil))
+	cmd.AddCommand(NewCmdTemplatesExport(f, nil))
+	cmd.AddCommand(NewCmdTemplatesDiff(f, nil))
+
+	return cmd
+}
+
+// templateDirs returns dir as a template override directory list, or
+// nil when dir is empty.
+func templateDirs(dir string) []string {
+	if dir == "" {
+		return nil
+	}
+	return []string{dir}
+}
+
+// configuredTemplates returns the templates directory from the config
+// file or GERMINATOR_TEMPLATES, or "" when neither sets one.
+func configuredTemplates(f *cmdutil.Factory) string {
+	if f.Config == nil {
+		return ""
+	}
+	if cfg, err := f.Config(); err == nil && cfg != nil {
+		return cfg.Templates
+	}
+	return ""
+}
+
+// filterTemplates keeps the templates of platform's template set, or
+// every template when platform is empty.
+func filterTemplates(list []renderer.Template, platform string) ([]renderer.Template, error) {
+	if platform == "" {
+		return list, nil
+	}
+	if err := platforms.Validate(platform); err != nil {
+		return nil, fmt.Errorf("validating platform: %w", err)
+	}
+	target, _ := platforms.Lookup(platform)
+	prefix := target.TemplateSet() + "/"
+	filtered := make([]renderer.Template, 0, len(list))
+	for _, t := range list {
+		if strings.HasPrefix(t.Name, prefix) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/carapace-sh/carapace"
+	"github.com/spf13/cobra"
+
+	"gitlab.com/amoconst/germinator/internal/cmdutil"
+	"gitlab.com/amoconst/germinator/internal/iostreams"
+	"gitlab.com/amoconst/germinator/internal/renderer"
+)
+
+// templatesDiffOptions holds the runtime state for a `templates diff`
+// invocation. Dir is the override directory to compare; it defaults to
+// the configured one.
+type templatesDiffOptions struct {
+	IO       *iostreams.IOStreams
+	Dir      string
+	Platform string
+}
+
+// NewCmdTemplatesDiff creates the `templates diff` command via the
+// canonical NewCmdXxx(f, runF) pattern.
+func NewCmdTemplatesDiff(f *cmdutil.Factory, runF func(*templatesDiffOptions) error) *cobra.Command {
+	opts := &templatesDiffOptions{}
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show how overrides differ from the bundled templates",
+		Long: `Print a unified diff from each bundled template to the file overriding it.
+Templates without an override, or whose override matches the bundled one,
+are skipped. Use it after upgrading germinator to see which bundled changes
+your overrides do not pick up.
+
+The override directory defaults to the templates directory from the config
+file (or GERMINATOR_TEMPLATES); pass --dir to compare another one.
+
+Example:
+  germinator templates diff
+  germinator templates diff --dir ./library/templates --platform opencode`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			opts.IO = f.IOStreams
+			if opts.Dir == "" {
+				opts.Dir = configuredTemplates(f)
+			}
+			if runF != nil {
+				return runF(opts)
+			}
+			return runTemplatesDiff(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Dir, "dir", "", "Template override directory (default: the configured templates directory)")
+	cmd.Flags().StringVar(&opts.Platform, "platform", "", "Only compare the templates of this platform")
+
+	carapace.Gen(cmd).FlagCompletion(carapace.ActionMap{
+		"platform": actionPlatforms(f),
+		"dir":      carapace.ActionDirectories(),
+	})
+
+	return cmd
+}
+
+// runTemplatesDiff writes the diff of every overridden template to Out,
+// or a note to ErrOut when nothing differs.
+func runTemplatesDiff(opts *templatesDiffOptions) error {
+	list, err := renderer.ListTemplates(renderer.TemplateOptions{Dirs: templateDirs(opts.Dir)})
+	if err != nil {
+		return fmt.Errorf("listing templates: %w", err)
+	}
+	list, err = filterTemplates(list, opts.Platform)
+	if err != nil {
+		return err
+	}
+
+	differs := false
+	for _, t := range list {
+		diff, err := renderer.DiffTemplate(t)
+		if err != nil {
+			return fmt.Errorf("comparing %s: %w", t.Name, err)
+		}
+		if diff == "" {
+			continue
+		}
+		differs = true
+		if _, err := fmt.Fprint(opts.IO.Out, diff); err != nil {
+			return fmt.Errorf("writing diff: %w", err)
+		}
+	}
+	if !differs {
+		_, _ = fmt.Fprintln(opts.IO.ErrOut, "no template overrides differ from the bundled templates")
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/carapace-sh/carapace"
+	"github.com/spf13/cobra"
+
+	"gitlab.com/amoconst/germinator/internal/cmdutil"
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/iostreams"
+	"gitlab.com/amoconst/germinator/internal/renderer"
+)
+
+// templatesExportOptions holds the runtime state for a `templates
+// export` invocation. Dir is the first positional argument, or the
+// configured templates directory when omitted; Templates are the
+// template names after it.
+type templatesExportOptions struct {
+	IO        *iostreams.IOStreams
+	Dir       string
+	Templates []string
+	Platform  string
+	Force     bool
+}
+
+// NewCmdTemplatesExport creates the `templates export` command via the
+// canonical NewCmdXxx(f, runF) pattern.
+func NewCmdTemplatesExport(f *cmdutil.Factory, runF func(*templatesExportOptions) error) *cobra.Command {
+	opts := &templatesExportOptions{}
+	cmd := &cobra.Command{
+		Use:   "export [dir] [template...]",
+		Short: "Copy the bundled templates into an override directory",
+		Long: `Copy the bundled templates into dir as <platform>/<type>.tmpl files, as a
+starting point for house-style overrides. Edit the files you want to change
+and delete the rest: templates missing from the directory fall back to the
+bundled ones.
+
+dir defaults to the templates directory from the config file (or
+GERMINATOR_TEMPLATES). Templates named after dir, as listed by
+'germinator templates list', are the only ones exported. Existing files
+are left alone unless --force is set.
+
+Example:
+  germinator templates export ~/.config/germinator/templates
+  germinator templates export ./library/templates --platform claude-code
+  germinator templates export ./library/templates opencode/agent.tmpl`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			opts.IO = f.IOStreams
+			if len(args) > 0 {
+				opts.Dir, opts.Templates = args[0], args[1:]
+			} else {
+				opts.Dir = configuredTemplates(f)
+			}
+			if runF != nil {
+				return runF(opts)
+			}
+			return runTemplatesExport(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Platform, "platform", "", "Only export the templates of this platform")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Overwrite existing template files")
+
+	carapace.Gen(cmd).FlagCompletion(carapace.ActionMap{
+		"platform": actionPlatforms(f),
+	})
+	carapace.Gen(cmd).PositionalCompletion(carapace.ActionDirectories())
+	carapace.Gen(cmd).PositionalAnyCompletion(actionTemplates())
+
+	return cmd
+}
+
+// runTemplatesExport writes each selected bundled template into
+// opts.Dir. Per-template failures (typically an existing file without
+// --force) are aggregated into a *core.PartialSuccessError, as init does.
+func runTemplatesExport(opts *templatesExportOptions) error {
+	if opts.Dir == "" {
+		return core.NewUsageError("dir", "no directory given and no templates directory configured").
+			WithSuggestions([]string{"pass a directory, or set templates in the config file"})
+	}
+
+	list, err := renderer.ListTemplates(renderer.TemplateOptions{})
+	if err != nil {
+		return fmt.Errorf("listing templates: %w", err)
+	}
+	list, err = filterTemplates(list, opts.Platform)
+	if err != nil {
+		return err
+	}
+	list, err = selectTemplates(list, opts.Templates)
+	if err != nil {
+		return err
+	}
+
+	var succeeded, failed int
+	var errs []core.InitializeError
+	for _, t := range list {
+		path, err := renderer.ExportTemplate(opts.Dir, t.Name, opts.Force)
+		if err != nil {
+			failed++
+			errs = append(errs, *core.NewInitializeError(t.Name, t.Name, path, err))
+			continue
+		}
+		succeeded++
+		_, _ = fmt.Fprintf(opts.IO.Out, "wrote %s\n", path)
+	}
+	if failed > 0 {
+		return core.NewPartialSuccessError(succeeded, failed, errs)
+	}
+	return nil
+}
+
+// selectTemplates keeps the templates of list named in names, or every
+// template when names is empty. A name not in list is a
+// *core.UsageError.
+func selectTemplates(list []renderer.Template, names []string) ([]renderer.Template, error) {
+	if len(names) == 0 {
+		return list, nil
+	}
+	selected := make([]renderer.Template, 0, len(names))
+	for _, name := range names {
+		i := slices.IndexFunc(list, func(t renderer.Template) bool { return t.Name == name })
+		if i < 0 {
+			return nil, core.NewUsageError("template", fmt.Sprintf("unknown template %q", name)).
+				WithSuggestions([]string{"run 'germinator templates list' for the template names, e.g. opencode/agent.tmpl"})
+		}
+		selected = append(selected, list[i])
+	}
+	return selected, nil
+}
+
+// actionTemplates returns a completion action listing the bundled
+// template names.
+func actionTemplates() carapace.Action {
+	return carapace.ActionCallback(func(_ carapace.Context) carapace.Action {
+		list, err := renderer.ListTemplates(renderer.TemplateOptions{})
+		if err != nil {
+			return carapace.ActionValues()
+		}
+		names := make([]string, 0, len(list))
+		for _, t := range list {
+			names = append(names, t.Name)
+		}
+		return carapace.ActionValues(names...)
+	})
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/carapace-sh/carapace"
+	"github.com/spf13/cobra"
+
+	"gitlab.com/amoconst/germinator/internal/cmdutil"
+	"gitlab.com/amoconst/germinator/internal/iostreams"
+	"gitlab.com/amoconst/germinator/internal/output"
+	"gitlab.com/amoconst/germinator/internal/renderer"
+)
+
+// templatesListOptions holds the runtime state for a `templates list`
+// invocation. Dir is the override directory to check; it defaults to
+// the configured one.
+type templatesListOptions struct {
+	IO       *iostreams.IOStreams
+	Dir      string
+	Platform string
+	Output   string
+}
+
+// templatesRow is the exporter representation of a single template.
+// Source is "bundled" or the override file rendering reads instead.
+type templatesRow struct {
+	Template string `tab:"TEMPLATE" json:"template"`
+	Source   string `tab:"SOURCE"   json:"source"`
+}
+
+// NewCmdTemplatesList creates the `templates list` command via the
+// canonical NewCmdXxx(f, runF) pattern.
+func NewCmdTemplatesList(f *cmdutil.Factory, runF func(*templatesListOptions) error) *cobra.Command {
+	opts := &templatesListOptions{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the bundled templates and their overrides",
+		Long: `List every bundled template with the file that overrides it, if any.
+
+The override directory defaults to the templates directory from the config
+file (or GERMINATOR_TEMPLATES); pass --dir to check another one, such as a
+library's templates directory.
+
+Example:
+  germinator templates list
+  germinator templates list --platform claude-code --dir ./library/templates
+  germinator templates list --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			opts.IO = f.IOStreams
+			if opts.Dir == "" {
+				opts.Dir = configuredTemplates(f)
+			}
+			if runF != nil {
+				return runF(opts)
+			}
+			return runTemplatesList(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Dir, "dir", "", "Template override directory (default: the configured templates directory)")
+	cmd.Flags().StringVar(&opts.Platform, "platform", "", "Only list the templates of this platform")
+	output.AddOutputFlags(cmd, &opts.Output)
+
+	carapace.Gen(cmd).FlagCompletion(carapace.ActionMap{
+		"platform": actionPlatforms(f),
+		"dir":      carapace.ActionDirectories(),
+	})
+
+	return cmd
+}
+
+// runTemplatesList renders the template listing in the requested format.
+func runTemplatesList(opts *templatesListOptions) error {
+	list, err := renderer.ListTemplates(renderer.TemplateOptions{Dirs: templateDirs(opts.Dir)})
+	if err != nil {
+		return fmt.Errorf("listing templates: %w", err)
+	}
+	list, err = filterTemplates(list, opts.Platform)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]templatesRow, 0, len(list))
+	for _, t := range list {
+		source := "bundled"
+		if t.Override != "" {
+			source = t.Override
+		}
+		rows = append(rows, templatesRow{Template: t.Name, Source: source})
+	}
+
+	switch opts.Output {
+	case "json":
+		if err := output.NewJSONExporter().Write(opts.IO, struct {
+			Templates []templatesRow `json:"templates"`
+		}{Templates: rows}); err != nil {
+			return fmt.Errorf("writing json output: %w", err)
+		}
+		return nil
+	case "table":
+		if err := output.NewTableExporter().Write(opts.IO, rows); err != nil {
+			return fmt.Errorf("writing table output: %w", err)
+		}
+		return nil
+	default:
+		if _, err := fmt.Fprint(opts.IO.Out, formatTemplatesList(rows)); err != nil {
+			return fmt.Errorf("writing plain output: %w", err)
+		}
+		return nil
+	}
+}
+
+// formatTemplatesList renders rows as plain text, one template per line
+// with its source in an aligned second column.
+func formatTemplatesList(rows []templatesRow) string {
+	width := 0
+	for _, r := range rows {
+		width = max(width, len(r.Template))
+	}
+	var sb strings.Builder
+	for _, r := range rows {
+		fmt.Fprintf(&sb, "%-*s  %s\n", width, r.Template, r.Source)
+	}
+	return sb.String()
+}
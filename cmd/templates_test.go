@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/amoconst/germinator/internal/cmdutil"
+	"gitlab.com/amoconst/germinator/internal/config"
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/iostreams"
+)
+
+func TestRunTemplatesExport(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	io := iostreams.Test()
+	opts := &templatesExportOptions{IO: io, Dir: dir, Platform: core.PlatformClaudeCode}
+	require.NoError(t, runTemplatesExport(opts))
+
+	assert.FileExists(t, filepath.Join(dir, "claude-code", "agent.tmpl"))
+	assert.NoDirExists(t, filepath.Join(dir, "opencode"), "--platform limits the export")
+	assert.Contains(t, outString(t, io), "wrote "+filepath.Join(dir, "claude-code", "agent.tmpl"))
+
+	err := runTemplatesExport(opts)
+	var partial *core.PartialSuccessError
+	require.ErrorAs(t, err, &partial, "existing files are not overwritten without --force")
+
+	opts.Force = true
+	require.NoError(t, runTemplatesExport(opts))
+}
+
+func TestRunTemplatesExport_Templates(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, runTemplatesExport(&templatesExportOptions{
+		IO: iostreams.Test(), Dir: dir, Templates: []string{"opencode/agent.tmpl"},
+	}))
+	assert.FileExists(t, filepath.Join(dir, "opencode", "agent.tmpl"))
+	assert.NoFileExists(t, filepath.Join(dir, "opencode", "command.tmpl"), "only the named template is exported")
+	assert.NoDirExists(t, filepath.Join(dir, "claude-code"))
+
+	err := runTemplatesExport(&templatesExportOptions{IO: iostreams.Test(), Dir: dir, Templates: []string{"opencode/agents.tmpl"}})
+	var usageErr *core.UsageError
+	require.ErrorAs(t, err, &usageErr)
+
+	err = runTemplatesExport(&templatesExportOptions{
+		IO: iostreams.Test(), Dir: dir, Platform: core.PlatformClaudeCode, Templates: []string{"opencode/agent.tmpl"},
+	})
+	require.ErrorAs(t, err, &usageErr, "a name outside --platform is not exported")
+}
+
+func TestRunTemplatesExport_NoDirectory(t *testing.T) {
+	t.Parallel()
+
+	err := runTemplatesExport(&templatesExportOptions{IO: iostreams.Test()})
+	var usageErr *core.UsageError
+	require.ErrorAs(t, err, &usageErr)
+}
+
+func TestRunTemplatesList(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	override := filepath.Join(dir, "opencode", "agent.tmpl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(override), 0o750))
+	require.NoError(t, os.WriteFile(override, []byte("custom"), 0o600))
+
+	io := iostreams.Test()
+	require.NoError(t, runTemplatesList(&templatesListOptions{IO: io, Dir: dir, Platform: core.PlatformOpenCode, Output: "json"}))
+
+	var got struct {
+		Templates []templatesRow `json:"templates"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(outString(t, io)), &got))
+	assert.Contains(t, got.Templates, templatesRow{Template: "opencode/agent.tmpl", Source: override})
+	assert.Contains(t, got.Templates, templatesRow{Template: "opencode/skill.tmpl", Source: "bundled"})
+	for _, row := range got.Templates {
+		assert.True(t, strings.HasPrefix(row.Template, "opencode/"), "unexpected template %s", row.Template)
+	}
+
+	err := runTemplatesList(&templatesListOptions{IO: iostreams.Test(), Platform: "nope"})
+	var valErr *core.ValidationError
+	require.ErrorAs(t, err, &valErr)
+}
+
+func TestRunTemplatesDiff(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, runTemplatesExport(&templatesExportOptions{IO: iostreams.Test(), Dir: dir, Platform: core.PlatformOpenCode}))
+
+	io := iostreams.Test()
+	require.NoError(t, runTemplatesDiff(&templatesDiffOptions{IO: io, Dir: dir}))
+	assert.Empty(t, outString(t, io), "exported templates match the bundled ones")
+	assert.Contains(t, io.ErrOut.(interface{ String() string }).String(), "no template overrides differ")
+
+	path := filepath.Join(dir, "opencode", "agent.tmpl")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, append(content, []byte("<!-- house footer -->\n")...), 0o600))
+
+	io = iostreams.Test()
+	require.NoError(t, runTemplatesDiff(&templatesDiffOptions{IO: io, Dir: dir}))
+	out := outString(t, io)
+	assert.Contains(t, out, "--- bundled/opencode/agent.tmpl")
+	assert.Contains(t, out, "+++ "+path)
+	assert.Contains(t, out, "+<!-- house footer -->")
+	assert.NotContains(t, out, "opencode/skill.tmpl")
+}
+
+func TestNewCmdTemplates_DefaultsToConfiguredDirectory(t *testing.T) {
+	t.Parallel()
+
+	f := &cmdutil.Factory{
+		IOStreams: iostreams.Test(),
+		Config: func() (*config.Config, error) {
+			cfg := config.DefaultConfig()
+			cfg.Templates = "/configured"
+			return cfg, nil
+		},
+	}
+
+	var listed *templatesListOptions
+	list := NewCmdTemplatesList(f, func(o *templatesListOptions) error { listed = o; return nil })
+	list.SetArgs([]string{})
+	require.NoError(t, list.Execute())
+	assert.Equal(t, "/configured", listed.Dir)
+
+	var exported *templatesExportOptions
+	export := NewCmdTemplatesExport(f, func(o *templatesExportOptions) error { exported = o; return nil })
+	export.SetArgs([]string{"out"})
+	require.NoError(t, export.Execute())
+	assert.Equal(t, "out", exported.Dir, "the argument wins over the configured directory")
+	assert.Empty(t, exported.Templates)
+
+	export = NewCmdTemplatesExport(f, func(o *templatesExportOptions) error { exported = o; return nil })
+	export.SetArgs([]string{"out", "opencode/agent.tmpl", "claude-code/agent.tmpl"})
+	require.NoError(t, export.Execute())
+	assert.Equal(t, "out", exported.Dir)
+	assert.Equal(t, []string{"opencode/agent.tmpl", "claude-code/agent.tmpl"}, exported.Templates)
+
+	var diffed *templatesDiffOptions
+	diff := NewCmdTemplatesDiff(f, func(o *templatesDiffOptions) error { diffed = o; return nil })
+	diff.SetArgs([]string{"--dir", "other"})
+	require.NoError(t, diff.Execute())
+	assert.Equal(t, "other", diffed.Dir)
+}
@@ -3,13 +3,18 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/carapace-sh/carapace"
 	"github.com/spf13/cobra"
 
+	"gitlab.com/amoconst/germinator/internal/batch"
 	"gitlab.com/amoconst/germinator/internal/cmdutil"
 	"gitlab.com/amoconst/germinator/internal/core"
 	"gitlab.com/amoconst/germinator/internal/iostreams"
+	"gitlab.com/amoconst/germinator/internal/output"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 	"gitlab.com/amoconst/germinator/internal/validate"
 )
 
@@ -23,42 +28,80 @@ type Validator interface {
 
 // validateOptions holds the runtime state for a `validate`
 // invocation. IO and Ctx come from the Factory; the rest come from
-// parsed flags and positional args. The Validator lazy field is the
+// parsed flags and positional args; InputPaths holds every argument
+// and InputPath the first. The Validator lazy field is the
 // per-call injection seam for tests — production wires it to a
 // closure that invokes validate.NewService(); tests substitute a
 // fake.
 type validateOptions struct {
-	IO        *iostreams.IOStreams
-	Validator func() (Validator, error)
-	Ctx       context.Context
-	InputPath string
-	Platform  string
+	IO         *iostreams.IOStreams
+	Validator  func() (Validator, error)
+	Ctx        context.Context
+	InputPath  string
+	InputPaths []string
+	Platform   string
+	Sets       []string
+	ValuesFile string
+	DocType    string
+	Models     core.ModelAliases
+	Output     string
 }
 
 // NewCmdValidate creates the `validate` command via the canonical
 // NewCmdXxx(f, runF) pattern. runF is the test-injection seam;
 // production wires it to runValidate, tests substitute a stub.
 func NewCmdValidate(f *cmdutil.Factory, runF func(*validateOptions) error) *cobra.Command {
-	var platform string
+	var (
+		platform   string
+		docType    string
+		sets       []string
+		valuesFile string
+		format     string
+	)
 
 	cmd := &cobra.Command{
-		Use:   "validate <file>",
-		Short: "Validate a document file",
-		Long: `Validate a document file and display any errors found.
+		Use:   "validate <file>...",
+		Short: "Validate document files",
+		Long: `Validate document files and display any errors found.
+
+Each argument may be a file, a directory (walked for .md, .yaml, and
+.yml documents), or a quoted glob where ** matches any number of
+directories. Several documents are validated in parallel and reported
+one per line, or with --output json|table; the command fails when any
+of them is invalid.
+
+The document type is read from a type: (or kind:) frontmatter key, the
+filename (agent-*.md, *-skill.yaml, ...), or fields only one type has;
+--type sets it explicitly. --verbose reports which rule decided.
+
+A file of - reads one document from stdin, which requires --type.
+
+A document that declares variables is validated with the values from --set
+and --values; a required variable without a value is a validation error.
 
 Supported platforms:
-  claude-code  - Claude Code document format
-  opencode     - OpenCode document format
+` + platformsHelp() + `
 
 Example:
-  germinator validate agent.yaml --platform claude-code`,
-		Args: cobra.ExactArgs(1),
+  germinator validate agent.yaml --platform claude-code
+  germinator validate agent-go.md --platform opencode --set test_command="go test ./..."
+  germinator validate reviewer.md --platform claude-code --type agent
+  cat reviewer.md | germinator validate - --platform claude-code --type agent
+  germinator validate 'agents/**/*.md' --platform claude-code
+  germinator validate ./canonical/ --platform opencode -o json`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
 			opts := &validateOptions{
-				IO:        f.IOStreams,
-				Ctx:       c.Context(),
-				InputPath: args[0],
-				Platform:  platform,
+				IO:         f.IOStreams,
+				Ctx:        c.Context(),
+				InputPath:  args[0],
+				InputPaths: args,
+				Output:     format,
+				Platform:   platform,
+				DocType:    docType,
+				Sets:       sets,
+				ValuesFile: valuesFile,
+				Models:     configuredModels(f),
 			}
 			if runF != nil {
 				return runF(opts)
@@ -67,8 +110,11 @@ Example:
 		},
 	}
 
-	cmd.Flags().StringVar(&platform, "platform", "", "Target platform (required: claude-code, opencode)")
+	cmd.Flags().StringVar(&platform, "platform", "", "Target platform (required: "+strings.Join(platforms.IDs(), ", ")+")")
+	addVarsFlags(cmd, &sets, &valuesFile)
+	addTypeFlag(cmd, &docType)
 	_ = cmd.MarkFlagRequired("platform")
+	output.AddOutputFlags(cmd, &format)
 
 	carapace.Gen(cmd).FlagCompletion(carapace.ActionMap{
 		"platform": actionPlatforms(f),
@@ -85,12 +131,22 @@ Example:
 // that calls validate.NewService(); tests may inject a fake via the
 // same field. A nil opts.Validator falls back to the production
 // constructor.
+//
+// A file of - is read from stdin. Several documents, or any --output
+// format other than plain, are validated by runValidateBatch.
 func runValidate(opts *validateOptions) error {
-	if err := core.ValidatePlatform(opts.Platform); err != nil {
+	if err := platforms.Validate(opts.Platform); err != nil {
 		return fmt.Errorf("validating platform: %w", err)
 	}
 
-	opts.IO.Verbosef("validating %s (platform: %s)", opts.InputPath, opts.Platform)
+	if err := validateTypeFlag(opts.DocType); err != nil {
+		return err
+	}
+
+	vars, err := resolveVars(opts.Ctx, opts.Sets, opts.ValuesFile)
+	if err != nil {
+		return err
+	}
 
 	resolve := opts.Validator
 	if resolve == nil {
@@ -100,14 +156,32 @@ func runValidate(opts *validateOptions) error {
 	if err != nil {
 		return fmt.Errorf("resolving validator: %w", err)
 	}
+	if batch.IsMulti(opts.InputPaths) || (opts.Output != "" && opts.Output != output.DefaultOutputFormat) {
+		return runValidateBatch(opts, v, vars)
+	}
+
+	opts.IO.Verbosef("validating %s (platform: %s)", opts.InputPath, opts.Platform)
+
+	var content []byte
+	if opts.InputPath == stdioPath {
+		if content, err = readStdinDocument(opts.IO, opts.DocType); err != nil {
+			return err
+		}
+	}
 
 	result, err := v.Validate(opts.Ctx, &validate.Request{
 		InputPath: opts.InputPath,
 		Platform:  opts.Platform,
+		DocType:   opts.DocType,
+		Content:   content,
+		Vars:      vars,
+		Models:    opts.Models,
 	})
 	if err != nil {
 		return fmt.Errorf("validating document: %w", err)
 	}
+	reportDocType(opts.IO, result.DocType, result.DetectedBy)
+	warnAll(opts.IO, result.Warnings)
 
 	if !result.Valid() {
 		return result.Errors[0]
@@ -116,3 +190,129 @@ func runValidate(opts *validateOptions) error {
 	_, _ = fmt.Fprintln(opts.IO.Out, "Document is valid")
 	return nil
 }
+
+// validatedDocument is one entry of the validate --output json report.
+type validatedDocument struct {
+	Input    string   `json:"input"`
+	Type     string   `json:"type,omitempty"`
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// validatedRow is the --output table shape: one row per error, or one
+// "valid" row for a valid document.
+type validatedRow struct {
+	File   string `tab:"FILE"`
+	Type   string `tab:"TYPE"`
+	Result string `tab:"RESULT"`
+}
+
+// runValidateBatch validates every document opts.InputPaths name,
+// several at a time (batch.Run), and reports each of them. Any invalid
+// document, or one that fails to parse, makes the command fail with a
+// *core.ValidationError (exit 1) after the report is written, so a CI
+// job can gate on it.
+func runValidateBatch(opts *validateOptions, v Validator, vars map[string]string) error {
+	if slices.Contains(opts.InputPaths, stdioPath) {
+		return stdinUsageError()
+	}
+	files, err := batch.Expand(opts.InputPaths)
+	if err != nil {
+		return fmt.Errorf("expanding inputs: %w", err)
+	}
+	opts.IO.Verbosef("validating %d documents (platform: %s)", len(files), opts.Platform)
+
+	docs, err := batch.Run(opts.Ctx, files, func(ctx context.Context, file batch.File) validatedDocument {
+		doc := validatedDocument{Input: file.Path, Errors: []string{}}
+		result, err := v.Validate(ctx, &validate.Request{
+			InputPath: file.Path,
+			Platform:  opts.Platform,
+			DocType:   opts.DocType,
+			Vars:      vars,
+			Models:    opts.Models,
+		})
+		if err != nil {
+			doc.Errors = append(doc.Errors, err.Error())
+			return doc
+		}
+		doc.Type = result.DocType
+		doc.Errors = errorStrings(result.Errors)
+		if len(result.Warnings) > 0 {
+			doc.Warnings = errorStrings(result.Warnings)
+		}
+		doc.Valid = result.Valid()
+		return doc
+	})
+	if err != nil {
+		return fmt.Errorf("validating documents: %w", err)
+	}
+
+	if err := writeValidatedReport(opts.IO, opts.Output, docs); err != nil {
+		return err
+	}
+
+	invalid := 0
+	for _, doc := range docs {
+		if !doc.Valid {
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		return core.NewValidationError("validate", "documents", "",
+			fmt.Sprintf("%d of %d document(s) are invalid", invalid, len(docs)))
+	}
+	return nil
+}
+
+// writeValidatedReport renders docs as the JSON payload
+// {"documents": [...]}, a table, or plain text: one ok or invalid line
+// per document, with its errors and warnings on ErrOut.
+func writeValidatedReport(io *iostreams.IOStreams, format string, docs []validatedDocument) error {
+	switch format {
+	case "json":
+		if err := output.NewJSONExporter().Write(io, struct {
+			Documents []validatedDocument `json:"documents"`
+		}{Documents: docs}); err != nil {
+			return fmt.Errorf("writing json output: %w", err)
+		}
+		return nil
+	case "table":
+		rows := []validatedRow{}
+		for _, d := range docs {
+			if d.Valid {
+				rows = append(rows, validatedRow{File: d.Input, Type: d.Type, Result: "valid"})
+			}
+			for _, e := range d.Errors {
+				rows = append(rows, validatedRow{File: d.Input, Type: d.Type, Result: e})
+			}
+			for _, w := range d.Warnings {
+				rows = append(rows, validatedRow{File: d.Input, Type: d.Type, Result: "warning: " + w})
+			}
+		}
+		if err := output.NewTableExporter().Write(io, rows); err != nil {
+			return fmt.Errorf("writing table output: %w", err)
+		}
+		return nil
+	default:
+		for _, d := range docs {
+			if d.Valid {
+				_, _ = fmt.Fprintf(io.Out, "ok       %s\n", d.Input)
+			} else {
+				_, _ = fmt.Fprintf(io.Out, "invalid  %s\n", d.Input)
+			}
+			for _, e := range d.Errors {
+				if strings.HasPrefix(e, d.Input+":") {
+					// Already located, e.g. compiler-style path:line:col.
+					io.Warnf("%s", e)
+					continue
+				}
+				io.Warnf("%s: %s", d.Input, e)
+			}
+			for _, w := range d.Warnings {
+				io.Warnf("%s", w)
+			}
+		}
+		return nil
+	}
+}
@@ -3,8 +3,10 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -326,3 +328,207 @@ Body`
 	assert.True(t, result.Valid(), "expected valid result")
 	assert.Empty(t, result.Errors)
 }
+
+func TestRunValidate_DocType(t *testing.T) {
+	t.Parallel()
+
+	t.Run("forwarded and reported", func(t *testing.T) {
+		t.Parallel()
+		io, _, errOut := newValidateTestIO()
+		io.Verbose = true
+		fake := &fakeValidator{result: &core.ValidateResult{DocType: "agent"}}
+		opts := &validateOptions{
+			IO:        io,
+			Validator: func() (Validator, error) { return fake, nil },
+			Ctx:       context.Background(),
+			InputPath: "/tmp/reviewer.md",
+			Platform:  core.PlatformClaudeCode,
+			DocType:   "agent",
+		}
+
+		require.NoError(t, runValidate(opts))
+		require.NotNil(t, fake.lastReq)
+		assert.Equal(t, "agent", fake.lastReq.DocType)
+		assert.Contains(t, errOut.String(), "document type: agent (--type)")
+	})
+
+	t.Run("detection rule reported", func(t *testing.T) {
+		t.Parallel()
+		io, _, errOut := newValidateTestIO()
+		io.Verbose = true
+		fake := &fakeValidator{result: &core.ValidateResult{DocType: "agent", DetectedBy: "frontmatter field behavior"}}
+		opts := &validateOptions{
+			IO:        io,
+			Validator: func() (Validator, error) { return fake, nil },
+			Ctx:       context.Background(),
+			InputPath: "/tmp/reviewer.md",
+			Platform:  core.PlatformClaudeCode,
+		}
+
+		require.NoError(t, runValidate(opts))
+		assert.Contains(t, errOut.String(), "document type: agent (frontmatter field behavior)")
+	})
+
+	t.Run("unknown type rejected", func(t *testing.T) {
+		t.Parallel()
+		io, _, _ := newValidateTestIO()
+		fake := &fakeValidator{}
+		opts := &validateOptions{
+			IO:        io,
+			Validator: func() (Validator, error) { return fake, nil },
+			Ctx:       context.Background(),
+			InputPath: "/tmp/reviewer.md",
+			Platform:  core.PlatformClaudeCode,
+			DocType:   "widget",
+		}
+
+		err := runValidate(opts)
+		var verr *core.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Zero(t, fake.calls, "validator must not run for an invalid --type")
+	})
+}
+
+func TestRunValidate_Batch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all valid", func(t *testing.T) {
+		t.Parallel()
+		root := writeBatchTree(t)
+		io, out, _ := newValidateTestIO()
+		opts := &validateOptions{
+			IO:         io,
+			Ctx:        context.Background(),
+			InputPaths: []string{filepath.Join(root, "agents")},
+			Platform:   core.PlatformClaudeCode,
+		}
+
+		require.NoError(t, runValidate(opts))
+		assert.Equal(t, 2, strings.Count(out.String(), "ok "))
+	})
+
+	t.Run("a mixed batch fails the run", func(t *testing.T) {
+		t.Parallel()
+		root := writeBatchTree(t)
+		io, out, errOut := newValidateTestIO()
+		opts := &validateOptions{
+			IO:         io,
+			Ctx:        context.Background(),
+			InputPaths: []string{filepath.Join(root, "**", "*.md")},
+			Platform:   core.PlatformClaudeCode,
+		}
+
+		err := runValidate(opts)
+		var verr *core.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Contains(t, err.Error(), "1 of 3 document(s) are invalid")
+		assert.NotContains(t, err.Error(), "initialize failed")
+		assert.Equal(t, cmdutil.ExitCodeError, cmdutil.ExitCodeFor(err), "one invalid document fails the batch")
+		assert.Equal(t, 2, strings.Count(out.String(), "ok "), "the valid documents are still reported")
+		assert.Contains(t, out.String(), "invalid  "+filepath.Join(root, "README.md"))
+		assert.Contains(t, errOut.String(), "cannot detect document type")
+	})
+
+	t.Run("library index is not a document", func(t *testing.T) {
+		t.Parallel()
+		root := writeBatchTree(t)
+		require.NoError(t, os.WriteFile(filepath.Join(root, "agents", "library.yaml"), []byte("version: \"1\"\nresources: {}\n"), 0o600))
+		io, out, _ := newValidateTestIO()
+		opts := &validateOptions{
+			IO:         io,
+			Ctx:        context.Background(),
+			InputPaths: []string{filepath.Join(root, "agents")},
+			Platform:   core.PlatformClaudeCode,
+		}
+
+		require.NoError(t, runValidate(opts))
+		assert.NotContains(t, out.String(), "library.yaml")
+	})
+
+	t.Run("json report", func(t *testing.T) {
+		t.Parallel()
+		root := writeBatchTree(t)
+		io, out, _ := newValidateTestIO()
+		opts := &validateOptions{
+			IO:         io,
+			Ctx:        context.Background(),
+			InputPath:  filepath.Join(root, "agents", "reviewer.md"),
+			InputPaths: []string{filepath.Join(root, "agents", "reviewer.md")},
+			Platform:   core.PlatformClaudeCode,
+			Output:     "json",
+		}
+
+		require.NoError(t, runValidate(opts))
+		var report struct {
+			Documents []validatedDocument `json:"documents"`
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+		require.Len(t, report.Documents, 1)
+		assert.True(t, report.Documents[0].Valid)
+		assert.Equal(t, "agent", report.Documents[0].Type)
+		assert.Empty(t, report.Documents[0].Errors)
+	})
+}
+
+func TestRunValidate_Stdin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		docType string
+		wantErr any
+	}{
+		{name: "valid document", in: "---\nname: reviewer\ndescription: Reviews code\n---\nReview.\n", docType: "agent"},
+		{name: "invalid document", in: "---\nname: reviewer\n---\nReview.\n", docType: "agent", wantErr: &core.ValidationError{}},
+		{name: "without --type", in: "---\nname: reviewer\n---\n", wantErr: &core.UsageError{}},
+		{name: "empty stdin", in: "", docType: "agent", wantErr: &core.UsageError{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			io, out, _ := newValidateTestIO()
+			io.In = strings.NewReader(tt.in)
+			opts := &validateOptions{
+				IO:         io,
+				Ctx:        context.Background(),
+				InputPath:  "-",
+				InputPaths: []string{"-"},
+				Platform:   core.PlatformOpenCode,
+				DocType:    tt.docType,
+			}
+
+			err := runValidate(opts)
+			switch want := tt.wantErr.(type) {
+			case nil:
+				require.NoError(t, err)
+				assert.Equal(t, "Document is valid\n", out.String())
+			case *core.ValidationError:
+				require.ErrorAs(t, err, &want)
+			case *core.UsageError:
+				require.ErrorAs(t, err, &want)
+			}
+		})
+	}
+}
+
+func TestRunValidate_UnknownFieldWarnings(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "agent-reviewer.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\nname: reviewer\ndescription: Reviews code\npermissionMode: plan\n---\nReview.\n"), 0o600))
+
+	io, out, errOut := newValidateTestIO()
+	opts := &validateOptions{
+		IO:         io,
+		Ctx:        context.Background(),
+		InputPath:  path,
+		InputPaths: []string{path},
+		Platform:   core.PlatformOpenCode,
+	}
+
+	require.NoError(t, runValidate(opts), "unknown fields are warnings, not errors")
+	assert.Equal(t, "Document is valid\n", out.String())
+	assert.Contains(t, errOut.String(), path+":4:1: unknown field permissionMode")
+	assert.Contains(t, errOut.String(), "did you mean permissionPolicy?")
+}
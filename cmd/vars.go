@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"github.com/carapace-sh/carapace"
+	"github.com/spf13/cobra"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/parser"
+)
+
+// addVarsFlags registers --set and --values, which supply the variables
+// canonical documents declare under vars:.
+func addVarsFlags(cmd *cobra.Command, sets *[]string, valuesFile *string) {
+	cmd.Flags().StringArrayVar(sets, "set", nil, "Set a document variable (key=value, repeatable)")
+	cmd.Flags().StringVar(valuesFile, "values", "", "YAML file of document variable values")
+	carapace.Gen(cmd).FlagCompletion(carapace.ActionMap{
+		"values": carapace.ActionFiles(".yaml", ".yml"),
+	})
+}
+
+// resolveVars merges the values file, when given, with the --set pairs;
+// --set wins on conflicts. It returns nil when neither is given.
+func resolveVars(ctx context.Context, sets []string, valuesFile string) (map[string]string, error) {
+	vars := make(map[string]string)
+	if valuesFile != "" {
+		values, err := parser.LoadValues(ctx, valuesFile)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // typed parser errors propagate as-is
+		}
+		maps.Copy(vars, values)
+	}
+	for _, set := range sets {
+		key, value, ok := strings.Cut(set, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, core.NewUsageError("set", "expected key=value, got "+set).
+				WithSuggestions([]string{"--set project_name=germinator"})
+		}
+		vars[strings.TrimSpace(key)] = value
+	}
+	if len(vars) == 0 {
+		return nil, nil
+	}
+	return vars, nil
+}
@@ -0,0 +1,12 @@
+// Package templates holds the bundled platform and canonical templates.
+// They are compiled into the binary so germinator renders documents
+// from any working directory; internal/renderer reads them through FS.
+package templates
+
+import "embed"
+
+// FS holds every template as <set>/<type>.tmpl, where <set> is a
+// platform's template set (claude-code, opencode, ...) or "canonical".
+//
+//go:embed */*.tmpl
+var FS embed.FS
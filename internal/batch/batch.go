@@ -0,0 +1,246 @@
+// Package batch expands document arguments into files and runs a
+// per-file operation over them on a bounded worker pool. It is the
+// shell-package seam behind `adapt --output-dir` and multi-file
+// `validate`: an argument may be a file, a directory (walked for
+// canonical documents), or a glob where `**` matches any number of
+// directories.
+//
+// Run uses errgroup.SetLimit(concurrencyLimit) as
+// library.DiscoverOrphans does; per-file failures belong in the
+// operation's result, so the group only fails on ctx cancellation.
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+// concurrencyLimit caps the number of files Run processes at once. It
+// matches library's scanConcurrencyLimit: rendering a document is
+// cheap, so the cap only bounds open files on very large trees.
+const concurrencyLimit = 8
+
+// documentExtensions are the extensions a directory walk picks up.
+var documentExtensions = []string{".md", ".yaml", ".yml"}
+
+// libraryIndex is the library's resource index, which sits beside the
+// documents in a library directory but is not one.
+const libraryIndex = "library.yaml"
+
+// File is a document found by Expand. Rel is its path relative to the
+// directory or glob root it was found under, or its base name for a
+// file given directly.
+type File struct {
+	Path string
+	Rel  string
+}
+
+// IsMulti reports whether args name more than one document: several
+// arguments, a directory, or a glob.
+func IsMulti(args []string) bool {
+	if len(args) != 1 {
+		return len(args) > 1
+	}
+	if isPattern(args[0]) {
+		return true
+	}
+	info, err := os.Stat(args[0])
+	return err == nil && info.IsDir()
+}
+
+// Expand resolves args into the documents they name, in argument order
+// and, within a directory or glob, in lexical order. A file named twice
+// is listed once. Directory walks pick up .md files and the .yaml and
+// .yml files that open with a frontmatter `---` line, skipping
+// library.yaml and hidden directories; a glob takes every file it
+// matches. An
+// argument that names nothing is a *core.FileError.
+func Expand(args []string) ([]File, error) {
+	var files []File
+	seen := map[string]bool{}
+	add := func(f File) {
+		if !seen[f.Path] {
+			seen[f.Path] = true
+			files = append(files, f)
+		}
+	}
+
+	for _, arg := range args {
+		found, err := expandArg(arg)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range found {
+			add(f)
+		}
+	}
+	return files, nil
+}
+
+// expandArg resolves one argument.
+func expandArg(arg string) ([]File, error) {
+	if isPattern(arg) {
+		return expandGlob(arg)
+	}
+	info, err := os.Stat(arg)
+	if err != nil {
+		return nil, core.NewFileError(arg, "read", "failed to read input", err)
+	}
+	if !info.IsDir() {
+		return []File{{Path: arg, Rel: filepath.Base(arg)}}, nil
+	}
+
+	files, err := walk(arg, func(rel string) bool {
+		return isDocument(filepath.Join(arg, rel))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, core.NewFileError(arg, "read", "no documents found", nil).
+			WithSuggestions([]string{"documents are .md, .yaml, or .yml files"})
+	}
+	return files, nil
+}
+
+// isDocument reports whether a directory walk picks up the file at p.
+// Markdown files are documents even without frontmatter (memory); a
+// YAML file is one only when it opens with a `---` line, which leaves
+// out library.yaml and other configuration.
+func isDocument(p string) bool {
+	ext := strings.ToLower(filepath.Ext(p))
+	if !slices.Contains(documentExtensions, ext) || filepath.Base(p) == libraryIndex {
+		return false
+	}
+	if ext == ".md" {
+		return true
+	}
+	f, err := os.Open(p) //nolint:gosec // G304: walking a directory the user named
+	if err != nil {
+		// Listed so that the read error surfaces for the document.
+		return true
+	}
+	defer f.Close() //nolint:errcheck // read-only file
+	head := make([]byte, len("---\n"))
+	n, _ := io.ReadFull(f, head) //nolint:errcheck // a short file is not a document
+	return strings.TrimRight(string(head[:n]), "\r\n") == "---"
+}
+
+// expandGlob resolves a glob. The walk starts at the pattern's longest
+// leading directory without wildcards.
+func expandGlob(pattern string) ([]File, error) {
+	clean := path.Clean(filepath.ToSlash(pattern))
+	segments := strings.Split(clean, "/")
+	fixed := 0
+	for fixed < len(segments)-1 && !isPattern(segments[fixed]) {
+		fixed++
+	}
+	root := "."
+	if fixed > 0 {
+		root = filepath.FromSlash(strings.Join(segments[:fixed], "/"))
+		if root == "" {
+			root = string(filepath.Separator)
+		}
+	}
+	rest := segments[fixed:]
+
+	files, err := walk(root, func(rel string) bool {
+		return matchSegments(rest, strings.Split(filepath.ToSlash(rel), "/"))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, core.NewFileError(pattern, "read", "no files match", nil)
+	}
+	return files, nil
+}
+
+// walk lists the files under root whose root-relative path keep
+// accepts, skipping hidden directories below root.
+func walk(root string, keep func(rel string) bool) ([]File, error) {
+	var files []File
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped once below as a *core.FileError
+		}
+		if keep(rel) {
+			files = append(files, File{Path: p, Rel: rel})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, core.NewFileError(root, "read", "failed to walk directory", err)
+	}
+	return files, nil
+}
+
+// matchSegments matches a slash-split path against slash-split glob
+// segments, where a `**` segment matches zero or more path segments
+// and any other segment is a path.Match pattern.
+func matchSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	ok, err := path.Match(pattern[0], name[0])
+	return err == nil && ok && matchSegments(pattern[1:], name[1:])
+}
+
+// isPattern reports whether s contains glob metacharacters.
+func isPattern(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+// Run calls fn for every file on at most concurrencyLimit goroutines
+// and returns the results in files' order. fn reports per-file failures
+// in its result; Run itself fails only when ctx is cancelled.
+func Run[T any](ctx context.Context, files []File, fn func(context.Context, File) T) ([]T, error) {
+	results := make([]T, len(files))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrencyLimit)
+	for i, f := range files {
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return fmt.Errorf("batch: %w", err)
+			}
+			results[i] = fn(gctx, f)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("batch: cancelled: %w", err)
+	}
+	return results, nil
+}
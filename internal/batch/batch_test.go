@@ -0,0 +1,161 @@
+package batch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+// writeTree creates the given slash-separated files under a temp
+// directory and returns it.
+func writeTree(t *testing.T, files ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, f := range files {
+		path := filepath.Join(root, filepath.FromSlash(f))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte("---\nname: x\n---\n"), 0o600))
+	}
+	return root
+}
+
+// rels returns the Rel of every file, slash-separated.
+func rels(files []File) []string {
+	out := make([]string, 0, len(files))
+	for _, f := range files {
+		out = append(out, filepath.ToSlash(f.Rel))
+	}
+	return out
+}
+
+func TestExpand(t *testing.T) {
+	t.Parallel()
+
+	root := writeTree(t,
+		"agents/reviewer.md",
+		"agents/team/lead.md",
+		"agents/notes.txt",
+		"skills/release.yaml",
+		".git/HEAD.md",
+		"memory-go.md",
+		"library.yaml",
+	)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "skills", "config.yml"), []byte("version: 1\n"), 0o600))
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "directory",
+			args: []string{root},
+			want: []string{"agents/reviewer.md", "agents/team/lead.md", "memory-go.md", "skills/release.yaml"},
+		},
+		{
+			name: "recursive glob",
+			args: []string{filepath.Join(root, "agents", "**", "*.md")},
+			want: []string{"reviewer.md", "team/lead.md"},
+		},
+		{
+			name: "single-level glob",
+			args: []string{filepath.Join(root, "agents", "*")},
+			want: []string{"notes.txt", "reviewer.md"},
+		},
+		{
+			name: "glob takes every match",
+			args: []string{filepath.Join(root, "*.yaml")},
+			want: []string{"library.yaml"},
+		},
+		{
+			name: "file",
+			args: []string{filepath.Join(root, "memory-go.md")},
+			want: []string{"memory-go.md"},
+		},
+		{
+			name: "file named twice is listed once",
+			args: []string{filepath.Join(root, "agents"), filepath.Join(root, "agents", "reviewer.md")},
+			want: []string{"reviewer.md", "team/lead.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			files, err := Expand(tt.args)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rels(files))
+		})
+	}
+}
+
+func TestExpand_NothingFound(t *testing.T) {
+	t.Parallel()
+
+	root := writeTree(t, "agents/notes.txt")
+
+	tests := []struct {
+		name string
+		arg  string
+	}{
+		{name: "missing file", arg: filepath.Join(root, "missing.md")},
+		{name: "directory without documents", arg: filepath.Join(root, "agents")},
+		{name: "glob without matches", arg: filepath.Join(root, "**", "*.md")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Expand([]string{tt.arg})
+			var ferr *core.FileError
+			require.ErrorAs(t, err, &ferr)
+		})
+	}
+}
+
+func TestIsMulti(t *testing.T) {
+	t.Parallel()
+
+	root := writeTree(t, "agent-reviewer.md")
+	file := filepath.Join(root, "agent-reviewer.md")
+
+	assert.False(t, IsMulti([]string{file}))
+	assert.False(t, IsMulti(nil))
+	assert.True(t, IsMulti([]string{file, file}))
+	assert.True(t, IsMulti([]string{root}))
+	assert.True(t, IsMulti([]string{"agents/**/*.md"}))
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	files := make([]File, 50)
+	for i := range files {
+		files[i] = File{Path: filepath.Join("docs", string(rune('a'+i%26))+".md")}
+	}
+
+	results, err := Run(context.Background(), files, func(_ context.Context, f File) string {
+		return f.Path
+	})
+	require.NoError(t, err)
+	require.Len(t, results, len(files))
+	for i, f := range files {
+		assert.Equal(t, f.Path, results[i], "results keep the order of files")
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Run(ctx, []File{{Path: "a.md"}}, func(context.Context, File) bool { return true })
+	require.ErrorIs(t, err, context.Canceled)
+}
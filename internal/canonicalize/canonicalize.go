@@ -15,6 +15,7 @@ package canonicalize
 
 import (
 	"context"
+	"io"
 	"os"
 
 	"gitlab.com/amoconst/germinator/internal/core"
@@ -32,6 +33,19 @@ type Request struct {
 	OutputPath string
 	Platform   string
 	DocType    string
+	// Name selects one MCP server when DocType is "mcp" and the input
+	// file (.mcp.json, opencode.json) defines several.
+	Name string
+	// Models are the model aliases a platform model id is written back
+	// as (core.ModelAliases.Canonical).
+	Models core.ModelAliases
+	// Content, when set, is the platform document to canonicalize in
+	// place of the file at InputPath, which then only names it
+	// (canonicalize - reads it from stdin).
+	Content []byte
+	// Output, when set, receives the canonical document in place of
+	// OutputPath.
+	Output io.Writer
 }
 
 // Service is the per-call contract for document canonicalization.
@@ -66,10 +80,19 @@ func NewService() Service {
 //
 // The docType field on Request MUST be pre-validated by the caller
 // via core.ValidateDocumentType. Platform is also pre-validated
-// upstream by core.ValidatePlatform in cmd/canonicalize.go's
+// upstream by platforms.Validate in cmd/canonicalize.go's
 // runCanonicalize.
 func (canonicalizeService) Canonicalize(ctx context.Context, req *Request) (*core.CanonicalizeResult, error) {
-	doc, err := parser.ParsePlatformDocument(ctx, req.InputPath, req.Platform, req.DocType)
+	var doc interface{}
+	var err error
+	switch {
+	case req.DocType == "mcp" && req.Content != nil:
+		doc, err = parser.ParsePlatformMCPServerContent(req.InputPath, req.Content, req.Platform, req.Name)
+	case req.DocType == "mcp":
+		doc, err = parser.ParsePlatformMCPServer(ctx, req.InputPath, req.Platform, req.Name)
+	default:
+		doc, err = parser.ParsePlatformDocumentWith(ctx, req.InputPath, req.Platform, req.DocType, parser.Options{Models: req.Models, Content: req.Content})
+	}
 	if err != nil {
 		return nil, core.NewParseError(req.InputPath, "failed to parse platform document", err)
 	}
@@ -83,7 +106,11 @@ func (canonicalizeService) Canonicalize(ctx context.Context, req *Request) (*cor
 		return nil, core.NewTransformError("marshal", req.Platform, "failed to marshal canonical document", err)
 	}
 
-	if err := os.WriteFile(req.OutputPath, []byte(yamlBytes), 0644); err != nil { //nolint:gosec // G306: User owns output file, 0644 is standard readable permission
+	if req.Output != nil {
+		if _, err := io.WriteString(req.Output, yamlBytes); err != nil {
+			return nil, core.NewFileError(req.OutputPath, "write", "failed to write output", err)
+		}
+	} else if err := os.WriteFile(req.OutputPath, []byte(yamlBytes), 0644); err != nil { //nolint:gosec // G306: User owns output file, 0644 is standard readable permission
 		return nil, core.NewFileError(req.OutputPath, "write", "failed to write output file", err)
 	}
 
@@ -110,6 +137,10 @@ func validateCanonicalDoc(doc interface{}) []error {
 		if result := core.ValidateMemory(&d.Memory); result.IsError() {
 			return unwrapCanonicalErrors(result.Error)
 		}
+	case *parser.CanonicalMCPServer:
+		if result := core.ValidateMCPServer(&d.MCPServer); result.IsError() {
+			return unwrapCanonicalErrors(result.Error)
+		}
 	default:
 		return []error{core.NewParseError("", "unknown document type", nil)}
 	}
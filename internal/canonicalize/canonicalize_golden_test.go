@@ -15,7 +15,7 @@ import (
 )
 
 // TestCanonicalizeGoldenFiles runs the canonicalize service against
-// every fixture in test/fixtures/{claude-code,opencode}/ and the
+// every fixture in test/fixtures/{claude-code,opencode,cursor,copilot,gemini,codex}/ and the
 // generic fixtures, comparing the output byte-for-byte against
 // test/golden/canonical/*.yaml.golden.
 //
@@ -103,6 +103,69 @@ func TestCanonicalizeGoldenFiles(t *testing.T) {
 			platform: core.PlatformOpenCode,
 			docType:  "memory",
 		},
+		{
+			name:     "skill-cursor",
+			fixture:  filepath.Join(fixturesDir, "cursor", "git-release.mdc"),
+			golden:   filepath.Join(goldenDir, "skill-cursor.yaml.golden"),
+			platform: core.PlatformCursor,
+			docType:  "skill",
+		},
+		{
+			name:     "command-cursor",
+			fixture:  filepath.Join(fixturesDir, "cursor", "git-release.mdc"),
+			golden:   filepath.Join(goldenDir, "command-cursor.yaml.golden"),
+			platform: core.PlatformCursor,
+			docType:  "command",
+		},
+		{
+			name:     "memory-cursor",
+			fixture:  filepath.Join(fixturesDir, "cursor", "go-style.mdc"),
+			golden:   filepath.Join(goldenDir, "memory-cursor.yaml.golden"),
+			platform: core.PlatformCursor,
+			docType:  "memory",
+		},
+		{
+			name:     "agent-copilot",
+			fixture:  filepath.Join(fixturesDir, "copilot", "planner.chatmode.md"),
+			golden:   filepath.Join(goldenDir, "agent-copilot.yaml.golden"),
+			platform: core.PlatformCopilot,
+			docType:  "agent",
+		},
+		{
+			name:     "command-copilot",
+			fixture:  filepath.Join(fixturesDir, "copilot", "review.prompt.md"),
+			golden:   filepath.Join(goldenDir, "command-copilot.yaml.golden"),
+			platform: core.PlatformCopilot,
+			docType:  "command",
+		},
+		{
+			name:     "memory-copilot",
+			fixture:  filepath.Join(fixturesDir, "copilot", "go-style.instructions.md"),
+			golden:   filepath.Join(goldenDir, "memory-copilot.yaml.golden"),
+			platform: core.PlatformCopilot,
+			docType:  "memory",
+		},
+		{
+			name:     "command-gemini",
+			fixture:  filepath.Join(fixturesDir, "gemini", "review.toml"),
+			golden:   filepath.Join(goldenDir, "command-gemini.yaml.golden"),
+			platform: core.PlatformGemini,
+			docType:  "command",
+		},
+		{
+			name:     "memory-gemini",
+			fixture:  filepath.Join(fixturesDir, "gemini", "GEMINI.md"),
+			golden:   filepath.Join(goldenDir, "memory-gemini.yaml.golden"),
+			platform: core.PlatformGemini,
+			docType:  "memory",
+		},
+		{
+			name:     "memory-codex",
+			fixture:  filepath.Join(fixturesDir, "codex", "AGENTS.md"),
+			golden:   filepath.Join(goldenDir, "memory-codex.yaml.golden"),
+			platform: core.PlatformCodex,
+			docType:  "memory",
+		},
 		{
 			name:     "agent-generic",
 			fixture:  filepath.Join(fixturesDir, "agent-valid.md"),
@@ -13,6 +13,9 @@ import (
 
 	"gitlab.com/amoconst/germinator/internal/canonicalize"
 	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/parser"
+	"gitlab.com/amoconst/germinator/internal/renderer"
+	"gitlab.com/amoconst/germinator/internal/transform"
 )
 
 // writePlatformDoc writes a Markdown file with YAML frontmatter
@@ -93,7 +96,7 @@ Body`,
 			filename: "memory-cc.md",
 			platform: core.PlatformClaudeCode,
 			docType:  "memory",
-			assertIn: []string{"content: |"},
+			assertIn: []string{"paths:\n  - src/**/*.go\n---"},
 		},
 		{
 			name: "agent opencode",
@@ -231,3 +234,126 @@ Body`
 	require.True(t, errors.As(err, &ferr),
 		"write failure must surface as *core.FileError")
 }
+
+func TestService_Canonicalize_MCPServer(t *testing.T) {
+	t.Parallel()
+
+	input := writePlatformDoc(t, ".mcp.json", `{"mcpServers": {
+  "github": {"command": "npx", "args": ["-y", "@scope/server-github"], "env": {"TOKEN": "${TOKEN}"}},
+  "docs": {"type": "http", "url": "https://example.com/mcp"}
+}}`)
+	output := filepath.Join(t.TempDir(), "mcp-github.md")
+
+	_, err := canonicalize.NewService().Canonicalize(context.Background(), &canonicalize.Request{
+		InputPath:  input,
+		OutputPath: output,
+		Platform:   core.PlatformClaudeCode,
+		DocType:    "mcp",
+		Name:       "github",
+	})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, `---
+type: mcp
+name: github
+command: npx
+args:
+  - -y
+  - '@scope/server-github'
+env:
+  TOKEN: ${TOKEN}
+---
+`, string(got))
+
+	_, err = canonicalize.NewService().Canonicalize(context.Background(), &canonicalize.Request{
+		InputPath:  input,
+		OutputPath: output,
+		Platform:   core.PlatformClaudeCode,
+		DocType:    "mcp",
+		Name:       "missing",
+	})
+	var perr *core.ParseError
+	require.True(t, errors.As(err, &perr), "unknown server must surface as *core.ParseError")
+}
+
+func TestService_Canonicalize_PreservesConditionalBlocks(t *testing.T) {
+	t.Parallel()
+
+	block := "<!-- germinator:if platform=claude-code -->\nUse the Task tool.\n<!-- germinator:else -->\nMention @explore.\n<!-- germinator:endif -->\n"
+	input := writePlatformDoc(t, "agent-cc.md", "---\nname: explorer\ndescription: Explores code\n---\n"+block)
+	output := filepath.Join(t.TempDir(), "agent-explorer.md")
+
+	_, err := canonicalize.NewService().Canonicalize(context.Background(), &canonicalize.Request{
+		InputPath:  input,
+		OutputPath: output,
+		Platform:   core.PlatformClaudeCode,
+		DocType:    "agent",
+	})
+	require.NoError(t, err)
+
+	contents, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), block)
+}
+
+// TestService_Canonicalize_ThenAdapt checks that canonicalize output
+// declares its type, so adapt can render it without --type even when
+// the file name does not match a detection pattern.
+func TestService_Canonicalize_ThenAdapt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		docType string
+		body    string
+		want    string
+	}{
+		{
+			name:    "agent",
+			docType: "agent",
+			body:    "---\nname: reviewer\ndescription: Reviews code\ntools:\n  - WebFetch\n---\nReview the diff.\n",
+			want:    "  - WebFetch",
+		},
+		{
+			name:    "memory",
+			docType: "memory",
+			body:    "Use tabs.\n",
+			want:    "Use tabs.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			input := writePlatformDoc(t, "platform.md", tt.body)
+			canonical := filepath.Join(dir, "canonical.md")
+
+			_, err := canonicalize.NewService().Canonicalize(context.Background(), &canonicalize.Request{
+				InputPath:  input,
+				OutputPath: canonical,
+				Platform:   core.PlatformClaudeCode,
+				DocType:    tt.docType,
+			})
+			require.NoError(t, err)
+
+			written, err := os.ReadFile(canonical)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(string(written), "---\ntype: "+tt.docType+"\n"), "got:\n%s", written)
+
+			output := filepath.Join(dir, "rendered.md")
+			_, err = transform.NewService(parser.NewParser(), renderer.NewSerializer()).Transform(context.Background(), &transform.Request{
+				InputPath:  canonical,
+				OutputPath: output,
+				Platform:   core.PlatformClaudeCode,
+			})
+			require.NoError(t, err)
+
+			rendered, err := os.ReadFile(output)
+			require.NoError(t, err)
+			assert.Contains(t, string(rendered), tt.want)
+		})
+	}
+}
@@ -8,8 +8,10 @@ package claudecode
 
 import (
 	"fmt"
+	"strings"
 
 	"gitlab.com/amoconst/germinator/internal/core"
+	coreclaudecode "gitlab.com/amoconst/germinator/internal/core/claudecode"
 	"gitlab.com/amoconst/germinator/internal/permission"
 )
 
@@ -76,6 +78,24 @@ func (a *Adapter) FromCanonical(docType string, doc interface{}) (map[string]int
 			return nil, core.NewTransformError("from-canonical", "claude-code", fmt.Sprintf("expected *core.Memory, got %T", doc), nil)
 		}
 		return a.renderMemory(mem)
+	case "mcp":
+		server, ok := doc.(*core.MCPServer)
+		if !ok {
+			return nil, core.NewTransformError("from-canonical", "claude-code", fmt.Sprintf("expected *core.MCPServer, got %T", doc), nil)
+		}
+		return a.renderMCPServer(server)
+	case "hook":
+		hook, ok := doc.(*core.Hook)
+		if !ok {
+			return nil, core.NewTransformError("from-canonical", "claude-code", fmt.Sprintf("expected *core.Hook, got %T", doc), nil)
+		}
+		return a.renderHook(hook)
+	case "settings":
+		settings, ok := doc.(*core.Settings)
+		if !ok {
+			return nil, core.NewTransformError("from-canonical", "claude-code", fmt.Sprintf("expected *core.Settings, got %T", doc), nil)
+		}
+		return a.renderSettings(settings)
 	default:
 		return nil, core.NewTransformError("from-canonical", "claude-code", "unknown document type: "+docType, nil)
 	}
@@ -91,12 +111,66 @@ func (a *Adapter) PermissionPolicyToPlatform(policy core.PermissionPolicy) (inte
 	return mapping.ClaudeCode, nil
 }
 
-// ConvertToolNameCase converts a tool name to PascalCase format used by Claude Code.
+// unsupportedFields lists, per document type, the canonical fields the
+// Claude Code templates do not render. Every agent, command, skill, and
+// memory field has a Claude Code equivalent.
+var unsupportedFields = map[string][]core.UnsupportedField{
+	"mcp": {
+		{Field: "enabled", Reason: "Claude Code .mcp.json has no per-server enabled flag"},
+	},
+}
+
+// knownFields lists, per document type, the frontmatter keys
+// ToCanonical reads from a Claude Code file. Any other key, such as an
+// agent's color, is kept under targets.claude-code by the parser and
+// written back by the templates.
+var knownFields = map[string][]string{
+	"agent": {
+		"name", "description", "tools", "disallowedTools", "permissionMode", "permissions",
+		"mode", "temperature", "maxSteps", "prompt", "hidden", "disabled", "hooks", "model",
+		"targets", "skills", "disable-model-invocation",
+	},
+	"command": {
+		"name", "description", "tools", "execution", "arguments", "context", "subtask", "agent",
+		"argument-hint", "model", "targets", "disable-model-invocation",
+	},
+	"skill": {
+		"name", "description", "tools", "extensions", "execution", "license", "compatibility", "metadata",
+		"hooks", "context", "agent", "user-invocable", "model", "targets", "disable-model-invocation",
+	},
+}
+
+// KnownFields returns the frontmatter keys Claude Code documents of
+// docType are read from, or nil for types without frontmatter targets.
+func (a *Adapter) KnownFields(docType string) []string {
+	return knownFields[docType]
+}
+
+// UnsupportedFields returns the canonical fields Claude Code cannot
+// represent for docType; convert reports them as dropped when they are
+// set.
+func (a *Adapter) UnsupportedFields(docType string) []core.UnsupportedField {
+	return unsupportedFields[docType]
+}
+
+// ConvertToolNameCase converts a tool name to the spelling Claude Code
+// uses (coreclaudecode.ToolName): built-in tools by their exact name,
+// other tools in PascalCase.
 func (a *Adapter) ConvertToolNameCase(name string) string {
-	return permission.ToPascalCase(name)
+	return coreclaudecode.ToolName(name)
+}
+
+// canonicalToolName lowercases a Claude Code tool name for a canonical
+// document, keeping a Tool(spec) specifier verbatim: Bash(npm run:*)
+// becomes bash(npm run:*).
+func canonicalToolName(name string) string {
+	if open := strings.IndexByte(name, '('); open > 0 {
+		return permission.ToLowerCase(name[:open]) + name[open:]
+	}
+	return permission.ToLowerCase(name)
 }
 
-//nolint:gocognit,gocyclo,unparam // parseAgent has high complexity due to nested map structure
+//nolint:gocognit,gocyclo // parseAgent has high complexity due to nested map structure
 func (a *Adapter) parseAgent(input map[string]interface{}) (*core.Agent, error) {
 	agent := &core.Agent{}
 
@@ -110,30 +184,39 @@ func (a *Adapter) parseAgent(input map[string]interface{}) (*core.Agent, error)
 	if tools, ok := input["tools"].([]interface{}); ok {
 		for _, t := range tools {
 			if toolName, ok := t.(string); ok {
-				agent.Tools = append(agent.Tools, permission.ToLowerCase(toolName))
+				agent.Tools = append(agent.Tools, canonicalToolName(toolName))
 			}
 		}
 	} else if tools, ok := input["tools"].([]string); ok {
 		for _, toolName := range tools {
-			agent.Tools = append(agent.Tools, permission.ToLowerCase(toolName))
+			agent.Tools = append(agent.Tools, canonicalToolName(toolName))
 		}
 	}
 
 	if disallowedTools, ok := input["disallowedTools"].([]interface{}); ok {
 		for _, t := range disallowedTools {
 			if toolName, ok := t.(string); ok {
-				agent.DisallowedTools = append(agent.DisallowedTools, permission.ToLowerCase(toolName))
+				agent.DisallowedTools = append(agent.DisallowedTools, canonicalToolName(toolName))
 			}
 		}
 	} else if disallowedTools, ok := input["disallowedTools"].([]string); ok {
 		for _, toolName := range disallowedTools {
-			agent.DisallowedTools = append(agent.DisallowedTools, permission.ToLowerCase(toolName))
+			agent.DisallowedTools = append(agent.DisallowedTools, canonicalToolName(toolName))
 		}
 	}
 
 	if permissionMode, ok := input["permissionMode"].(string); ok {
 		agent.PermissionPolicy = a.mapPermissionModeToPolicy(permissionMode)
 	}
+	if perms, ok := input["permissions"].(map[string]interface{}); ok {
+		rules, err := permission.ParseClaudeCodePermissions(perms)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // typed *core.ConfigError propagates as-is for callers to errors.As dispatch
+		}
+		if len(rules) > 0 {
+			agent.Permissions = rules
+		}
+	}
 
 	agent.Behavior = core.AgentBehavior{}
 	if mode, ok := input["mode"].(string); ok {
@@ -142,8 +225,12 @@ func (a *Adapter) parseAgent(input map[string]interface{}) (*core.Agent, error)
 	if temperature, ok := input["temperature"].(float64); ok {
 		agent.Behavior.Temperature = &temperature
 	}
-	if maxSteps, ok := input["maxSteps"].(int); ok {
+	// YAML frontmatter decodes whole numbers as int, JSON as float64.
+	switch maxSteps := input["maxSteps"].(type) {
+	case int:
 		agent.Behavior.Steps = maxSteps
+	case float64:
+		agent.Behavior.Steps = int(maxSteps)
 	}
 	if prompt, ok := input["prompt"].(string); ok {
 		agent.Behavior.Prompt = prompt
@@ -189,6 +276,7 @@ func (a *Adapter) parseAgent(input map[string]interface{}) (*core.Agent, error)
 		}
 		agent.Targets["claude-code"]["skills"] = skillNames
 	}
+	a.parseDisableModelInvocation(input, agent.Targets)
 
 	return agent, nil
 }
@@ -222,6 +310,13 @@ func (a *Adapter) renderAgent(agent *core.Agent) (map[string]interface{}, error)
 		}
 		output["permissionMode"] = permissionMode
 	}
+	if len(agent.Permissions) > 0 {
+		perms := make(map[string][]string)
+		for action, rules := range permission.ClaudeCodePermissions(agent.Permissions) {
+			perms[string(action)] = rules
+		}
+		output["permissions"] = perms
+	}
 
 	if agent.Behavior.Mode != "" {
 		output["mode"] = agent.Behavior.Mode
@@ -276,7 +371,7 @@ func (a *Adapter) parseCommand(input map[string]interface{}) (*core.Command, err
 	if tools, ok := input["tools"].([]interface{}); ok {
 		for _, t := range tools {
 			if toolName, ok := t.(string); ok {
-				cmd.Tools = append(cmd.Tools, permission.ToLowerCase(toolName))
+				cmd.Tools = append(cmd.Tools, canonicalToolName(toolName))
 			}
 		}
 	}
@@ -301,6 +396,21 @@ func (a *Adapter) parseCommand(input map[string]interface{}) (*core.Command, err
 		}
 	}
 
+	// Claude Code command files carry these flat, as the template
+	// writes them.
+	if context, ok := input["context"].(string); ok {
+		cmd.Execution.Context = context
+	}
+	if subtask, ok := input["subtask"].(bool); ok {
+		cmd.Execution.Subtask = subtask
+	}
+	if agent, ok := input["agent"].(string); ok {
+		cmd.Execution.Agent = agent
+	}
+	if hint, ok := input["argument-hint"].(string); ok {
+		cmd.Arguments.Hint = hint
+	}
+
 	if model, ok := input["model"].(string); ok {
 		cmd.Model = model
 	}
@@ -309,6 +419,7 @@ func (a *Adapter) parseCommand(input map[string]interface{}) (*core.Command, err
 	if targets, ok := input["targets"].(map[string]interface{}); ok {
 		cmd.Targets = a.parseTargets(targets)
 	}
+	a.parseDisableModelInvocation(input, cmd.Targets)
 
 	return cmd, nil
 }
@@ -368,7 +479,7 @@ func (a *Adapter) parseSkill(input map[string]interface{}) (*core.Skill, error)
 	if tools, ok := input["tools"].([]interface{}); ok {
 		for _, t := range tools {
 			if toolName, ok := t.(string); ok {
-				skill.Tools = append(skill.Tools, permission.ToLowerCase(toolName))
+				skill.Tools = append(skill.Tools, canonicalToolName(toolName))
 			}
 		}
 	}
@@ -416,6 +527,8 @@ func (a *Adapter) parseSkill(input map[string]interface{}) (*core.Skill, error)
 		}
 	}
 
+	a.parseFlatSkillFields(input, skill)
+
 	if model, ok := input["model"].(string); ok {
 		skill.Model = model
 	}
@@ -424,10 +537,67 @@ func (a *Adapter) parseSkill(input map[string]interface{}) (*core.Skill, error)
 	if targets, ok := input["targets"].(map[string]interface{}); ok {
 		skill.Targets = a.parseTargets(targets)
 	}
+	a.parseDisableModelInvocation(input, skill.Targets)
 
 	return skill, nil
 }
 
+// parseFlatSkillFields reads the skill fields that Claude Code skill
+// files carry at the top level, as the template writes them, over the
+// nested canonical layout.
+func (a *Adapter) parseFlatSkillFields(input map[string]interface{}, skill *core.Skill) {
+	if license, ok := input["license"].(string); ok {
+		skill.Extensions.License = license
+	}
+	if compatibility, ok := input["compatibility"].([]interface{}); ok {
+		skill.Extensions.Compatibility = nil
+		for _, c := range compatibility {
+			if comp, ok := c.(string); ok {
+				skill.Extensions.Compatibility = append(skill.Extensions.Compatibility, comp)
+			}
+		}
+	}
+	if metadata, ok := input["metadata"].(map[string]interface{}); ok {
+		skill.Extensions.Metadata = make(map[string]string)
+		for k, v := range metadata {
+			if val, ok := v.(string); ok {
+				skill.Extensions.Metadata[k] = val
+			}
+		}
+	}
+	if hooks, ok := input["hooks"].(map[string]interface{}); ok {
+		skill.Extensions.Hooks = make(map[string]string)
+		for k, v := range hooks {
+			if val, ok := v.(string); ok {
+				skill.Extensions.Hooks[k] = val
+			}
+		}
+	}
+	if context, ok := input["context"].(string); ok {
+		skill.Execution.Context = context
+	}
+	if agent, ok := input["agent"].(string); ok {
+		skill.Execution.Agent = agent
+	}
+	if userInvocable, ok := input["user-invocable"].(bool); ok {
+		skill.Execution.UserInvocable = userInvocable
+	}
+}
+
+// parseDisableModelInvocation records a top-level
+// disable-model-invocation flag under targets.claude-code, where the
+// canonical model keeps it.
+func (a *Adapter) parseDisableModelInvocation(input map[string]interface{}, targets core.PlatformConfig) {
+	disable, ok := input["disable-model-invocation"].(bool)
+	if !ok {
+		return
+	}
+	if targets["claude-code"] == nil {
+		targets["claude-code"] = make(map[string]interface{})
+	}
+	targets["claude-code"]["disable-model-invocation"] = disable
+}
+
 func (a *Adapter) renderSkill(skill *core.Skill) (map[string]interface{}, error) {
 	output := make(map[string]interface{})
 	output["__type"] = "skill"
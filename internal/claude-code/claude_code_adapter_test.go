@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	canonical "gitlab.com/amoconst/germinator/internal/core"
+	coreclaudecode "gitlab.com/amoconst/germinator/internal/core/claudecode"
 )
 
 func TestConvertToolNameCase(t *testing.T) {
@@ -20,6 +21,9 @@ func TestConvertToolNameCase(t *testing.T) {
 		{"hyphenated", "write-to-file", "WriteToFile"},
 		{"multiple hyphens", "read-from-github", "ReadFromGithub"},
 		{"already pascal", "Bash", "Bash"},
+		{"lowercase built-in", "webfetch", "WebFetch"},
+		{"specifier", "bash(git:*)", "Bash(git:*)"},
+		{"all tools", "*", "*"},
 		{"empty", "", ""},
 	}
 
@@ -33,6 +37,39 @@ func TestConvertToolNameCase(t *testing.T) {
 	}
 }
 
+// TestToolsRoundTrip checks that tools canonicalize writes pass the
+// Claude Code validators and render back to the original names.
+func TestToolsRoundTrip(t *testing.T) {
+	tools := []string{"WebFetch", "TodoWrite", "Agent", "LSP", "Bash(git:*)", "Read(./Docs/**)", "*", "mcp__github__create_issue"}
+
+	agent, _, _, _, err := ClaudeCode.ToCanonical(map[string]interface{}{
+		"__type":          "agent",
+		"name":            "reviewer",
+		"description":     "Reviews code",
+		"tools":           toInterfaces(tools),
+		"disallowedTools": []interface{}{"NotebookEdit"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, agent.Tools, "webfetch")
+	assert.Contains(t, agent.Tools, "read(./Docs/**)")
+
+	result := coreclaudecode.ValidateAgentTools(agent)
+	require.True(t, result.IsSuccess(), "canonical tools must validate: %v", result.Error)
+
+	output, err := ClaudeCode.FromCanonical("agent", agent)
+	require.NoError(t, err)
+	assert.Equal(t, tools, output["tools"])
+	assert.Equal(t, []string{"NotebookEdit"}, output["disallowedTools"])
+}
+
+func toInterfaces(values []string) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
 func TestPermissionPolicyToPlatform(t *testing.T) {
 	adapter := ClaudeCode
 
@@ -1215,6 +1252,54 @@ func TestParseAgent_BehaviorFields(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, agent.Behavior.Disabled)
 	})
+
+	t.Run("maxSteps from JSON", func(t *testing.T) {
+		input := map[string]interface{}{
+			"__type":      "agent",
+			"description": "test",
+			"maxSteps":    float64(12),
+		}
+		agent, _, _, _, err := adapter.ToCanonical(input)
+		require.NoError(t, err)
+		assert.Equal(t, 12, agent.Behavior.Steps)
+	})
+}
+
+// TestToCanonical_FlatFrontmatter covers the top-level keys the Claude
+// Code templates write for commands and skills, which parse back into
+// the nested canonical fields.
+func TestToCanonical_FlatFrontmatter(t *testing.T) {
+	adapter := ClaudeCode
+
+	_, cmd, _, _, err := adapter.ToCanonical(map[string]interface{}{
+		"__type":                   "command",
+		"description":              "test",
+		"context":                  "fork",
+		"agent":                    "reviewer",
+		"subtask":                  true,
+		"argument-hint":            "[issue-number]",
+		"disable-model-invocation": true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, canonical.CommandExecution{Context: "fork", Agent: "reviewer", Subtask: true}, cmd.Execution)
+	assert.Equal(t, "[issue-number]", cmd.Arguments.Hint)
+	assert.Equal(t, true, cmd.Targets["claude-code"]["disable-model-invocation"])
+
+	_, _, skill, _, err := adapter.ToCanonical(map[string]interface{}{
+		"__type":         "skill",
+		"description":    "test",
+		"license":        "MIT",
+		"compatibility":  []interface{}{"claude-code"},
+		"metadata":       map[string]interface{}{"author": "team"},
+		"context":        "fork",
+		"agent":          "reviewer",
+		"user-invocable": true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "MIT", skill.Extensions.License)
+	assert.Equal(t, []string{"claude-code"}, skill.Extensions.Compatibility)
+	assert.Equal(t, map[string]string{"author": "team"}, skill.Extensions.Metadata)
+	assert.Equal(t, canonical.SkillExecution{Context: "fork", Agent: "reviewer", UserInvocable: true}, skill.Execution)
 }
 
 // TestRenderAgent_AllFields covers renderAgent (claude_code_adapter.go:196)
@@ -0,0 +1,20 @@
+package claudecode
+
+import "gitlab.com/amoconst/germinator/internal/core"
+
+// renderHook returns the .claude/settings.json fragment for one hook: a
+// single matcher group under the hook's event.
+func (a *Adapter) renderHook(hook *core.Hook) (map[string]interface{}, error) {
+	command := map[string]interface{}{"type": "command", "command": hook.Command}
+	if hook.Timeout > 0 {
+		command["timeout"] = hook.Timeout
+	}
+	group := map[string]interface{}{"hooks": []interface{}{command}}
+	if hook.Matcher != "" {
+		group["matcher"] = hook.Matcher
+	}
+	return map[string]interface{}{
+		"__type": "hook",
+		"hooks":  map[string]interface{}{hook.Event: []interface{}{group}},
+	}, nil
+}
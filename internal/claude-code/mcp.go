@@ -0,0 +1,70 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"sort"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+// mcpConfig is the shape of Claude Code's project .mcp.json.
+type mcpConfig struct {
+	MCPServers map[string]mcpServerEntry `json:"mcpServers"`
+}
+
+// mcpServerEntry is one server in .mcp.json. Type is "stdio" for local
+// servers (the default when omitted) and "http" or "sse" for remote ones.
+type mcpServerEntry struct {
+	Type    string            `json:"type,omitempty"`
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// DecodeMCPServers reads the servers of a .mcp.json file, sorted by
+// name.
+func (a *Adapter) DecodeMCPServers(content []byte) ([]core.MCPServer, error) {
+	var cfg mcpConfig
+	if err := json.Unmarshal(content, &cfg); err != nil {
+		return nil, core.NewParseError("", "invalid .mcp.json", err)
+	}
+
+	servers := make([]core.MCPServer, 0, len(cfg.MCPServers))
+	for name, entry := range cfg.MCPServers {
+		servers = append(servers, core.MCPServer{
+			Name:    name,
+			Command: entry.Command,
+			Args:    entry.Args,
+			Env:     entry.Env,
+			URL:     entry.URL,
+			Headers: entry.Headers,
+		})
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].Name < servers[j].Name })
+	return servers, nil
+}
+
+func (a *Adapter) renderMCPServer(server *core.MCPServer) (map[string]interface{}, error) {
+	entry := make(map[string]interface{})
+	if server.IsRemote() {
+		entry["type"] = "http"
+		entry["url"] = server.URL
+		if len(server.Headers) > 0 {
+			entry["headers"] = server.Headers
+		}
+	} else {
+		entry["command"] = server.Command
+		if len(server.Args) > 0 {
+			entry["args"] = server.Args
+		}
+		if len(server.Env) > 0 {
+			entry["env"] = server.Env
+		}
+	}
+	return map[string]interface{}{
+		"__type":     "mcp",
+		"mcpServers": map[string]interface{}{server.Name: entry},
+	}, nil
+}
@@ -0,0 +1,47 @@
+package claudecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	canonical "gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestDecodeMCPServers(t *testing.T) {
+	servers, err := ClaudeCode.DecodeMCPServers([]byte(`{
+  "mcpServers": {
+    "github": {"command": "npx", "args": ["-y", "server-github"], "env": {"TOKEN": "x"}},
+    "docs": {"type": "http", "url": "https://example.com/mcp", "headers": {"Authorization": "Bearer x"}}
+  },
+  "other": true
+}`))
+	require.NoError(t, err)
+	assert.Equal(t, []canonical.MCPServer{
+		{Name: "docs", URL: "https://example.com/mcp", Headers: map[string]string{"Authorization": "Bearer x"}},
+		{Name: "github", Command: "npx", Args: []string{"-y", "server-github"}, Env: map[string]string{"TOKEN": "x"}},
+	}, servers)
+
+	_, err = ClaudeCode.DecodeMCPServers([]byte(`{"mcpServers": [`))
+	var parseErr *canonical.ParseError
+	require.ErrorAs(t, err, &parseErr)
+}
+
+func TestFromCanonicalMCP(t *testing.T) {
+	local, err := ClaudeCode.FromCanonical("mcp", &canonical.MCPServer{
+		Name: "github", Command: "npx", Args: []string{"-y"}, Env: map[string]string{"TOKEN": "x"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"command": "npx", "args": []string{"-y"}, "env": map[string]string{"TOKEN": "x"},
+	}, local["mcpServers"].(map[string]interface{})["github"])
+
+	remote, err := ClaudeCode.FromCanonical("mcp", &canonical.MCPServer{Name: "docs", URL: "https://example.com/mcp"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"type": "http", "url": "https://example.com/mcp",
+	}, remote["mcpServers"].(map[string]interface{})["docs"])
+
+	_, err = ClaudeCode.FromCanonical("mcp", &canonical.Agent{})
+	require.Error(t, err)
+}
@@ -0,0 +1,36 @@
+package claudecode
+
+import (
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/permission"
+)
+
+// renderSettings returns the .claude/settings.json fragment for one
+// settings resource. The permission policy becomes permissions.defaultMode
+// and the rules the allow, ask, and deny lists.
+func (a *Adapter) renderSettings(settings *core.Settings) (map[string]interface{}, error) {
+	output := map[string]interface{}{"__type": "settings"}
+	if settings.Model != "" {
+		output["model"] = settings.Model
+	}
+
+	permissions := make(map[string]interface{})
+	for action, rules := range permission.ClaudeCodePermissions(settings.Permissions) {
+		permissions[string(action)] = rules
+	}
+	if settings.PermissionPolicy != "" {
+		mode, err := a.PermissionPolicyToPlatform(settings.PermissionPolicy)
+		if err != nil {
+			return nil, err
+		}
+		permissions["defaultMode"] = mode
+	}
+	if len(permissions) > 0 {
+		output["permissions"] = permissions
+	}
+
+	if len(settings.Env) > 0 {
+		output["env"] = settings.Env
+	}
+	return output, nil
+}
@@ -0,0 +1,96 @@
+// Package codex provides an adapter implementation for transforming between
+// AGENTS.md instruction files and canonical domain models.
+package codex
+
+import (
+	"fmt"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/permission"
+)
+
+// unsupportedFields lists, per document type, the canonical fields that
+// AGENTS.md cannot represent.
+var unsupportedFields = map[string][]core.UnsupportedField{
+	"memory": {
+		{Field: "paths", Reason: "AGENTS.md has no path scope; paths only choose the directory it is written to"},
+	},
+}
+
+// Adapter implements the Adapter interface for the Codex platform.
+type Adapter struct{}
+
+// Codex is the package-level singleton for the Codex Adapter.
+// The adapter is stateless; a single shared instance is safe to use across goroutines.
+var Codex = &Adapter{}
+
+// ToCanonical converts AGENTS.md content into canonical memory. Agents,
+// commands, and skills are rejected because AGENTS.md holds instructions only.
+func (a *Adapter) ToCanonical(input map[string]interface{}) (*core.Agent, *core.Command, *core.Skill, *core.Memory, error) {
+	docType, ok := input["__type"].(string)
+	if !ok {
+		return nil, nil, nil, nil, core.NewParseError("", "missing __type field", nil)
+	}
+
+	switch docType {
+	case "memory":
+		return nil, nil, nil, &core.Memory{}, nil
+	case "agent", "command", "skill":
+		return nil, nil, nil, nil, core.NewParseError("", "AGENTS.md cannot be read as "+docType+" documents", nil).
+			WithSuggestions([]string{"use --type memory"})
+	default:
+		return nil, nil, nil, nil, core.NewParseError("", "unknown document type: "+docType, nil)
+	}
+}
+
+// FromCanonical converts canonical memory to an AGENTS.md field map.
+func (a *Adapter) FromCanonical(docType string, doc interface{}) (map[string]interface{}, error) {
+	switch docType {
+	case "memory":
+		mem, ok := doc.(*core.Memory)
+		if !ok {
+			return nil, core.NewTransformError("from-canonical", core.PlatformCodex, fmt.Sprintf("expected *core.Memory, got %T", doc), nil)
+		}
+		output := map[string]interface{}{"__type": "memory"}
+		if mem.Content != "" {
+			output["content"] = mem.Content
+		}
+		return output, nil
+	case "agent", "command", "skill":
+		return nil, core.NewTransformError("from-canonical", core.PlatformCodex, "codex does not support "+docType+" documents", nil)
+	default:
+		return nil, core.NewTransformError("from-canonical", core.PlatformCodex, "unknown document type: "+docType, nil)
+	}
+}
+
+// PermissionPolicyToPlatform validates the policy and returns nil:
+// AGENTS.md carries no permission settings.
+func (a *Adapter) PermissionPolicyToPlatform(policy core.PermissionPolicy) (interface{}, error) {
+	if _, ok := permission.PermissionPolicyMappings[string(policy)]; !ok {
+		return nil, core.NewConfigError("permission-policy", string(policy), "unknown permission policy")
+	}
+	return nil, nil //nolint:nilnil // no permission representation exists; nil is the platform value
+}
+
+// UnsupportedFields returns the canonical fields AGENTS.md cannot
+// represent for docType; convert reports them as dropped when they are
+// set.
+func (a *Adapter) UnsupportedFields(docType string) []core.UnsupportedField {
+	return unsupportedFields[docType]
+}
+
+// ConvertToolNameCase returns the canonical lowercase name. AGENTS.md
+// does not reference tools, so no platform casing applies.
+func (a *Adapter) ConvertToolNameCase(name string) string {
+	return permission.ToLowerCase(name)
+}
+
+// DecodeDocument reads AGENTS.md as plain Markdown with no frontmatter,
+// dropping germinator section markers. Other document types are left to
+// the default parser, which rejects them through ToCanonical.
+func (a *Adapter) DecodeDocument(docType string, content []byte) (map[string]interface{}, string, bool, error) {
+	if docType != "memory" {
+		return nil, "", false, nil
+	}
+	return map[string]interface{}{}, core.StripSectionMarkers(string(content)), true, nil
+}
@@ -0,0 +1,49 @@
+package codex
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	canonical "gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestDecodeDocumentMemory(t *testing.T) {
+	content := []byte("# Project\n\n<!-- germinator:begin memory/style -->\nBe concise.\n<!-- germinator:end memory/style -->\n")
+
+	fields, body, handled, err := Codex.DecodeDocument("memory", content)
+	require.NoError(t, err)
+	assert.True(t, handled)
+	assert.Empty(t, fields)
+	assert.Equal(t, "# Project\n\nBe concise.\n", body)
+
+	_, _, handled, err = Codex.DecodeDocument("agent", content)
+	require.NoError(t, err)
+	assert.False(t, handled, "non-memory types fall through to the default parser")
+}
+
+func TestToCanonical(t *testing.T) {
+	_, _, _, mem, err := Codex.ToCanonical(map[string]interface{}{"__type": "memory"})
+	require.NoError(t, err)
+	assert.NotNil(t, mem)
+
+	for _, docType := range []string{"agent", "command", "skill"} {
+		_, _, _, _, err = Codex.ToCanonical(map[string]interface{}{"__type": docType})
+		var parseErr *canonical.ParseError
+		require.True(t, errors.As(err, &parseErr), docType)
+	}
+}
+
+func TestFromCanonical(t *testing.T) {
+	out, err := Codex.FromCanonical("memory", &canonical.Memory{Content: "Be concise."})
+	require.NoError(t, err)
+	assert.Equal(t, "Be concise.", out["content"])
+
+	_, err = Codex.FromCanonical("skill", &canonical.Skill{})
+	var transformErr *canonical.TransformError
+	require.True(t, errors.As(err, &transformErr))
+
+	_, err = Codex.FromCanonical("memory", &canonical.Agent{})
+	require.Error(t, err)
+}
@@ -0,0 +1,39 @@
+package codex
+
+// Package codex implements the Codex adapter for bidirectional conversion
+// between canonical memory and AGENTS.md, the instruction file read by
+// the OpenAI Codex CLI and other agents that follow the AGENTS.md
+// convention.
+//
+// Document Mapping
+//
+//   memory (no paths) → AGENTS.md at the project root
+//   memory (paths)    → <dir>/AGENTS.md, where <dir> is the directory the
+//                       paths share (core.PathsDirectory)
+//
+// AGENTS.md holds instructions only; agents, commands, and skills are
+// rejected. Memory paths choose the nested file and are not written into
+// it, so glob precision below directory level is lost.
+//
+// Merged Sections
+//
+// Several memory resources share one AGENTS.md. The install service
+// writes each as a section delimited by stable markers named after the
+// library ref:
+//
+//   <!-- germinator:begin memory/go-style -->
+//   ...
+//   <!-- germinator:end memory/go-style -->
+//
+// Re-running init replaces only those sections; hand-written text around
+// them is preserved (core.MergeSection). When AGENTS.md is read back, the
+// marker lines are dropped so they do not leak into canonical documents.
+//
+// Usage Example
+//
+//   import "gitlab.com/amoconst/germinator/internal/codex"
+//
+//   adapter := codex.Codex
+//
+//   // Read an AGENTS.md as canonical memory
+//   fields, body, _, err := adapter.DecodeDocument("memory", content)
@@ -8,6 +8,7 @@ import (
 
 	gerrors "gitlab.com/amoconst/germinator/internal/core"
 	"gitlab.com/amoconst/germinator/internal/paths"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 )
 
 // Config holds the application configuration.
@@ -18,6 +19,7 @@ import (
 //     `GERMINATOR_PLATFORM` (NOT `GERMINATOR_PLATFORM_DEFAULT`; the
 //     prefix is stripped and the remaining key is lowercased)
 //   - `Config.Debug` ← `GERMINATOR_DEBUG`
+//   - `Config.Templates` ← `GERMINATOR_TEMPLATES`
 //   - `Config.Completion.Timeout` ← `GERMINATOR_COMPLETION.TIMEOUT`
 //   - `Config.Completion.CacheTTL` ← `GERMINATOR_COMPLETION.CACHE_TTL`
 //
@@ -32,8 +34,8 @@ type Config struct {
 	// `adrg/xdg.DataFile("germinator/library")`).
 	Library string `koanf:"library"`
 
-	// PlatformDefault is the default target platform
-	// (`claude-code` or `opencode`) for commands that opt in via a
+	// PlatformDefault is the default target platform (any ID in the
+	// platform registry, e.g. `claude-code`) for commands that opt in via a
 	// follow-up change. Empty means platform must be specified via
 	// flag (the historical default). The koanf tag remains `platform`
 	// so existing config files continue to bind the same key.
@@ -44,8 +46,22 @@ type Config struct {
 	// the bool truthiness rule documented on the package.
 	Debug bool `koanf:"debug"`
 
+	// Templates is a template override directory laid out like the
+	// bundled set (`<platform>/<type>.tmpl`). Templates it holds replace
+	// the bundled ones for every render; a library's own template
+	// overrides take precedence over it. Empty means bundled templates
+	// only.
+	Templates string `koanf:"templates"`
+
 	// Completion holds the shell completion configuration.
 	Completion CompletionConfig `koanf:"completion"`
+
+	// Models maps logical model names to each platform's model id
+	// (`[models.smart]` tables of `<platform> = "<model>"`). They are
+	// merged over `core.DefaultModelAliases()`; a library's own
+	// `models:` take precedence over them. Nil means the built-in
+	// aliases only.
+	Models gerrors.ModelAliases `koanf:"models"`
 }
 
 // CompletionConfig holds configuration for shell completion.
@@ -70,6 +86,7 @@ func DefaultConfig() *Config {
 		Library:         "",
 		PlatformDefault: "",
 		Debug:           false,
+		Templates:       "",
 		Completion: CompletionConfig{
 			Timeout:  "500ms",
 			CacheTTL: "5s",
@@ -86,6 +103,7 @@ func DefaultConfig() *Config {
 //   - *core.ConfigError for unknown PlatformDefault
 //   - *core.ConfigError for unparseable Completion.Timeout
 //   - *core.ConfigError for unparseable Completion.CacheTTL
+//   - *core.ConfigError for a Models entry naming an unknown platform
 //
 // Empty Completion durations are valid (the helper layer falls back to
 // defaults). Debug is always valid (bool); Library is always valid (empty
@@ -93,14 +111,14 @@ func DefaultConfig() *Config {
 func (c *Config) Validate() error {
 	var errs []error
 
-	if c.PlatformDefault != "" &&
-		c.PlatformDefault != gerrors.PlatformClaudeCode &&
-		c.PlatformDefault != gerrors.PlatformOpenCode {
-		errs = append(errs, gerrors.NewConfigError(
-			"platform",
-			c.PlatformDefault,
-			"unknown platform",
-		).WithSuggestions([]string{gerrors.PlatformClaudeCode, gerrors.PlatformOpenCode}))
+	if c.PlatformDefault != "" {
+		if _, ok := platforms.Lookup(c.PlatformDefault); !ok {
+			errs = append(errs, gerrors.NewConfigError(
+				"platform",
+				c.PlatformDefault,
+				"unknown platform",
+			).WithSuggestions(platforms.IDs()))
+		}
 	}
 
 	if err := validateDuration("completion.timeout", c.Completion.Timeout); err != nil {
@@ -109,6 +127,9 @@ func (c *Config) Validate() error {
 	if err := validateDuration("completion.cache_ttl", c.Completion.CacheTTL); err != nil {
 		errs = append(errs, err)
 	}
+	if err := c.Models.Validate(platforms.IDs()); err != nil {
+		errs = append(errs, err)
+	}
 
 	if len(errs) > 0 {
 		return errors.Join(errs...)
@@ -138,12 +159,18 @@ func validateDuration(field, raw string) error {
 //
 // Tilde expansion is delegated to internal/paths.ExpandHome so this
 // package and cmd/completions share a single canonical implementation;
-// behavior is otherwise unchanged.
+// behavior is otherwise unchanged. Library and Templates are expanded.
 func (c *Config) ExpandPaths() error {
 	expanded, err := paths.ExpandHome(c.Library)
 	if err != nil {
 		return gerrors.NewConfigError("path", c.Library, err.Error())
 	}
 	c.Library = expanded
+
+	expanded, err = paths.ExpandHome(c.Templates)
+	if err != nil {
+		return gerrors.NewConfigError("templates", c.Templates, err.Error())
+	}
+	c.Templates = expanded
 	return nil
 }
@@ -67,6 +67,7 @@ func TestConfigValidate(t *testing.T) {
 		name             string
 		platform         string
 		completion       CompletionConfig
+		models           gerrors.ModelAliases
 		wantErr          bool
 		errContains      string
 		errFieldContains string
@@ -139,6 +140,18 @@ func TestConfigValidate(t *testing.T) {
 			wantErr:     true,
 			errContains: "completion.timeout",
 		},
+		{
+			name:    "model aliases for known platforms are valid",
+			models:  gerrors.ModelAliases{"smart": {gerrors.PlatformOpenCode: "anthropic/claude-opus-4-1"}},
+			wantErr: false,
+		},
+		{
+			name:             "model alias for unknown platform returns ConfigError",
+			models:           gerrors.ModelAliases{"smart": {"claude": "opus"}},
+			wantErr:          true,
+			errContains:      "unknown platform",
+			errFieldContains: "models.smart",
+		},
 	}
 
 	for _, tt := range tests {
@@ -146,6 +159,7 @@ func TestConfigValidate(t *testing.T) {
 			cfg := &Config{
 				PlatformDefault: tt.platform,
 				Completion:      tt.completion,
+				Models:          tt.models,
 			}
 			err := cfg.Validate()
 
@@ -149,6 +149,38 @@ func TestConfigManagerLoad_XDGConfigHome(t *testing.T) {
 	}
 }
 
+func TestConfigManagerLoad_Models(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configDir := filepath.Join(tmpDir, ".config", "germinator")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+
+	configContent := `
+[models.smart]
+claude-code = "opus"
+opencode = "anthropic/claude-opus-4-1"
+`
+	configPath := filepath.Join(configDir, "config.toml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", tmpDir)
+
+	mgr := NewConfigManager()
+	if err := mgr.Load(); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	got := mgr.GetConfig().Models["smart"]
+	if got[core.PlatformClaudeCode] != "opus" || got[core.PlatformOpenCode] != "anthropic/claude-opus-4-1" {
+		t.Errorf("Models[smart] = %v, want claude-code=opus, opencode=anthropic/claude-opus-4-1", got)
+	}
+}
+
 func TestConfigManagerLoad_InvalidPlatform(t *testing.T) {
 	tmpDir := t.TempDir()
 
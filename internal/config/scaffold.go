@@ -31,6 +31,13 @@ const DefaultTOML = `# Germinator configuration
 # Default: "" (none)
 # platform = ""
 
+# Template override directory, laid out like the bundled templates
+# (<platform>/<type>.tmpl, e.g. claude-code/agent.tmpl). Templates found here
+# replace the bundled ones; run "germinator templates export" to start from
+# the defaults. Supports ~ expansion for home directory.
+# Default: "" (bundled templates only)
+# templates = "~/.config/germinator/templates"
+
 # Shell completion configuration
 [completion]
 
@@ -43,6 +50,13 @@ const DefaultTOML = `# Germinator configuration
 # Higher values = faster completions but may show stale results.
 # Default: "5s"
 # cache_ttl = "5s"
+
+# Model aliases: logical model names documents use (model: smart), mapped to
+# the model id each platform expects. sonnet, opus, and haiku are built in;
+# entries here add to or override them.
+# [models.smart]
+# claude-code = "opus"
+# opencode = "anthropic/claude-opus-4-1"
 `
 
 // WriteDefault scaffolds a default germinator config file at path,
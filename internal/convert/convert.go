@@ -0,0 +1,373 @@
+// Package convert provides direct platform-to-platform conversion as an
+// I/O shell-package service. A platform document is parsed into its
+// canonical model (parser.ParsePlatformDocument) and rendered for the
+// target platform (renderer.RenderDocument) in memory, so migrating
+// between tools needs no intermediate canonical file.
+//
+// The Service interface, Request type, and NewService constructor are
+// the canonical contract. cmd/convert.go declares a local Converter
+// interface that is structurally identical to convert.Service.
+//
+// Conversion is lossy wherever the target platform has no equivalent
+// for a canonical field; every such field that was set on the source
+// document is reported in core.ConvertedDocument.Dropped
+// (core.DetectLosses over the target adapter's UnsupportedFields).
+package convert
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/install"
+	"gitlab.com/amoconst/germinator/internal/library"
+	"gitlab.com/amoconst/germinator/internal/parser"
+	"gitlab.com/amoconst/germinator/internal/platforms"
+	"gitlab.com/amoconst/germinator/internal/renderer"
+)
+
+// Request carries the inputs for a conversion. When InputPath is a
+// directory, it is treated as a project root holding From's install
+// layout and every document found there is converted into To's layout
+// under OutputPath. DocType is optional: when empty it is inferred from
+// the source layout, which fails for platforms that share one layout
+// across document types (Cursor rules).
+type Request struct {
+	InputPath  string
+	OutputPath string
+	From       string
+	To         string
+	DocType    string
+	// Strict fails a document, without writing it, when the target
+	// platform drops any of its fields.
+	Strict bool
+	// Name selects one MCP server when the input file defines several
+	// (.mcp.json, opencode.json). Directory mode converts every server.
+	Name string
+	// Models are the model aliases that carry a model across: From's
+	// model id is read back as its alias, which renders as To's id.
+	Models core.ModelAliases
+	// Vars are values for the variables the documents declare, shared
+	// by every document of a directory.
+	Vars map[string]string
+}
+
+// Service is the per-call contract for platform-to-platform conversion.
+// In file mode a failure is returned directly as a typed core error; in
+// directory mode per-document failures are recorded on the result and
+// the caller aggregates them.
+type Service interface {
+	Convert(ctx context.Context, req *Request) (*core.ConvertResult, error)
+}
+
+// convertService is the production implementation. Zero-size because
+// parsing and rendering are delegated to the parser and renderer
+// packages.
+type convertService struct{}
+
+// Compile-time confirmation that *convertService satisfies the Service
+// interface declared in this package.
+var _ Service = (*convertService)(nil)
+
+// NewService returns the production wiring for conversion.
+func NewService() Service {
+	return &convertService{}
+}
+
+// Convert implements Service. Platforms are assumed pre-validated by
+// the caller (cmd/convert.go validates --from and --to).
+func (convertService) Convert(ctx context.Context, req *Request) (*core.ConvertResult, error) {
+	if req == nil {
+		return nil, core.NewValidationError("convert", "request", "", "convert request must not be nil")
+	}
+
+	info, err := os.Stat(req.InputPath)
+	if err != nil {
+		return nil, core.NewFileError(req.InputPath, "read", "failed to read input", err)
+	}
+	if info.IsDir() {
+		return convertDirectory(ctx, req)
+	}
+
+	docType := req.DocType
+	if docType == "" {
+		docType, err = inferDocType(req.InputPath, req.From)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	doc := core.ConvertedDocument{DocType: docType, InputPath: req.InputPath, OutputPath: req.OutputPath}
+	_, rendered, dropped, err := convertDocument(ctx, req, req.InputPath, docType, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	doc.Dropped = dropped
+
+	if err := os.WriteFile(req.OutputPath, []byte(rendered), 0o644); err != nil { //nolint:gosec // G306: user-owned output path; 0644 is standard readable permission
+		return nil, core.NewFileError(req.OutputPath, "write", "failed to write output file", err)
+	}
+	return &core.ConvertResult{Documents: []core.ConvertedDocument{doc}}, nil
+}
+
+// convertDocument parses one source file and renders it for the target
+// platform, returning the parsed document, the rendered text, and the
+// fields the target dropped. server picks the MCP server to read from an
+// mcp config file. Under req.Strict any dropped field is an error.
+func convertDocument(ctx context.Context, req *Request, inputPath, docType, server string) (interface{}, string, []core.FieldLoss, error) {
+	var doc interface{}
+	var err error
+	if docType == "mcp" {
+		doc, err = parser.ParsePlatformMCPServer(ctx, inputPath, req.From, server)
+	} else {
+		doc, err = parser.ParsePlatformDocumentWith(ctx, inputPath, req.From, docType, parser.Options{Models: req.Models, Vars: req.Vars})
+	}
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("parsing %s document: %w", req.From, err)
+	}
+
+	rendered, err := renderer.RenderDocumentWith(ctx, doc, req.To, renderer.TemplateOptions{Models: req.Models})
+	if err != nil {
+		return nil, "", nil, core.NewTransformError("render", req.To, "failed to render document", err)
+	}
+
+	dropped, err := renderer.DroppedFields(doc, req.To)
+	if err == nil && req.Strict {
+		err = core.CheckLosses(req.To, dropped)
+	}
+	if err != nil {
+		return nil, "", nil, err //nolint:wrapcheck // typed *core.TransformError for ExitCodeFor dispatch
+	}
+	return doc, rendered, dropped, nil
+}
+
+// sourceFile is a document discovered in a source platform layout.
+// server names the MCP server it stands for when the file is a shared
+// mcp config.
+type sourceFile struct {
+	path    string
+	docType string
+	name    string
+	server  string
+}
+
+// convertDirectory converts every document found in req.From's install
+// layout under req.InputPath into req.To's layout under req.OutputPath.
+func convertDirectory(ctx context.Context, req *Request) (*core.ConvertResult, error) {
+	files, err := discover(req.InputPath, req.From, req.DocType)
+	if err != nil {
+		return nil, err
+	}
+	files = expandMCPServers(ctx, files, req.From)
+	if len(files) == 0 {
+		return nil, core.NewValidationError("convert", "input", req.InputPath,
+			fmt.Sprintf("no %s documents found", req.From)).
+			WithSuggestions([]string{"point the input at the project root that holds the " + req.From + " files"})
+	}
+
+	result := &core.ConvertResult{}
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("convert: cancelled: %w", err)
+		}
+		result.Documents = append(result.Documents, convertFile(ctx, req, f))
+	}
+	return result, nil
+}
+
+// convertFile converts one discovered document into the target layout.
+// Errors are recorded on the returned document rather than aborting the
+// directory run.
+func convertFile(ctx context.Context, req *Request, f sourceFile) core.ConvertedDocument {
+	doc := core.ConvertedDocument{DocType: f.docType, InputPath: f.path}
+	if f.docType == "" {
+		doc.Error = core.NewValidationError("convert", "type", f.path,
+			fmt.Sprintf("%s uses the same layout for several document types", req.From)).
+			WithSuggestions([]string{"pass --type to choose one"})
+		return doc
+	}
+
+	parsed, rendered, dropped, err := convertDocument(ctx, req, f.path, f.docType, f.server)
+	if err != nil {
+		doc.Error = err
+		return doc
+	}
+	doc.Dropped = dropped
+
+	outputPath, err := targetPath(req, f, parsed)
+	if err != nil {
+		doc.Error = err
+		return doc
+	}
+	doc.OutputPath = outputPath
+
+	layout, merge := library.MergedOutputLayout(f.docType, req.To)
+	if m, ok := parsed.(*parser.CanonicalMemory); ok {
+		layout, merge = library.MergedMemoryLayout(req.To, m.Paths)
+	}
+	if merge {
+		rendered, err = install.MergeIntoExisting(outputPath, f.docType+"/"+f.name, rendered, layout.MergeJSON, false)
+		if err != nil {
+			doc.Error = err
+			return doc
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil { //nolint:gosec // G301: user-owned output directory; 0755 is standard permission
+		doc.Error = core.NewFileError(outputPath, "mkdir", "failed to create output directory", err)
+		return doc
+	}
+	if err := os.WriteFile(outputPath, []byte(rendered), 0o644); err != nil { //nolint:gosec // G306: user-owned output path; 0644 is standard readable permission
+		doc.Error = core.NewFileError(outputPath, "write", "failed to write output file", err)
+	}
+	return doc
+}
+
+// targetPath resolves where a converted document lands in the target
+// layout. Memory is placed by its path scope, as init does.
+func targetPath(req *Request, f sourceFile, doc interface{}) (string, error) {
+	if m, ok := doc.(*parser.CanonicalMemory); ok {
+		return library.GetMemoryOutputPath(f.name, req.To, req.OutputPath, m.Paths)
+	}
+	return library.GetOutputPath(f.docType, f.name, req.To, req.OutputPath)
+}
+
+// layoutPattern is a source layout expressed as a slash-separated glob
+// relative to the project root, with the document type it holds.
+type layoutPattern struct {
+	docType string
+	layout  core.OutputPathConfig
+	glob    string
+}
+
+// sourcePatterns lists the globs of every layout the platform installs
+// documents into, restricted to docType when it is non-empty.
+func sourcePatterns(platform, docType string) []layoutPattern {
+	target, ok := platforms.Lookup(platform)
+	if !ok {
+		return nil
+	}
+	var patterns []layoutPattern
+	for _, typ := range core.ResourceTypes() {
+		if (docType != "" && typ != docType) || typ == "hook" || typ == "settings" {
+			// Hooks and settings are write-only: settings.json,
+			// opencode.json, and plugin files do not record which
+			// resource a hook or setting came from.
+			continue
+		}
+		for _, lookup := range []func(string) (core.OutputPathConfig, bool){target.OutputPath, target.UnscopedOutputPath} {
+			if layout, ok := lookup(typ); ok {
+				patterns = append(patterns, layoutPattern{docType: typ, layout: layout, glob: core.ResolveOutputPath(layout, "*")})
+			}
+		}
+	}
+	return patterns
+}
+
+// discover finds the documents of platform's layouts under root. A file
+// matched by layouts of several document types gets an empty docType so
+// the caller can report the ambiguity for that file.
+func discover(root, platform, docType string) ([]sourceFile, error) {
+	byPath := make(map[string]*sourceFile)
+	for _, p := range sourcePatterns(platform, docType) {
+		matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(p.glob)))
+		if err != nil {
+			return nil, core.NewFileError(root, "read", "failed to scan input directory", err)
+		}
+		for _, m := range matches {
+			if info, err := os.Stat(m); err != nil || info.IsDir() {
+				continue
+			}
+			if f, seen := byPath[m]; seen {
+				if f.docType != p.docType {
+					f.docType = ""
+				}
+				continue
+			}
+			rel, _ := filepath.Rel(root, m)
+			byPath[m] = &sourceFile{path: m, docType: p.docType, name: layoutName(filepath.ToSlash(rel), p.layout)}
+		}
+	}
+
+	files := make([]sourceFile, 0, len(byPath))
+	for _, f := range byPath {
+		files = append(files, *f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+	return files, nil
+}
+
+// expandMCPServers replaces each mcp config file with one entry per
+// server it defines. A file that cannot be read is kept as-is so
+// convertFile reports the error for it.
+func expandMCPServers(ctx context.Context, files []sourceFile, platform string) []sourceFile {
+	out := make([]sourceFile, 0, len(files))
+	for _, f := range files {
+		if f.docType != "mcp" {
+			out = append(out, f)
+			continue
+		}
+		servers, err := parser.ParsePlatformMCPServers(ctx, f.path, platform)
+		if err != nil {
+			out = append(out, f)
+			continue
+		}
+		for _, s := range servers {
+			out = append(out, sourceFile{path: f.path, docType: f.docType, name: s.Name, server: s.Name})
+		}
+	}
+	return out
+}
+
+// layoutName recovers the resource name from a path matched by layout:
+// the part the layout's "*" stood for, or the lowercased file stem for
+// fixed-file layouts such as GEMINI.md.
+func layoutName(rel string, layout core.OutputPathConfig) string {
+	if layout.File != "" {
+		return strings.ToLower(strings.TrimSuffix(layout.File, path.Ext(layout.File)))
+	}
+	if layout.UseSubdirectory {
+		return path.Base(path.Dir(rel))
+	}
+	return strings.TrimSuffix(path.Base(rel), layout.FileSuffix)
+}
+
+// inferDocType picks the document type whose source layout matches the
+// trailing segments of inputPath.
+func inferDocType(inputPath, platform string) (string, error) {
+	slashed := filepath.ToSlash(inputPath)
+	var types []string
+	for _, p := range sourcePatterns(platform, "") {
+		if matchesTail(slashed, p.glob) && !slices.Contains(types, p.docType) {
+			types = append(types, p.docType)
+		}
+	}
+	if len(types) == 1 {
+		return types[0], nil
+	}
+
+	msg := fmt.Sprintf("cannot infer the document type from the %s layout", platform)
+	if len(types) > 1 {
+		msg = fmt.Sprintf("%s uses this layout for %s documents", platform, strings.Join(types, ", "))
+	}
+	return "", core.NewValidationError("convert", "type", inputPath, msg).
+		WithSuggestions([]string{"pass --type (" + strings.Join(core.ResourceTypes(), ", ") + ")"})
+}
+
+// matchesTail reports whether the last segments of p match glob, which
+// has no leading directory wildcards.
+func matchesTail(p, glob string) bool {
+	segments := strings.Split(p, "/")
+	n := strings.Count(glob, "/") + 1
+	if len(segments) < n {
+		return false
+	}
+	ok, err := path.Match(glob, strings.Join(segments[len(segments)-n:], "/"))
+	return err == nil && ok
+}
@@ -0,0 +1,356 @@
+package convert
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+const claudeCommand = `---
+name: deploy
+description: Deploys the service
+tools:
+  - Bash
+execution:
+  context: fork
+  agent: code-reviewer
+arguments:
+  hint: "environment"
+model: claude-sonnet-4-5-20250929
+---
+Deploy to $ARGUMENTS.
+`
+
+const claudeAgent = `---
+name: reviewer
+description: Reviews code
+tools:
+  - Read
+  - Grep
+---
+Review the diff.
+`
+
+func writeFile(t *testing.T, path, content string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func fields(losses []core.FieldLoss) []string {
+	out := make([]string, 0, len(losses))
+	for _, l := range losses {
+		out = append(out, l.Field)
+	}
+	return out
+}
+
+func TestService_Convert_File(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	input := writeFile(t, filepath.Join(dir, ".claude", "commands", "deploy.md"), claudeCommand)
+	output := filepath.Join(dir, "deploy.toml")
+
+	result, err := NewService().Convert(context.Background(), &Request{
+		InputPath:  input,
+		OutputPath: output,
+		From:       core.PlatformClaudeCode,
+		To:         core.PlatformGemini,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Documents, 1)
+
+	doc := result.Documents[0]
+	assert.Equal(t, "command", doc.DocType, "type is inferred from the .claude/commands layout")
+	assert.Equal(t, output, doc.OutputPath)
+	assert.Equal(t, []string{"tools", "execution.context", "execution.agent", "arguments.hint", "model"}, fields(doc.Dropped))
+	assert.Equal(t, "bash", doc.Dropped[0].Value)
+
+	written, err := os.ReadFile(output) //nolint:gosec // G304: test temp file
+	require.NoError(t, err)
+	assert.Contains(t, string(written), `description = "Deploys the service"`)
+	assert.Contains(t, string(written), "Deploy to {{args}}.")
+}
+
+func TestService_Convert_FileNothingDropped(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	input := writeFile(t, filepath.Join(dir, "reviewer.md"), claudeAgent)
+
+	result, err := NewService().Convert(context.Background(), &Request{
+		InputPath:  input,
+		OutputPath: filepath.Join(dir, "reviewer.opencode.md"),
+		From:       core.PlatformClaudeCode,
+		To:         core.PlatformOpenCode,
+		DocType:    "agent",
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Documents, 1)
+	assert.Empty(t, result.Documents[0].Dropped)
+}
+
+func TestService_Convert_FileModelAlias(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	input := writeFile(t, filepath.Join(dir, ".claude", "agents", "reviewer.md"), "---\nname: reviewer\ndescription: Reviews code\nmodel: opus\n---\nReview the diff.\n")
+	output := filepath.Join(dir, "reviewer.opencode.md")
+
+	_, err := NewService().Convert(context.Background(), &Request{
+		InputPath:  input,
+		OutputPath: output,
+		From:       core.PlatformClaudeCode,
+		To:         core.PlatformOpenCode,
+		Models:     core.DefaultModelAliases(),
+	})
+	require.NoError(t, err)
+
+	written, err := os.ReadFile(output) //nolint:gosec // G304: test temp file
+	require.NoError(t, err)
+	assert.Contains(t, string(written), "model: anthropic/claude-opus-4-1\n")
+}
+
+func TestService_Convert_FileVars(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	input := writeFile(t, filepath.Join(dir, ".claude", "commands", "test.md"),
+		"---\ndescription: Test {{ .Vars.project }}\nvars:\n  project:\n---\nRun the tests.\n")
+	output := filepath.Join(dir, "test.md")
+
+	_, err := NewService().Convert(context.Background(), &Request{
+		InputPath:  input,
+		OutputPath: output,
+		From:       core.PlatformClaudeCode,
+		To:         core.PlatformOpenCode,
+		Vars:       map[string]string{"project": "germinator"},
+	})
+	require.NoError(t, err)
+
+	written, err := os.ReadFile(output) //nolint:gosec // G304: test temp file
+	require.NoError(t, err)
+	assert.Contains(t, string(written), "description: Test germinator")
+	assert.NotContains(t, string(written), "vars:", "the vars block is not platform configuration")
+}
+
+func TestService_Convert_FileTypeNotInferred(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		path  string
+		from  string
+		error string
+	}{
+		{"outside any layout", "notes.md", core.PlatformClaudeCode, "cannot infer the document type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			input := writeFile(t, filepath.Join(dir, filepath.FromSlash(tt.path)), "---\ndescription: x\n---\nbody\n")
+
+			_, err := NewService().Convert(context.Background(), &Request{
+				InputPath:  input,
+				OutputPath: filepath.Join(dir, "out.md"),
+				From:       tt.from,
+				To:         core.PlatformClaudeCode,
+			})
+			var valErr *core.ValidationError
+			require.True(t, errors.As(err, &valErr), "got %v", err)
+			assert.Contains(t, valErr.Error(), tt.error)
+		})
+	}
+}
+
+func TestService_Convert_Directory(t *testing.T) {
+	t.Parallel()
+
+	in := t.TempDir()
+	writeFile(t, filepath.Join(in, ".claude", "agents", "reviewer.md"), claudeAgent)
+	writeFile(t, filepath.Join(in, ".claude", "commands", "deploy.md"), claudeCommand)
+	writeFile(t, filepath.Join(in, ".claude", "memory", "style.md"), "Use gofmt.\n")
+	writeFile(t, filepath.Join(in, "README.md"), "not a claude document\n")
+	out := t.TempDir()
+
+	result, err := NewService().Convert(context.Background(), &Request{
+		InputPath:  in,
+		OutputPath: out,
+		From:       core.PlatformClaudeCode,
+		To:         core.PlatformOpenCode,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Documents, 3)
+
+	var types []string
+	for _, doc := range result.Documents {
+		require.NoError(t, doc.Error, doc.InputPath)
+		assert.FileExists(t, doc.OutputPath)
+		types = append(types, doc.DocType)
+	}
+	assert.Equal(t, []string{"agent", "command", "memory"}, types)
+	assert.Equal(t, filepath.Join(out, ".opencode", "agents", "reviewer.md"), result.Documents[0].OutputPath)
+	assert.Equal(t, filepath.Join(out, ".opencode", "commands", "deploy.md"), result.Documents[1].OutputPath)
+	assert.Equal(t, []string{"tools", "arguments.hint"}, fields(result.Documents[1].Dropped))
+}
+
+func TestService_Convert_DirectoryCursorRules(t *testing.T) {
+	t.Parallel()
+
+	in := t.TempDir()
+	writeFile(t, filepath.Join(in, ".cursor", "rules", "style.mdc"), "---\nglobs: \"*.go\"\n---\nUse gofmt.\n")
+	writeFile(t, filepath.Join(in, ".cursor", "rules", "skills", "style.mdc"), "---\ndescription: Style skill\n---\nApply the style.\n")
+	writeFile(t, filepath.Join(in, ".cursor", "rules", "commands", "style.mdc"), "---\ndescription: Style command\n---\nRestyle.\n")
+	out := t.TempDir()
+
+	result, err := NewService().Convert(context.Background(), &Request{
+		InputPath:  in,
+		OutputPath: out,
+		From:       core.PlatformCursor,
+		To:         core.PlatformClaudeCode,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Documents, 3, "a skill, command, and memory of the same name are distinct rules")
+
+	types := map[string]string{}
+	for _, doc := range result.Documents {
+		require.NoError(t, doc.Error, doc.InputPath)
+		types[doc.DocType] = doc.OutputPath
+	}
+	assert.Equal(t, map[string]string{
+		"command": filepath.Join(out, ".claude", "commands", "style.md"),
+		"memory":  filepath.Join(out, ".claude", "memory", "style.md"),
+		"skill":   filepath.Join(out, ".claude", "skills", "style", "SKILL.md"),
+	}, types)
+}
+
+func TestService_Convert_DirectoryPartialFailure(t *testing.T) {
+	t.Parallel()
+
+	in := t.TempDir()
+	writeFile(t, filepath.Join(in, ".claude", "agents", "reviewer.md"), claudeAgent)
+	writeFile(t, filepath.Join(in, ".claude", "memory", "style.md"), "Use gofmt.\n")
+	out := t.TempDir()
+	agentsMD := writeFile(t, filepath.Join(out, "AGENTS.md"), "# Team notes\n")
+
+	result, err := NewService().Convert(context.Background(), &Request{
+		InputPath:  in,
+		OutputPath: out,
+		From:       core.PlatformClaudeCode,
+		To:         core.PlatformCodex,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Documents, 2)
+
+	var transformErr *core.TransformError
+	assert.True(t, errors.As(result.Documents[0].Error, &transformErr), "codex has no agents")
+
+	require.NoError(t, result.Documents[1].Error)
+	assert.Equal(t, agentsMD, result.Documents[1].OutputPath)
+	written, err := os.ReadFile(agentsMD) //nolint:gosec // G304: test temp file
+	require.NoError(t, err)
+	begin, _ := core.SectionMarkers("memory/style")
+	assert.Contains(t, string(written), "# Team notes")
+	assert.Contains(t, string(written), begin)
+	assert.Contains(t, string(written), "Use gofmt.")
+}
+
+func TestService_Convert_DirectoryEmpty(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewService().Convert(context.Background(), &Request{
+		InputPath:  t.TempDir(),
+		OutputPath: t.TempDir(),
+		From:       core.PlatformClaudeCode,
+		To:         core.PlatformOpenCode,
+	})
+	var valErr *core.ValidationError
+	require.True(t, errors.As(err, &valErr))
+	assert.Contains(t, valErr.Error(), "no claude-code documents found")
+}
+
+func TestService_Convert_StrictRefusesLoss(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	input := writeFile(t, filepath.Join(dir, ".claude", "commands", "deploy.md"), claudeCommand)
+	output := filepath.Join(dir, "deploy.toml")
+
+	_, err := NewService().Convert(context.Background(), &Request{
+		InputPath:  input,
+		OutputPath: output,
+		From:       core.PlatformClaudeCode,
+		To:         core.PlatformGemini,
+		Strict:     true,
+	})
+	var transformErr *core.TransformError
+	require.ErrorAs(t, err, &transformErr)
+	assert.Contains(t, err.Error(), "5 field(s) would be dropped")
+	assert.NoFileExists(t, output)
+}
+
+func TestService_Convert_DirectoryMCPServers(t *testing.T) {
+	t.Parallel()
+
+	in := t.TempDir()
+	writeFile(t, filepath.Join(in, ".mcp.json"), `{"mcpServers": {
+  "github": {"command": "npx", "args": ["-y", "server-github"]},
+  "docs": {"type": "http", "url": "https://example.com/mcp"}
+}}`)
+	out := t.TempDir()
+
+	result, err := NewService().Convert(context.Background(), &Request{
+		InputPath:  in,
+		OutputPath: out,
+		From:       core.PlatformClaudeCode,
+		To:         core.PlatformOpenCode,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Documents, 2, "one document per server")
+	for _, doc := range result.Documents {
+		require.NoError(t, doc.Error)
+		assert.Equal(t, filepath.Join(out, "opencode.json"), doc.OutputPath)
+	}
+
+	got, err := os.ReadFile(filepath.Join(out, "opencode.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(got), `"docs": {`)
+	assert.Contains(t, string(got), `"github": {`)
+}
+
+func TestService_Convert_FileMCPServerByName(t *testing.T) {
+	t.Parallel()
+
+	in := writeFile(t, filepath.Join(t.TempDir(), "opencode.json"), `{"mcp": {
+  "github": {"type": "local", "command": ["npx", "server-github"]},
+  "docs": {"type": "remote", "url": "https://example.com/mcp", "enabled": false}
+}}`)
+	req := &Request{
+		InputPath:  in,
+		OutputPath: filepath.Join(t.TempDir(), ".mcp.json"),
+		From:       core.PlatformOpenCode,
+		To:         core.PlatformClaudeCode,
+		DocType:    "mcp",
+	}
+
+	_, err := NewService().Convert(context.Background(), req)
+	var parseErr *core.ParseError
+	require.ErrorAs(t, err, &parseErr, "several servers need --name")
+
+	req.Name = "docs"
+	result, err := NewService().Convert(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"enabled"}, fields(result.Documents[0].Dropped))
+}
@@ -0,0 +1,266 @@
+package copilot
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/permission"
+)
+
+// Adapter implements the Adapter interface for the GitHub Copilot platform.
+type Adapter struct{}
+
+// Copilot is the package-level singleton for the Copilot Adapter.
+// The adapter is stateless; a single shared instance is safe to use across goroutines.
+var Copilot = &Adapter{}
+
+// unsupportedFields lists, per document type, the canonical fields the
+// Copilot templates do not render.
+var unsupportedFields = map[string][]core.UnsupportedField{
+	"agent": {
+		{Field: "disallowedTools", Reason: "Copilot chat modes only list allowed tools"},
+		{Field: "permissionPolicy", Reason: "Copilot chat modes have no permission policy"},
+		{Field: "permissions", Reason: "Copilot chat modes have no permission rules"},
+		{Field: "behavior", Reason: "Copilot chat modes have no behavior settings"},
+		{Field: "extensions.hooks", Reason: "Copilot chat modes have no hooks"},
+	},
+	"command": {
+		{Field: "execution.context", Reason: "Copilot prompts always run in the chat session"},
+		{Field: "execution.subtask", Reason: "Copilot prompts always run in the chat session"},
+		{Field: "arguments.hint", Reason: "Copilot prompts have no argument hint"},
+	},
+}
+
+// toolNames maps canonical tool names to Copilot tool names.
+var toolNames = map[string]string{
+	"bash":     "runCommands",
+	"edit":     "editFiles",
+	"write":    "editFiles",
+	"read":     "codebase",
+	"list":     "codebase",
+	"grep":     "search",
+	"glob":     "search",
+	"webfetch": "fetch",
+}
+
+// canonicalToolNames is the reverse of toolNames. Where several canonical
+// tools share a Copilot tool, the primary canonical name wins.
+var canonicalToolNames = map[string]string{
+	"runCommands": "bash",
+	"editFiles":   "edit",
+	"codebase":    "read",
+	"search":      "grep",
+	"fetch":       "webfetch",
+}
+
+// applyToAll is the applyTo glob Copilot uses for repository-wide
+// instructions; it is treated as "no path scope".
+const applyToAll = "**"
+
+// ToCanonical parses Copilot frontmatter into canonical domain models.
+// Skills are rejected because Copilot has no skill equivalent.
+func (a *Adapter) ToCanonical(input map[string]interface{}) (*core.Agent, *core.Command, *core.Skill, *core.Memory, error) {
+	docType, ok := input["__type"].(string)
+	if !ok {
+		return nil, nil, nil, nil, core.NewParseError("", "missing __type field", nil)
+	}
+
+	switch docType {
+	case "agent":
+		return a.parseAgent(input), nil, nil, nil, nil
+	case "command":
+		return nil, a.parseCommand(input), nil, nil, nil
+	case "memory":
+		return nil, nil, nil, a.parseMemory(input), nil
+	case "skill":
+		return nil, nil, nil, nil, core.NewParseError("", "copilot files cannot be read as skill documents", nil).
+			WithSuggestions([]string{"use --type agent, command, or memory"})
+	default:
+		return nil, nil, nil, nil, core.NewParseError("", "unknown document type: "+docType, nil)
+	}
+}
+
+// FromCanonical converts canonical domain models to Copilot frontmatter maps.
+func (a *Adapter) FromCanonical(docType string, doc interface{}) (map[string]interface{}, error) {
+	switch docType {
+	case "agent":
+		agent, ok := doc.(*core.Agent)
+		if !ok {
+			return nil, core.NewTransformError("from-canonical", core.PlatformCopilot, fmt.Sprintf("expected *core.Agent, got %T", doc), nil)
+		}
+		return a.renderAgent(agent), nil
+	case "command":
+		cmd, ok := doc.(*core.Command)
+		if !ok {
+			return nil, core.NewTransformError("from-canonical", core.PlatformCopilot, fmt.Sprintf("expected *core.Command, got %T", doc), nil)
+		}
+		return a.renderCommand(cmd), nil
+	case "memory":
+		mem, ok := doc.(*core.Memory)
+		if !ok {
+			return nil, core.NewTransformError("from-canonical", core.PlatformCopilot, fmt.Sprintf("expected *core.Memory, got %T", doc), nil)
+		}
+		return a.renderMemory(mem), nil
+	case "skill":
+		return nil, core.NewTransformError("from-canonical", core.PlatformCopilot, "copilot does not support skill documents", nil)
+	default:
+		return nil, core.NewTransformError("from-canonical", core.PlatformCopilot, "unknown document type: "+docType, nil)
+	}
+}
+
+// PermissionPolicyToPlatform validates the policy and returns nil: Copilot
+// files carry no permission settings (tool access is the tools list).
+func (a *Adapter) PermissionPolicyToPlatform(policy core.PermissionPolicy) (interface{}, error) {
+	if _, ok := permission.PermissionPolicyMappings[string(policy)]; !ok {
+		return nil, core.NewConfigError("permission-policy", string(policy), "unknown permission policy")
+	}
+	return nil, nil //nolint:nilnil // no permission representation exists; nil is the platform value
+}
+
+// NamesFromFile reports that every document is named by its file:
+// Copilot files have no name field.
+func (a *Adapter) NamesFromFile(_ string) bool {
+	return true
+}
+
+// UnsupportedFields returns the canonical fields Copilot cannot represent
+// for docType; convert reports them as dropped when they are set.
+func (a *Adapter) UnsupportedFields(docType string) []core.UnsupportedField {
+	return unsupportedFields[docType]
+}
+
+// ConvertToolNameCase converts a canonical tool name to its Copilot name.
+// Names without a Copilot counterpart are returned unchanged.
+func (a *Adapter) ConvertToolNameCase(name string) string {
+	if mapped, ok := toolNames[permission.ToLowerCase(name)]; ok {
+		return mapped
+	}
+	return name
+}
+
+// toCanonicalToolName converts a Copilot tool name back to its canonical
+// name. Copilot-only tools are returned unchanged.
+func toCanonicalToolName(name string) string {
+	if mapped, ok := canonicalToolNames[name]; ok {
+		return mapped
+	}
+	return name
+}
+
+func (a *Adapter) parseAgent(input map[string]interface{}) *core.Agent {
+	agent := &core.Agent{}
+	if name, ok := input["name"].(string); ok {
+		agent.Name = name
+	}
+	if description, ok := input["description"].(string); ok {
+		agent.Description = description
+	}
+	agent.Tools = parseTools(input["tools"])
+	if model, ok := input["model"].(string); ok {
+		agent.Model = model
+	}
+	agent.Targets = make(core.PlatformConfig)
+	return agent
+}
+
+func (a *Adapter) renderAgent(agent *core.Agent) map[string]interface{} {
+	output := map[string]interface{}{"__type": "agent"}
+	if agent.Description != "" {
+		output["description"] = agent.Description
+	}
+	if tools := a.renderTools(agent.Tools); len(tools) > 0 {
+		output["tools"] = tools
+	}
+	if agent.Model != "" {
+		output["model"] = agent.Model
+	}
+	return output
+}
+
+func (a *Adapter) parseCommand(input map[string]interface{}) *core.Command {
+	cmd := &core.Command{}
+	if name, ok := input["name"].(string); ok {
+		cmd.Name = name
+	}
+	if description, ok := input["description"].(string); ok {
+		cmd.Description = description
+	}
+	cmd.Tools = parseTools(input["tools"])
+	if mode, ok := input["mode"].(string); ok {
+		cmd.Execution.Agent = mode
+	}
+	if model, ok := input["model"].(string); ok {
+		cmd.Model = model
+	}
+	cmd.Targets = make(core.PlatformConfig)
+	return cmd
+}
+
+func (a *Adapter) renderCommand(cmd *core.Command) map[string]interface{} {
+	output := map[string]interface{}{"__type": "command"}
+	if cmd.Description != "" {
+		output["description"] = cmd.Description
+	}
+	if cmd.Execution.Agent != "" {
+		output["mode"] = cmd.Execution.Agent
+	}
+	if tools := a.renderTools(cmd.Tools); len(tools) > 0 {
+		output["tools"] = tools
+	}
+	if cmd.Model != "" {
+		output["model"] = cmd.Model
+	}
+	return output
+}
+
+func (a *Adapter) parseMemory(input map[string]interface{}) *core.Memory {
+	mem := &core.Memory{}
+	applyTo, _ := input["applyTo"].(string)
+	for _, glob := range strings.Split(applyTo, ",") {
+		if glob = strings.TrimSpace(glob); glob != "" && glob != applyToAll {
+			mem.Paths = append(mem.Paths, glob)
+		}
+	}
+	return mem
+}
+
+func (a *Adapter) renderMemory(mem *core.Memory) map[string]interface{} {
+	output := map[string]interface{}{"__type": "memory"}
+	if len(mem.Paths) > 0 {
+		output["applyTo"] = strings.Join(mem.Paths, ",")
+	}
+	if mem.Content != "" {
+		output["content"] = mem.Content
+	}
+	return output
+}
+
+// renderTools converts canonical tools to Copilot names, dropping
+// duplicates produced by many-to-one mappings (edit, write → editFiles).
+func (a *Adapter) renderTools(tools []string) []string {
+	var out []string
+	seen := make(map[string]bool, len(tools))
+	for _, t := range tools {
+		name := a.ConvertToolNameCase(t)
+		if !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func parseTools(value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	var tools []string
+	for _, item := range items {
+		if name, ok := item.(string); ok {
+			tools = append(tools, toCanonicalToolName(name))
+		}
+	}
+	return tools
+}
@@ -0,0 +1,104 @@
+package copilot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	canonical "gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestConvertToolNameCase(t *testing.T) {
+	tests := []struct {
+		name string
+		tool string
+		want string
+	}{
+		{"bash", "bash", "runCommands"},
+		{"mixed case", "Edit", "editFiles"},
+		{"write shares editFiles", "write", "editFiles"},
+		{"grep", "grep", "search"},
+		{"webfetch", "webfetch", "fetch"},
+		{"copilot-only passes through", "githubRepo", "githubRepo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Copilot.ConvertToolNameCase(tt.tool))
+		})
+	}
+}
+
+func TestToCanonicalMemory(t *testing.T) {
+	tests := []struct {
+		name    string
+		applyTo interface{}
+		want    []string
+	}{
+		{"comma separated", "**/*.go, go.mod", []string{"**/*.go", "go.mod"}},
+		{"repository wide", "**", nil},
+		{"absent", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := map[string]interface{}{"__type": "memory"}
+			if tt.applyTo != nil {
+				input["applyTo"] = tt.applyTo
+			}
+			_, _, _, mem, err := Copilot.ToCanonical(input)
+			require.NoError(t, err)
+			require.NotNil(t, mem)
+			assert.Equal(t, tt.want, mem.Paths)
+		})
+	}
+}
+
+func TestToCanonicalCommand(t *testing.T) {
+	input := map[string]interface{}{
+		"__type":      "command",
+		"description": "Review the diff",
+		"mode":        "agent",
+		"tools":       []interface{}{"runCommands", "codebase", "githubRepo"},
+		"model":       "GPT-4o",
+	}
+	_, cmd, _, _, err := Copilot.ToCanonical(input)
+	require.NoError(t, err)
+	assert.Equal(t, "Review the diff", cmd.Description)
+	assert.Equal(t, "agent", cmd.Execution.Agent)
+	assert.Equal(t, []string{"bash", "read", "githubRepo"}, cmd.Tools)
+	assert.Equal(t, "GPT-4o", cmd.Model)
+}
+
+func TestToCanonicalRejectsSkill(t *testing.T) {
+	_, _, _, _, err := Copilot.ToCanonical(map[string]interface{}{"__type": "skill"})
+	var parseErr *canonical.ParseError
+	require.True(t, errors.As(err, &parseErr))
+}
+
+func TestFromCanonical(t *testing.T) {
+	out, err := Copilot.FromCanonical("agent", &canonical.Agent{
+		Name:        "planner",
+		Description: "Plans work",
+		Tools:       []string{"edit", "write", "read"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"editFiles", "codebase"}, out["tools"])
+	assert.NotContains(t, out, "name")
+
+	out, err = Copilot.FromCanonical("memory", &canonical.Memory{Paths: []string{"*.go", "go.mod"}})
+	require.NoError(t, err)
+	assert.Equal(t, "*.go,go.mod", out["applyTo"])
+
+	out, err = Copilot.FromCanonical("memory", &canonical.Memory{Content: "always"})
+	require.NoError(t, err)
+	assert.NotContains(t, out, "applyTo")
+
+	_, err = Copilot.FromCanonical("skill", &canonical.Skill{})
+	var transformErr *canonical.TransformError
+	require.True(t, errors.As(err, &transformErr))
+
+	_, err = Copilot.FromCanonical("agent", &canonical.Command{})
+	require.Error(t, err)
+}
@@ -0,0 +1,45 @@
+// Package copilot implements the GitHub Copilot adapter for bidirectional
+// conversion between canonical models and Copilot's customization files
+// under .github/.
+//
+// Document Mapping
+//
+//	memory (no paths) → .github/copilot-instructions.md (plain Markdown)
+//	memory (paths)    → .github/instructions/<name>.instructions.md, paths → applyTo
+//	command           → .github/prompts/<name>.prompt.md
+//	agent             → .github/chatmodes/<name>.chatmode.md
+//
+// Skills have no Copilot equivalent and are rejected. Every unscoped
+// memory shares copilot-instructions.md, each as its own germinator
+// section (core.MergeSection), as AGENTS.md does for Codex.
+//
+// Copilot files carry no name field; names are derived from the file name
+// (minus the .prompt.md / .chatmode.md / .instructions.md suffix) when
+// canonicalizing.
+//
+// # Tool Name Conventions
+//
+// Copilot exposes its own tool set. Canonical tools with a Copilot
+// counterpart are renamed; everything else passes through verbatim so
+// Copilot-specific tools (githubRepo, problems, ...) survive a round trip:
+//
+//	bash      ↔ runCommands
+//	edit      ↔ editFiles  (write → editFiles)
+//	read      ↔ codebase   (list → codebase)
+//	grep      ↔ search     (glob → search)
+//	webfetch  ↔ fetch
+//
+// # Command Mode
+//
+// A prompt file's mode (ask, edit, agent, or a custom chat mode name) maps
+// to the canonical execution.agent field.
+//
+// Usage Example
+//
+//	import "gitlab.com/amoconst/germinator/internal/copilot"
+//
+//	adapter := copilot.Copilot
+//
+//	// Convert a prompt file to a canonical command
+//	_, cmd, _, _, err := adapter.ToCanonical(promptMap)
+package copilot
@@ -22,9 +22,13 @@ type Agent struct {
 	Content     string `yaml:"-" json:"-"`
 	FilePath    string `yaml:"-" json:"-"`
 
+	Extends string   `yaml:"extends,omitempty" json:"extends,omitempty"`
+	Replace []string `yaml:"replace,omitempty" json:"replace,omitempty"`
+
 	Tools            []string         `yaml:"tools,omitempty" json:"tools,omitempty"`
 	DisallowedTools  []string         `yaml:"disallowedTools,omitempty" json:"disallowedTools,omitempty"`
 	PermissionPolicy PermissionPolicy `yaml:"permissionPolicy,omitempty" json:"permissionPolicy,omitempty"`
+	Permissions      PermissionRules  `yaml:"permissions,omitempty" json:"permissions,omitempty"`
 	Behavior         AgentBehavior    `yaml:"behavior,omitempty" json:"behavior,omitempty"`
 	Targets          PlatformConfig   `yaml:"targets,omitempty" json:"targets,omitempty"`
 	Extensions       AgentExtensions  `yaml:"extensions,omitempty" json:"extensions,omitempty"`
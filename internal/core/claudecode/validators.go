@@ -0,0 +1,276 @@
+// Package claudecode provides Claude Code-specific validation functions.
+package claudecode
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/permission"
+)
+
+// mcpToolPrefix marks MCP server tools (mcp__<server>__<tool>), which
+// are named by the server rather than by Claude Code.
+const mcpToolPrefix = "mcp__"
+
+// allTools is the tools entry that grants every tool.
+const allTools = "*"
+
+// modelNames lists the model aliases Claude Code itself accepts; any
+// other model is a full model name (claude-sonnet-4-5-20250929).
+var modelNames = []string{"default", "sonnet", "opus", "haiku", "opusplan", "sonnet[1m]", "inherit"}
+
+// permissionModes lists the values of Claude Code's permissionMode.
+var permissionModes = []string{"default", "acceptEdits", "dontAsk", "bypassPermissions", "plan"}
+
+// matcherEvents lists the hook events that take a matcher: tool names
+// for the tool events, the trigger (manual, auto, startup, ...) for the
+// others.
+var matcherEvents = []string{"PreToolUse", "PostToolUse", "PreCompact", "SessionStart"}
+
+// ValidateAgentTools validates that the agent's tools and disallowed
+// tools render to Claude Code tool names.
+func ValidateAgentTools(a *core.Agent) core.Result[bool] {
+	return core.NewValidationPipeline(
+		func(a *core.Agent) core.Result[bool] { return validateTools("Agent", "tools", a.Tools) },
+		func(a *core.Agent) core.Result[bool] {
+			return validateTools("Agent", "disallowedTools", a.DisallowedTools)
+		},
+	).Validate(a)
+}
+
+// ValidateAgentPermissionMode validates a permissionMode set under
+// targets.claude-code, which is rendered when the agent has no
+// permissionPolicy.
+func ValidateAgentPermissionMode(a *core.Agent) core.Result[bool] {
+	mode, ok := a.Targets[core.PlatformClaudeCode]["permissionMode"]
+	if !ok {
+		return core.NewResult(true)
+	}
+	value := fmt.Sprint(mode)
+	if !slices.Contains(permissionModes, value) {
+		return core.NewErrorResult[bool](
+			core.NewValidationError(
+				"Agent",
+				"targets.claude-code.permissionMode",
+				value,
+				"permissionMode must be one of: "+strings.Join(permissionModes, ", "),
+			),
+		)
+	}
+	if a.PermissionPolicy != "" {
+		return core.NewErrorResult[bool](
+			core.NewValidationError(
+				"Agent",
+				"targets.claude-code.permissionMode",
+				value,
+				"permissionMode conflicts with permissionPolicy",
+			).WithSuggestions([]string{"set either permissionPolicy or targets.claude-code.permissionMode"}),
+		)
+	}
+	return core.NewResult(true)
+}
+
+// ValidateAgentModel validates that the agent's model, once model
+// aliases are resolved, is a Claude Code model name.
+func ValidateAgentModel(a *core.Agent) core.Result[bool] {
+	return validateModel("Agent", a.Model)
+}
+
+// ValidateAgentClaudeCode composes all Claude Code-specific agent
+// validators.
+func ValidateAgentClaudeCode(a *core.Agent) core.Result[bool] {
+	return core.NewValidationPipeline(
+		ValidateAgentTools,
+		ValidateAgentPermissionMode,
+		ValidateAgentModel,
+	).Validate(a)
+}
+
+// ValidateCommandTools validates that the command's tools render to
+// Claude Code tool names.
+func ValidateCommandTools(c *core.Command) core.Result[bool] {
+	return validateTools("Command", "tools", c.Tools)
+}
+
+// ValidateCommandFork validates that execution.agent is only set with
+// context: fork: Claude Code runs a command in an agent only when it
+// forks.
+func ValidateCommandFork(c *core.Command) core.Result[bool] {
+	return validateFork("Command", c.Execution.Context, c.Execution.Agent)
+}
+
+// ValidateCommandClaudeCode composes all Claude Code-specific command
+// validators.
+func ValidateCommandClaudeCode(c *core.Command) core.Result[bool] {
+	return core.NewValidationPipeline(
+		ValidateCommandTools,
+		ValidateCommandFork,
+		func(c *core.Command) core.Result[bool] { return validateModel("Command", c.Model) },
+	).Validate(c)
+}
+
+// reservedSkillWords may not appear in a Claude Code skill name.
+var reservedSkillWords = []string{"anthropic", "claude"}
+
+// ValidateSkillName validates Claude Code's skill name rules on top of
+// the core ones (1-64 characters of kebab-case): the name must not
+// contain a reserved word.
+func ValidateSkillName(s *core.Skill) core.Result[bool] {
+	for _, word := range reservedSkillWords {
+		if strings.Contains(s.Name, word) {
+			return core.NewErrorResult[bool](
+				core.NewValidationError("Skill", "name", s.Name, "name must not contain the reserved word "+word),
+			)
+		}
+	}
+	return core.NewResult(true)
+}
+
+// ValidateSkillDescription validates Claude Code's skill description
+// rules on top of the core ones (1-1024 characters): the description
+// must not contain XML tags.
+func ValidateSkillDescription(s *core.Skill) core.Result[bool] {
+	if open := strings.Index(s.Description, "<"); open >= 0 && strings.Contains(s.Description[open:], ">") {
+		return core.NewErrorResult[bool](
+			core.NewValidationError("Skill", "description", s.Description, "description must not contain XML tags"),
+		)
+	}
+	return core.NewResult(true)
+}
+
+// ValidateSkillFork validates that execution.agent is only set with
+// context: fork.
+func ValidateSkillFork(s *core.Skill) core.Result[bool] {
+	return validateFork("Skill", s.Execution.Context, s.Execution.Agent)
+}
+
+// ValidateSkillClaudeCode composes all Claude Code-specific skill
+// validators.
+func ValidateSkillClaudeCode(s *core.Skill) core.Result[bool] {
+	return core.NewValidationPipeline(
+		ValidateSkillName,
+		ValidateSkillDescription,
+		func(s *core.Skill) core.Result[bool] { return validateTools("Skill", "tools", s.Tools) },
+		ValidateSkillFork,
+		func(s *core.Skill) core.Result[bool] { return validateModel("Skill", s.Model) },
+	).Validate(s)
+}
+
+// ValidateHookMatcher validates that a matcher is only set on the
+// events Claude Code matches against. Unknown events are left to
+// core.ValidateHookEvent.
+func ValidateHookMatcher(h *core.Hook) core.Result[bool] {
+	if h.Matcher == "" || !slices.Contains(core.HookEvents(), h.Event) {
+		return core.NewResult(true)
+	}
+	if !slices.Contains(matcherEvents, h.Event) {
+		return core.NewErrorResult[bool](
+			core.NewValidationError("Hook", "matcher", h.Matcher, h.Event+" hooks take no matcher on Claude Code").
+				WithSuggestions([]string{"remove the matcher, or use PreToolUse, PostToolUse, PreCompact, or SessionStart"}),
+		)
+	}
+	return core.NewResult(true)
+}
+
+// ValidateHookClaudeCode composes all Claude Code-specific hook
+// validators.
+func ValidateHookClaudeCode(h *core.Hook) core.Result[bool] {
+	return core.NewValidationPipeline(
+		ValidateHookMatcher,
+	).Validate(h)
+}
+
+// ValidateSettingsClaudeCode validates Claude Code-specific settings
+// constraints: the model must be a Claude Code model name.
+func ValidateSettingsClaudeCode(s *core.Settings) core.Result[bool] {
+	return validateModel("Settings", s.Model)
+}
+
+// ToolName renders a canonical tool name as Claude Code spells it: a
+// built-in tool matched case-insensitively (webfetch and web-fetch
+// both become WebFetch), any other name in PascalCase. A Tool(spec)
+// specifier is kept verbatim; "*" and MCP tools pass through unchanged.
+func ToolName(name string) string {
+	if name == allTools || strings.HasPrefix(name, mcpToolPrefix) {
+		return name
+	}
+	tool, spec := splitToolSpec(name)
+	known, _ := permission.ClaudeCodeToolName(tool)
+	return known + spec
+}
+
+// validateTools reports the first tool in list that does not render to
+// a Claude Code tool name. Entries may be "*", MCP tools, or a tool
+// with a specifier such as Bash(git:*).
+func validateTools(request, field string, list []string) core.Result[bool] {
+	for i, tool := range list {
+		if tool == allTools || strings.HasPrefix(tool, mcpToolPrefix) {
+			continue
+		}
+		name, spec := splitToolSpec(tool)
+		if spec != "" && !strings.HasSuffix(spec, ")") {
+			return core.NewErrorResult[bool](
+				core.NewValidationError(request, fmt.Sprintf("%s[%d]", field, i), tool, "tool specifier must end with )").
+					WithSuggestions([]string{"write it as e.g. bash(git:*)"}),
+			)
+		}
+		if _, ok := permission.ClaudeCodeToolName(name); ok {
+			continue
+		}
+		return core.NewErrorResult[bool](
+			core.NewValidationError(
+				request,
+				fmt.Sprintf("%s[%d]", field, i),
+				tool,
+				"unknown Claude Code tool "+permission.ToPascalCase(name),
+			).WithSuggestions([]string{"use a built-in tool such as read, web-fetch, or bash(git:*), or an MCP tool (mcp__<server>__<tool>)"}),
+		)
+	}
+	return core.NewResult(true)
+}
+
+// splitToolSpec splits tool into its name and its "(spec)" suffix, if
+// any.
+func splitToolSpec(tool string) (name, spec string) {
+	if open := strings.IndexByte(tool, '('); open > 0 {
+		return tool[:open], tool[open:]
+	}
+	return tool, ""
+}
+
+// validateFork reports an execution agent set without context: fork.
+func validateFork(request, execContext, agent string) core.Result[bool] {
+	if agent != "" && execContext != "fork" {
+		return core.NewErrorResult[bool](
+			core.NewValidationError(request, "execution.agent", agent, "execution.agent applies only with execution.context: fork").
+				WithSuggestions([]string{"set execution.context to fork, or remove execution.agent"}),
+		)
+	}
+	return core.NewResult(true)
+}
+
+// IsModelID reports whether model is a Claude Code model: one of its
+// model aliases or a full Claude model name, including the
+// Bedrock and Vertex forms (us.anthropic.claude-..., claude-...@date).
+func IsModelID(model string) bool {
+	return slices.Contains(modelNames, model) ||
+		(strings.Contains(model, "claude-") && !strings.Contains(model, "/"))
+}
+
+// validateModel reports a non-empty model that is not a Claude Code
+// model, such as OpenCode's provider/model form or an unmapped alias.
+func validateModel(request, model string) core.Result[bool] {
+	if model == "" || IsModelID(model) {
+		return core.NewResult(true)
+	}
+	message := "unknown Claude Code model " + model
+	if strings.Contains(model, "/") {
+		message = "Claude Code models are written without a provider/ prefix"
+	}
+	return core.NewErrorResult[bool](
+		core.NewValidationError(request, "model", model, message).
+			WithSuggestions([]string{"use one of " + strings.Join(modelNames, ", ") + ", a claude-... model name, or a model alias mapped for claude-code"}),
+	)
+}
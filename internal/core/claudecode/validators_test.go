@@ -0,0 +1,372 @@
+package claudecode
+
+import (
+	"strings"
+	"testing"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestValidateAgentTools(t *testing.T) {
+	tests := []struct {
+		name        string
+		agent       *core.Agent
+		expectError bool
+	}{
+		{
+			name:        "no tools passes",
+			agent:       &core.Agent{},
+			expectError: false,
+		},
+		{
+			name:        "canonical names pass",
+			agent:       &core.Agent{Tools: []string{"bash", "read", "web-fetch", "todo-write"}},
+			expectError: false,
+		},
+		{
+			name:        "PascalCase names pass",
+			agent:       &core.Agent{Tools: []string{"Bash", "Grep"}},
+			expectError: false,
+		},
+		{
+			name:        "MCP tools pass",
+			agent:       &core.Agent{Tools: []string{"mcp__github__create_issue"}},
+			expectError: false,
+		},
+		{
+			name:        "lowercase names pass",
+			agent:       &core.Agent{Tools: []string{"webfetch", "todowrite", "lsp"}},
+			expectError: false,
+		},
+		{
+			name:        "all tools and specifiers pass",
+			agent:       &core.Agent{Tools: []string{"*", "agent", "bash(git:*)", "Read(./docs/**)"}},
+			expectError: false,
+		},
+		{
+			name:        "unterminated specifier fails",
+			agent:       &core.Agent{Tools: []string{"bash(git:*"}},
+			expectError: true,
+		},
+		{
+			name:        "unknown tool with specifier fails",
+			agent:       &core.Agent{Tools: []string{"shell(git:*)"}},
+			expectError: true,
+		},
+		{
+			name:        "unknown tool fails",
+			agent:       &core.Agent{Tools: []string{"list"}},
+			expectError: true,
+		},
+		{
+			name:        "unknown disallowed tool fails",
+			agent:       &core.Agent{DisallowedTools: []string{"patch"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateAgentTools(tt.agent)
+			if tt.expectError {
+				if result.IsSuccess() {
+					t.Error("expected error but got success")
+				}
+			} else {
+				if result.IsError() {
+					t.Errorf("expected success but got error: %v", result.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAgentTools_Suggestion(t *testing.T) {
+	result := ValidateAgentTools(&core.Agent{Tools: []string{"bash", "web-fetcher"}})
+	if result.IsSuccess() {
+		t.Fatal("expected error but got success")
+	}
+	if !strings.Contains(result.Error.Error(), "tools[1]") {
+		t.Errorf("expected error to name tools[1], got: %v", result.Error)
+	}
+	if !strings.Contains(result.Error.Error(), "unknown Claude Code tool WebFetcher") {
+		t.Errorf("expected the PascalCase name, got: %v", result.Error)
+	}
+}
+
+func TestToolName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "web-fetch", want: "WebFetch"},
+		{name: "webfetch", want: "WebFetch"},
+		{name: "todowrite", want: "TodoWrite"},
+		{name: "lsp", want: "LSP"},
+		{name: "bash(npm run test:*)", want: "Bash(npm run test:*)"},
+		{name: "*", want: "*"},
+		{name: "mcp__github__create_issue", want: "mcp__github__create_issue"},
+		{name: "custom-tool", want: "CustomTool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToolName(tt.name); got != tt.want {
+				t.Errorf("ToolName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAgentPermissionMode(t *testing.T) {
+	targets := func(mode any) core.PlatformConfig {
+		return core.PlatformConfig{core.PlatformClaudeCode: {"permissionMode": mode}}
+	}
+
+	tests := []struct {
+		name        string
+		agent       *core.Agent
+		expectError bool
+	}{
+		{
+			name:        "unset passes",
+			agent:       &core.Agent{},
+			expectError: false,
+		},
+		{
+			name:        "plan passes",
+			agent:       &core.Agent{Targets: targets("plan")},
+			expectError: false,
+		},
+		{
+			name:        "bypassPermissions passes",
+			agent:       &core.Agent{Targets: targets("bypassPermissions")},
+			expectError: false,
+		},
+		{
+			name:        "unknown mode fails",
+			agent:       &core.Agent{Targets: targets("yolo")},
+			expectError: true,
+		},
+		{
+			name: "mode with permissionPolicy fails",
+			agent: &core.Agent{
+				PermissionPolicy: core.PermissionPolicyBalanced,
+				Targets:          targets("plan"),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateAgentPermissionMode(tt.agent)
+			if tt.expectError {
+				if result.IsSuccess() {
+					t.Error("expected error but got success")
+				}
+			} else {
+				if result.IsError() {
+					t.Errorf("expected success but got error: %v", result.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAgentModel(t *testing.T) {
+	tests := []struct {
+		name        string
+		model       string
+		expectError bool
+	}{
+		{name: "empty passes", model: "", expectError: false},
+		{name: "alias passes", model: "sonnet", expectError: false},
+		{name: "model id passes", model: "claude-sonnet-4-5-20250929", expectError: false},
+		{name: "provider/model fails", model: "anthropic/claude-sonnet-4-5", expectError: true},
+		{name: "unknown name fails", model: "smartest", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateAgentModel(&core.Agent{Model: tt.model})
+			if tt.expectError {
+				if result.IsSuccess() {
+					t.Error("expected error but got success")
+				}
+			} else {
+				if result.IsError() {
+					t.Errorf("expected success but got error: %v", result.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestIsModelID(t *testing.T) {
+	tests := []struct {
+		model string
+		want  bool
+	}{
+		{model: "sonnet", want: true},
+		{model: "opusplan", want: true},
+		{model: "claude-sonnet-4-5-20250929", want: true},
+		{model: "us.anthropic.claude-sonnet-4-5-20250929-v1:0", want: true},
+		{model: "anthropic/claude-sonnet-4-5", want: false},
+		{model: "smartest", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			if got := IsModelID(tt.model); got != tt.want {
+				t.Errorf("IsModelID(%q) = %v, want %v", tt.model, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCommandClaudeCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     *core.Command
+		expectError bool
+	}{
+		{
+			name:        "plain command passes",
+			command:     &core.Command{Tools: []string{"bash"}},
+			expectError: false,
+		},
+		{
+			name:        "agent with fork passes",
+			command:     &core.Command{Execution: core.CommandExecution{Context: "fork", Agent: "explore"}},
+			expectError: false,
+		},
+		{
+			name:        "agent without fork fails",
+			command:     &core.Command{Execution: core.CommandExecution{Agent: "explore"}},
+			expectError: true,
+		},
+		{
+			name:        "unknown tool fails",
+			command:     &core.Command{Tools: []string{"question"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateCommandClaudeCode(tt.command)
+			if tt.expectError {
+				if result.IsSuccess() {
+					t.Error("expected error but got success")
+				}
+			} else {
+				if result.IsError() {
+					t.Errorf("expected success but got error: %v", result.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSkillClaudeCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		skill       *core.Skill
+		expectError bool
+	}{
+		{
+			name:        "plain skill passes",
+			skill:       &core.Skill{Name: "git-release", Description: "Create releases"},
+			expectError: false,
+		},
+		{
+			name:        "reserved word in name fails",
+			skill:       &core.Skill{Name: "claude-helper", Description: "Helps"},
+			expectError: true,
+		},
+		{
+			name:        "XML tag in description fails",
+			skill:       &core.Skill{Name: "git-release", Description: "Create <release> notes"},
+			expectError: true,
+		},
+		{
+			name:        "comparison in description passes",
+			skill:       &core.Skill{Name: "git-release", Description: "Use when diff size > 100 lines"},
+			expectError: false,
+		},
+		{
+			name: "agent without fork fails",
+			skill: &core.Skill{
+				Name:        "git-release",
+				Description: "Create releases",
+				Execution:   core.SkillExecution{Agent: "explore"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateSkillClaudeCode(tt.skill)
+			if tt.expectError {
+				if result.IsSuccess() {
+					t.Error("expected error but got success")
+				}
+			} else {
+				if result.IsError() {
+					t.Errorf("expected success but got error: %v", result.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateHookMatcher(t *testing.T) {
+	tests := []struct {
+		name        string
+		hook        *core.Hook
+		expectError bool
+	}{
+		{
+			name:        "tool event with matcher passes",
+			hook:        &core.Hook{Event: "PreToolUse", Matcher: "Edit|Write"},
+			expectError: false,
+		},
+		{
+			name:        "SessionStart with matcher passes",
+			hook:        &core.Hook{Event: "SessionStart", Matcher: "startup"},
+			expectError: false,
+		},
+		{
+			name:        "Stop without matcher passes",
+			hook:        &core.Hook{Event: "Stop"},
+			expectError: false,
+		},
+		{
+			name:        "Stop with matcher fails",
+			hook:        &core.Hook{Event: "Stop", Matcher: "Bash"},
+			expectError: true,
+		},
+		{
+			name:        "unknown event is left to core",
+			hook:        &core.Hook{Event: "Unknown", Matcher: "Bash"},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateHookMatcher(tt.hook)
+			if tt.expectError {
+				if result.IsSuccess() {
+					t.Error("expected error but got success")
+				}
+			} else {
+				if result.IsError() {
+					t.Errorf("expected success but got error: %v", result.Error)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Package codex provides Codex (AGENTS.md)-specific validation functions.
+package codex
+
+import (
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+// unsupported builds the rejection shared by the document types that
+// AGENTS.md cannot hold.
+func unsupported(request, docType, name string) core.Result[bool] {
+	return core.NewErrorResult[bool](
+		core.NewValidationError(
+			request,
+			"",
+			name,
+			"codex does not support "+docType+" documents",
+		).WithSuggestions([]string{"target codex with a memory document"}),
+	)
+}
+
+// ValidateAgentCodex rejects agents: AGENTS.md holds instructions only.
+func ValidateAgentCodex(a *core.Agent) core.Result[bool] {
+	return unsupported("Agent", "agent", a.Name)
+}
+
+// ValidateCommandCodex rejects commands: AGENTS.md holds instructions only.
+func ValidateCommandCodex(c *core.Command) core.Result[bool] {
+	return unsupported("Command", "command", c.Name)
+}
+
+// ValidateSkillCodex rejects skills: AGENTS.md holds instructions only.
+func ValidateSkillCodex(s *core.Skill) core.Result[bool] {
+	return unsupported("Skill", "skill", s.Name)
+}
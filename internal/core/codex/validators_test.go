@@ -0,0 +1,26 @@
+package codex
+
+import (
+	"testing"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestValidatorsRejectNonMemoryDocuments(t *testing.T) {
+	tests := []struct {
+		name   string
+		result core.Result[bool]
+	}{
+		{"agent", ValidateAgentCodex(&core.Agent{Name: "reviewer"})},
+		{"command", ValidateCommandCodex(&core.Command{Name: "review"})},
+		{"skill", ValidateSkillCodex(&core.Skill{Name: "commit"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result.IsSuccess() {
+				t.Errorf("expected %s documents to be rejected for codex", tt.name)
+			}
+		})
+	}
+}
@@ -19,6 +19,9 @@ type Command struct {
 	Content     string `yaml:"-" json:"-"`
 	FilePath    string `yaml:"-" json:"-"`
 
+	Extends string   `yaml:"extends,omitempty" json:"extends,omitempty"`
+	Replace []string `yaml:"replace,omitempty" json:"replace,omitempty"`
+
 	Tools     []string         `yaml:"tools,omitempty" json:"tools,omitempty"`
 	Execution CommandExecution `yaml:"execution,omitempty" json:"execution,omitempty"`
 	Arguments CommandArguments `yaml:"arguments,omitempty" json:"arguments,omitempty"`
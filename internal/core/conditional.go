@@ -0,0 +1,115 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Conditional blocks let one canonical body differ by platform:
+//
+//	Delegate the search to a subagent:
+//	<!-- germinator:if platform=claude-code -->
+//	use the Task tool.
+//	<!-- germinator:else -->
+//	mention @explore.
+//	<!-- germinator:endif -->
+//
+// The condition is platform=<id>[,<id>...] or platform!=<id>[,<id>...].
+// Directives sit on lines of their own, and blocks may nest.
+const (
+	conditionalIfPrefix = "<!-- germinator:if "
+	conditionalElse     = "<!-- germinator:else -->"
+	conditionalEnd      = "<!-- germinator:endif -->"
+)
+
+// conditionalBlock is one open germinator:if while a body is scanned.
+type conditionalBlock struct {
+	line      int
+	match     bool
+	inElse    bool
+	outerKeep bool
+}
+
+// SelectPlatformBlocks returns body with its conditional blocks resolved
+// for platform: the lines of every branch that applies are kept, the
+// others and the directive lines themselves removed. Text outside
+// blocks is untouched, so a body without directives is returned as is.
+// known lists the valid platform ids; a condition naming another
+// platform is an error, as are unbalanced directives. Errors are
+// *ParseError values naming the offending line.
+func SelectPlatformBlocks(body, platform string, known []string) (string, error) {
+	if !strings.Contains(body, "<!-- germinator:") {
+		return body, nil
+	}
+
+	lines := strings.SplitAfter(body, "\n")
+	var out strings.Builder
+	var stack []conditionalBlock
+	keep := true
+	for i, line := range lines {
+		n := i + 1
+		directive := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(directive, conditionalIfPrefix):
+			match, err := matchCondition(directive, platform, known)
+			if err != nil {
+				return "", conditionalError(n, err.Error())
+			}
+			stack = append(stack, conditionalBlock{line: n, match: match, outerKeep: keep})
+			keep = keep && match
+		case directive == conditionalElse:
+			if len(stack) == 0 {
+				return "", conditionalError(n, "germinator:else without germinator:if")
+			}
+			top := &stack[len(stack)-1]
+			if top.inElse {
+				return "", conditionalError(n, "second germinator:else in one block")
+			}
+			top.inElse = true
+			keep = top.outerKeep && !top.match
+		case directive == conditionalEnd:
+			if len(stack) == 0 {
+				return "", conditionalError(n, "germinator:endif without germinator:if")
+			}
+			keep = stack[len(stack)-1].outerKeep
+			stack = stack[:len(stack)-1]
+		default:
+			if keep {
+				out.WriteString(line)
+			}
+		}
+	}
+	if len(stack) > 0 {
+		return "", conditionalError(stack[len(stack)-1].line, "germinator:if has no germinator:endif")
+	}
+	return out.String(), nil
+}
+
+// matchCondition evaluates the condition of a germinator:if directive.
+func matchCondition(directive, platform string, known []string) (bool, error) {
+	cond := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(directive, conditionalIfPrefix), "-->"))
+	key, ids, negate := "", "", false
+	if k, v, ok := strings.Cut(cond, "!="); ok {
+		key, ids, negate = k, v, true
+	} else if k, v, ok := strings.Cut(cond, "="); ok {
+		key, ids = k, v
+	}
+	if strings.TrimSpace(key) != "platform" || strings.TrimSpace(ids) == "" {
+		return false, fmt.Errorf("unsupported condition %q (expected platform=<id> or platform!=<id>)", cond)
+	}
+
+	match := false
+	for _, id := range strings.Split(ids, ",") {
+		id = strings.TrimSpace(id)
+		if known != nil && !slices.Contains(known, id) {
+			return false, fmt.Errorf("unknown platform %q (known: %s)", id, strings.Join(known, ", "))
+		}
+		match = match || id == platform
+	}
+	return match != negate, nil
+}
+
+func conditionalError(line int, msg string) *ParseError {
+	return NewParseError("", fmt.Sprintf("body line %d: %s", line, msg), nil)
+}
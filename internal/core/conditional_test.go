@@ -0,0 +1,102 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectPlatformBlocks(t *testing.T) {
+	t.Parallel()
+
+	known := []string{"claude-code", "opencode", "cursor"}
+	body := "Delegate the search:\n" +
+		"<!-- germinator:if platform=claude-code -->\n" +
+		"Use the Task tool.\n" +
+		"<!-- germinator:else -->\n" +
+		"Mention @explore.\n" +
+		"<!-- germinator:if platform!=cursor -->\n" +
+		"It runs in the background.\n" +
+		"<!-- germinator:endif -->\n" +
+		"<!-- germinator:endif -->\n" +
+		"Then summarize.\n"
+
+	tests := []struct {
+		name     string
+		body     string
+		platform string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "if branch",
+			body:     body,
+			platform: "claude-code",
+			want:     "Delegate the search:\nUse the Task tool.\nThen summarize.\n",
+		},
+		{
+			name:     "else branch with nested block",
+			body:     body,
+			platform: "opencode",
+			want:     "Delegate the search:\nMention @explore.\nIt runs in the background.\nThen summarize.\n",
+		},
+		{
+			name:     "nested block excluded",
+			body:     body,
+			platform: "cursor",
+			want:     "Delegate the search:\nMention @explore.\nThen summarize.\n",
+		},
+		{
+			name:     "platform list",
+			body:     "<!-- germinator:if platform=opencode, cursor -->\nA\n<!-- germinator:endif -->\nB",
+			platform: "cursor",
+			want:     "A\nB",
+		},
+		{
+			name:     "other germinator markers are left alone",
+			body:     "<!-- germinator:begin memory/x -->\nA\n<!-- germinator:end memory/x -->\n",
+			platform: "opencode",
+			want:     "<!-- germinator:begin memory/x -->\nA\n<!-- germinator:end memory/x -->\n",
+		},
+		{
+			name:     "unclosed block",
+			body:     "A\n<!-- germinator:if platform=opencode -->\nB\n",
+			platform: "opencode",
+			wantErr:  "body line 2: germinator:if has no germinator:endif",
+		},
+		{
+			name:     "stray end",
+			body:     "A\n<!-- germinator:endif -->\n",
+			platform: "opencode",
+			wantErr:  "body line 2: germinator:endif without germinator:if",
+		},
+		{
+			name:     "unknown platform",
+			body:     "<!-- germinator:if platform=vscode -->\n<!-- germinator:endif -->\n",
+			platform: "opencode",
+			wantErr:  `unknown platform "vscode"`,
+		},
+		{
+			name:     "unsupported condition",
+			body:     "<!-- germinator:if model=opus -->\n<!-- germinator:endif -->\n",
+			platform: "opencode",
+			wantErr:  `unsupported condition "model=opus"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := SelectPlatformBlocks(tt.body, tt.platform, known)
+			if tt.wantErr != "" {
+				var perr *ParseError
+				require.ErrorAs(t, err, &perr)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
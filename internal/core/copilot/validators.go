@@ -0,0 +1,49 @@
+// Package copilot provides GitHub Copilot-specific validation functions.
+package copilot
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+// ValidateSkillCopilot rejects skills: Copilot has no skill equivalent,
+// so a skill cannot be adapted for Copilot.
+func ValidateSkillCopilot(s *core.Skill) core.Result[bool] {
+	return core.NewErrorResult[bool](
+		core.NewValidationError(
+			"Skill",
+			"",
+			s.Name,
+			"copilot does not support skill documents",
+		).WithSuggestions([]string{"target copilot with an agent, command, or memory document"}),
+	)
+}
+
+// IsModelID reports whether model reads as a Copilot model name.
+// Copilot names models by display name (GPT-4.1, Claude Sonnet 4.5,
+// o3), every one of which carries a version number.
+func IsModelID(model string) bool {
+	return strings.ContainsFunc(model, unicode.IsDigit)
+}
+
+// ValidateMemoryCopilot validates that each path can be emitted in an
+// applyTo list. Copilot separates applyTo globs with commas, so a path
+// containing a comma would be split into two globs.
+func ValidateMemoryCopilot(m *core.Memory) core.Result[bool] {
+	for i, p := range m.Paths {
+		if strings.Contains(p, ",") {
+			return core.NewErrorResult[bool](
+				core.NewValidationError(
+					"Memory",
+					fmt.Sprintf("paths[%d]", i),
+					p,
+					"copilot applyTo globs are comma-separated; a path must not contain a comma",
+				),
+			)
+		}
+	}
+	return core.NewResult(true)
+}
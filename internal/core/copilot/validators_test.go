@@ -0,0 +1,64 @@
+package copilot
+
+import (
+	"testing"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestValidateSkillCopilot(t *testing.T) {
+	result := ValidateSkillCopilot(&core.Skill{Name: "commit", Description: "Commit helper"})
+	if result.IsSuccess() {
+		t.Error("expected skills to be rejected for copilot")
+	}
+}
+
+func TestValidateMemoryCopilot(t *testing.T) {
+	tests := []struct {
+		name        string
+		memory      *core.Memory
+		expectError bool
+	}{
+		{
+			name:        "no paths passes",
+			memory:      &core.Memory{Content: "x"},
+			expectError: false,
+		},
+		{
+			name:        "plain globs pass",
+			memory:      &core.Memory{Paths: []string{"**/*.ts", "docs/**"}},
+			expectError: false,
+		},
+		{
+			name:        "comma in path fails",
+			memory:      &core.Memory{Paths: []string{"src/{a,b}/*.ts"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateMemoryCopilot(tt.memory)
+			if tt.expectError {
+				if result.IsSuccess() {
+					t.Error("expected error but got success")
+				}
+			} else {
+				if result.IsError() {
+					t.Errorf("expected success but got error: %v", result.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestIsModelID(t *testing.T) {
+	for _, model := range []string{"GPT-4.1", "Claude Sonnet 4.5", "o3"} {
+		if !IsModelID(model) {
+			t.Errorf("IsModelID(%q) = false, want true", model)
+		}
+	}
+	if IsModelID("smartest") {
+		t.Error("IsModelID(\"smartest\") = true, want false")
+	}
+}
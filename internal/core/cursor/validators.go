@@ -0,0 +1,41 @@
+// Package cursor provides Cursor-specific validation functions.
+package cursor
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+// ValidateAgentCursor rejects agents: Cursor rules have no agent
+// equivalent, so an agent cannot be adapted for Cursor.
+func ValidateAgentCursor(a *core.Agent) core.Result[bool] {
+	return core.NewErrorResult[bool](
+		core.NewValidationError(
+			"Agent",
+			"",
+			a.Name,
+			"cursor does not support agent documents",
+		).WithSuggestions([]string{"target cursor with a skill, command, or memory document"}),
+	)
+}
+
+// ValidateMemoryCursor validates that each path can be emitted as a
+// Cursor glob. Cursor stores globs as one comma-separated string, so a
+// path containing a comma would be split into two globs.
+func ValidateMemoryCursor(m *core.Memory) core.Result[bool] {
+	for i, p := range m.Paths {
+		if strings.Contains(p, ",") {
+			return core.NewErrorResult[bool](
+				core.NewValidationError(
+					"Memory",
+					fmt.Sprintf("paths[%d]", i),
+					p,
+					"cursor globs are comma-separated; a path must not contain a comma",
+				),
+			)
+		}
+	}
+	return core.NewResult(true)
+}
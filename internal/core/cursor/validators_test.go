@@ -0,0 +1,53 @@
+package cursor
+
+import (
+	"testing"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestValidateAgentCursor(t *testing.T) {
+	result := ValidateAgentCursor(&core.Agent{Name: "reviewer", Description: "Reviews code"})
+	if result.IsSuccess() {
+		t.Error("expected agents to be rejected for cursor")
+	}
+}
+
+func TestValidateMemoryCursor(t *testing.T) {
+	tests := []struct {
+		name        string
+		memory      *core.Memory
+		expectError bool
+	}{
+		{
+			name:        "no paths passes",
+			memory:      &core.Memory{Content: "x"},
+			expectError: false,
+		},
+		{
+			name:        "plain globs pass",
+			memory:      &core.Memory{Paths: []string{"src/**/*.go", "*.md"}},
+			expectError: false,
+		},
+		{
+			name:        "comma in path fails",
+			memory:      &core.Memory{Paths: []string{"src/{a,b}/*.go"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateMemoryCursor(tt.memory)
+			if tt.expectError {
+				if result.IsSuccess() {
+					t.Error("expected error but got success")
+				}
+			} else {
+				if result.IsError() {
+					t.Errorf("expected success but got error: %v", result.Error)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package core
+
+import "strings"
+
+// FieldDrift records a canonical field whose value changed when a
+// document was rendered for a platform and parsed back.
+type FieldDrift struct {
+	// Field is the dotted YAML path of the canonical field.
+	Field string `json:"field"`
+	// Want is the value of the source document, empty when unset.
+	Want string `json:"want"`
+	// Got is the value read back from the platform, empty when unset.
+	Got string `json:"got"`
+}
+
+// DetectDrift compares two canonical models of the same type (*Agent,
+// *Command, *Skill, *Memory, or *MCPServer) field by field and returns
+// a FieldDrift for every leaf that differs. Fields covered by dropped,
+// which the platform is known not to represent, are not drift. Drift is
+// returned in canonical field order, followed by fields only got sets.
+func DetectDrift(want, got any, dropped []FieldLoss) []FieldDrift {
+	gotFields := make(map[string]string)
+	var gotOrder []string
+	for _, f := range setFields(got) {
+		gotFields[f.path] = f.value
+		gotOrder = append(gotOrder, f.path)
+	}
+
+	var drift []FieldDrift
+	seen := make(map[string]bool)
+	for _, f := range setFields(want) {
+		seen[f.path] = true
+		if isDropped(f.path, dropped) {
+			continue
+		}
+		if g, ok := gotFields[f.path]; !ok || g != f.value {
+			drift = append(drift, FieldDrift{Field: f.path, Want: f.value, Got: g})
+		}
+	}
+	for _, path := range gotOrder {
+		if !seen[path] && !isDropped(path, dropped) {
+			drift = append(drift, FieldDrift{Field: path, Got: gotFields[path]})
+		}
+	}
+	return drift
+}
+
+func isDropped(field string, dropped []FieldLoss) bool {
+	for _, l := range dropped {
+		if field == l.Field || strings.HasPrefix(field, l.Field+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectDrift(t *testing.T) {
+	t.Parallel()
+
+	want := &Agent{
+		Name:        "reviewer",
+		Description: "Reviews code",
+		Tools:       []string{"read", "grep"},
+		Behavior:    AgentBehavior{Steps: 5, Mode: "subagent"},
+		Targets:     PlatformConfig{"claude-code": {"skills": []string{"commit"}}},
+	}
+
+	tests := []struct {
+		name    string
+		got     *Agent
+		dropped []FieldLoss
+		want    []FieldDrift
+	}{
+		{
+			name: "identical",
+			got:  want,
+		},
+		{
+			name: "changed and missing fields",
+			got: &Agent{
+				Name:        "reviewer",
+				Description: "Reviews code",
+				Tools:       []string{"read"},
+				Behavior:    AgentBehavior{Mode: "subagent"},
+				Targets:     PlatformConfig{"claude-code": {"skills": []string{"commit"}}},
+			},
+			want: []FieldDrift{
+				{Field: "tools", Want: "read, grep", Got: "read"},
+				{Field: "behavior.steps", Want: "5"},
+			},
+		},
+		{
+			name: "dropped fields are not drift",
+			got:  &Agent{Name: "reviewer", Description: "Reviews code", Tools: []string{"read", "grep"}},
+			dropped: []FieldLoss{
+				{Field: "behavior"},
+				{Field: "targets.claude-code.skills"},
+			},
+		},
+		{
+			name: "fields only the parsed document sets",
+			got: &Agent{
+				Name:             "reviewer",
+				Description:      "Reviews code",
+				Tools:            []string{"read", "grep"},
+				PermissionPolicy: PermissionPolicyBalanced,
+				Behavior:         AgentBehavior{Steps: 5, Mode: "subagent"},
+				Targets:          PlatformConfig{"claude-code": {"skills": []string{"commit"}}},
+			},
+			want: []FieldDrift{{Field: "permissionPolicy", Got: "balanced"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, DetectDrift(want, tt.got, tt.dropped))
+		})
+	}
+}
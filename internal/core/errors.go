@@ -34,8 +34,12 @@ func (e *NotFoundError) Unwrap() error {
 }
 
 // ParseError represents a parsing failure with immutable builders for fluent construction.
+// A ParseError with a position (WithPosition) renders compiler-style
+// as path:line:col: message.
 type ParseError struct {
 	path        string
+	line        int
+	column      int
 	message     string
 	cause       error
 	suggestions []string
@@ -57,6 +61,8 @@ func NewParseError(path, message string, cause error) *ParseError {
 func (e *ParseError) WithSuggestions(suggestions []string) *ParseError {
 	return &ParseError{
 		path:        e.path,
+		line:        e.line,
+		column:      e.column,
 		message:     e.message,
 		cause:       e.cause,
 		suggestions: suggestions,
@@ -68,6 +74,8 @@ func (e *ParseError) WithSuggestions(suggestions []string) *ParseError {
 func (e *ParseError) WithContext(context string) *ParseError {
 	return &ParseError{
 		path:        e.path,
+		line:        e.line,
+		column:      e.column,
 		message:     e.message,
 		cause:       e.cause,
 		suggestions: e.suggestions,
@@ -75,11 +83,50 @@ func (e *ParseError) WithContext(context string) *ParseError {
 	}
 }
 
+// WithPosition returns a new ParseError located at line and column of
+// its file (immutable builder). Both are 1-based; a column of 0 means
+// only the line is known.
+func (e *ParseError) WithPosition(line, column int) *ParseError {
+	return &ParseError{
+		path:        e.path,
+		line:        line,
+		column:      column,
+		message:     e.message,
+		cause:       e.cause,
+		suggestions: e.suggestions,
+		context:     e.context,
+	}
+}
+
 // Path returns the file path where the parse error occurred.
 func (e *ParseError) Path() string {
 	return e.path
 }
 
+// Line returns the 1-based line of the error, or 0 when unknown.
+func (e *ParseError) Line() int {
+	return e.line
+}
+
+// Column returns the 1-based column of the error, or 0 when unknown.
+func (e *ParseError) Column() int {
+	return e.column
+}
+
+// Location returns the error's path with its position appended
+// compiler-style, e.g. agent.md:4:3, or the path alone when the
+// position is unknown.
+func (e *ParseError) Location() string {
+	if e.line <= 0 {
+		return e.path
+	}
+	loc := fmt.Sprintf("%s:%d", e.path, e.line)
+	if e.column > 0 {
+		loc += fmt.Sprintf(":%d", e.column)
+	}
+	return loc
+}
+
 // Message returns the parse error message.
 func (e *ParseError) Message() string {
 	return e.message
@@ -109,9 +156,12 @@ func (e *ParseError) Context() string {
 func (e *ParseError) Error() string {
 	var parts []string
 
-	if e.path != "" {
+	switch {
+	case e.line > 0:
+		parts = append(parts, e.Location())
+	case e.path != "":
 		parts = append(parts, "parse error in "+e.path)
-	} else {
+	default:
 		parts = append(parts, "parse error")
 	}
 
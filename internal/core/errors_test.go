@@ -47,6 +47,18 @@ func TestParseError(t *testing.T) {
 			wantMsg:    "parse error in test.yaml: invalid YAML: yaml: line 5\n💡 Check indentation",
 			wantUnwrap: fmt.Errorf("yaml: line 5"),
 		},
+		{
+			name:       "with position",
+			err:        NewParseError("agent.md", "failed to parse agent", fmt.Errorf("cannot unmarshal")).WithPosition(4, 8),
+			wantMsg:    "agent.md:4:8: failed to parse agent: cannot unmarshal",
+			wantUnwrap: fmt.Errorf("cannot unmarshal"),
+		},
+		{
+			name:       "with line only",
+			err:        NewParseError("agent.md", "unknown field permissionMode", nil).WithPosition(3, 0).WithSuggestions([]string{"did you mean permissionPolicy?"}),
+			wantMsg:    "agent.md:3: unknown field permissionMode\n💡 did you mean permissionPolicy?",
+			wantUnwrap: nil,
+		},
 	}
 
 	for _, tt := range tests {
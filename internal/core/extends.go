@@ -0,0 +1,177 @@
+package core
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ExtendsMarker is the body line of a document that stands for the body
+// of the document it extends. Text before the marker is prepended to
+// the inherited body and text after it appended.
+const ExtendsMarker = "<!-- germinator:extends -->"
+
+// Extend composes child on base, the document named by child's
+// `extends`. Both must be fully resolved (base has no `extends` of its
+// own). set holds the dotted YAML paths child's frontmatter sets, such
+// as "behavior.steps"; it may be nil when they are unknown. The result
+// is child with every field it leaves unset taken from base:
+//
+//   - scalars: a value set on child overrides base, even a zero value
+//     such as false when its path is in set;
+//   - lists (tools, disallowedTools, extensions.compatibility): the
+//     union of both, base entries first, unless child names the field
+//     in `replace`, in which case child's list is used as is;
+//   - maps (targets, permissions, hooks, metadata): merged key by key,
+//     recursively for nested maps, with child winning on conflicts.
+//
+// Name is never inherited, and the result has no extends or replace.
+// A `replace` entry that is not a list field is a *ValidationError.
+func Extend[T Agent | Command | Skill](base, child T, set map[string]bool) (T, error) {
+	m := &extendMerger{set: set, replace: make(map[string]bool), lists: make(map[string]bool)}
+	out := reflect.ValueOf(&child).Elem()
+	for _, field := range out.FieldByName("Replace").Interface().([]string) { //nolint:forcetypeassert // every T declares Replace []string
+		m.replace[field] = true
+	}
+
+	m.mergeStruct(out, reflect.ValueOf(base), "")
+
+	for _, field := range slices.Sorted(maps.Keys(m.replace)) {
+		if !m.lists[field] {
+			return child, NewValidationError("", "replace", field, "not a list field").
+				WithSuggestions(slices.Sorted(maps.Keys(m.lists)))
+		}
+	}
+
+	out.FieldByName("Extends").SetZero()
+	out.FieldByName("Replace").SetZero()
+	return child, nil
+}
+
+// ExtendContent composes a child body on the body of the document it
+// extends. An empty child body inherits base; a body holding
+// ExtendsMarker has the marker line replaced by base; any other body
+// replaces base.
+func ExtendContent(base, child string) string {
+	if strings.TrimSpace(child) == "" {
+		return base
+	}
+	lines := strings.SplitAfter(child, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != ExtendsMarker {
+			continue
+		}
+		inherited := strings.Trim(base, "\n")
+		if inherited != "" {
+			inherited += "\n"
+		}
+		return strings.Join(lines[:i], "") + inherited + strings.Join(lines[i+1:], "")
+	}
+	return child
+}
+
+// extendMerger carries the set and replace paths of one Extend call and
+// records the list fields it meets, so unknown replace entries can be
+// reported.
+type extendMerger struct {
+	set     map[string]bool
+	replace map[string]bool
+	lists   map[string]bool
+}
+
+// mergeStruct fills the fields of child (addressable) from base,
+// following the rules of Extend. prefix is the dotted YAML path of the
+// struct.
+func (m *extendMerger) mergeStruct(child, base reflect.Value, prefix string) {
+	t := child.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		if !sf.IsExported() || name == "-" {
+			continue
+		}
+		if prefix == "" && (name == "name" || name == "extends" || name == "replace") {
+			continue
+		}
+		path := prefix + name
+		cf, bf := child.Field(i), base.Field(i)
+
+		switch cf.Kind() { //nolint:exhaustive // remaining kinds are scalars
+		case reflect.Struct:
+			m.mergeStruct(cf, bf, path+".")
+		case reflect.Slice:
+			m.lists[path] = true
+			if !m.replace[path] {
+				cf.Set(unionSlices(bf, cf))
+			}
+		case reflect.Map:
+			cf.Set(mergeMaps(bf, cf))
+		default:
+			if cf.IsZero() && !m.set[path] {
+				cf.Set(bf)
+			}
+		}
+	}
+}
+
+// unionSlices returns base followed by the entries of child not already
+// in base.
+func unionSlices(base, child reflect.Value) reflect.Value {
+	if base.Len() == 0 {
+		return child
+	}
+	out := reflect.MakeSlice(base.Type(), 0, base.Len()+child.Len())
+	seen := make(map[any]bool, base.Len()+child.Len())
+	for _, list := range []reflect.Value{base, child} {
+		for i := range list.Len() {
+			item := list.Index(i)
+			if seen[item.Interface()] {
+				continue
+			}
+			seen[item.Interface()] = true
+			out = reflect.Append(out, item)
+		}
+	}
+	return out
+}
+
+// mergeMaps returns a new map of base's type holding base with child
+// layered on top. Values that are maps on both sides are merged
+// recursively; otherwise child's value wins.
+func mergeMaps(base, child reflect.Value) reflect.Value {
+	if base.Len() == 0 {
+		return child
+	}
+	if child.Len() == 0 {
+		return base
+	}
+	out := reflect.MakeMapWithSize(base.Type(), base.Len()+child.Len())
+	iter := base.MapRange()
+	for iter.Next() {
+		out.SetMapIndex(iter.Key(), iter.Value())
+	}
+	iter = child.MapRange()
+	for iter.Next() {
+		value := iter.Value()
+		if existing := out.MapIndex(iter.Key()); existing.IsValid() {
+			if b, c := mapValue(existing), mapValue(value); b.IsValid() && c.IsValid() && b.Type() == c.Type() {
+				value = mergeMaps(b, c)
+			}
+		}
+		out.SetMapIndex(iter.Key(), value)
+	}
+	return out
+}
+
+// mapValue returns v, unwrapped from an interface, when it holds a map,
+// and the zero Value otherwise.
+func mapValue(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Map {
+		return reflect.Value{}
+	}
+	return v
+}
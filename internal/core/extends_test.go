@@ -0,0 +1,160 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtend(t *testing.T) {
+	t.Parallel()
+
+	temp := 0.2
+	base := Agent{
+		Name:             "base-reviewer",
+		Description:      "Reviews code",
+		Tools:            []string{"read", "grep"},
+		PermissionPolicy: PermissionPolicyRestrictive,
+		Permissions:      PermissionRules{"bash": {"*": PermissionAsk}},
+		Behavior:         AgentBehavior{Mode: "subagent", Temperature: &temp, Steps: 10, Hidden: true},
+		Targets: PlatformConfig{
+			"claude-code": {"skills": []interface{}{"commit"}, "permissionMode": "plan"},
+			"opencode":    {"options": map[string]interface{}{"a": 1, "b": 2}},
+		},
+		Model: "sonnet",
+	}
+
+	tests := []struct {
+		name  string
+		child Agent
+		set   map[string]bool
+		want  Agent
+	}{
+		{
+			name:  "inherits unset fields but not the name",
+			child: Agent{Name: "go-reviewer", Extends: "agent/base-reviewer"},
+			want: Agent{
+				Name:             "go-reviewer",
+				Description:      "Reviews code",
+				Tools:            []string{"read", "grep"},
+				PermissionPolicy: PermissionPolicyRestrictive,
+				Permissions:      PermissionRules{"bash": {"*": PermissionAsk}},
+				Behavior:         AgentBehavior{Mode: "subagent", Temperature: &temp, Steps: 10, Hidden: true},
+				Targets:          base.Targets,
+				Model:            "sonnet",
+			},
+		},
+		{
+			name: "scalars override, lists union, maps deep-merge",
+			child: Agent{
+				Name:        "go-reviewer",
+				Description: "Reviews Go code",
+				Extends:     "agent/base-reviewer",
+				Tools:       []string{"grep", "bash"},
+				Permissions: PermissionRules{"bash": {"go test*": PermissionAllow}},
+				Behavior:    AgentBehavior{Steps: 20},
+				Targets: PlatformConfig{
+					"claude-code": {"permissionMode": "default"},
+					"opencode":    {"options": map[string]interface{}{"b": 3}},
+				},
+			},
+			want: Agent{
+				Name:             "go-reviewer",
+				Description:      "Reviews Go code",
+				Tools:            []string{"read", "grep", "bash"},
+				PermissionPolicy: PermissionPolicyRestrictive,
+				Permissions:      PermissionRules{"bash": {"*": PermissionAsk, "go test*": PermissionAllow}},
+				Behavior:         AgentBehavior{Mode: "subagent", Temperature: &temp, Steps: 20, Hidden: true},
+				Targets: PlatformConfig{
+					"claude-code": {"skills": []interface{}{"commit"}, "permissionMode": "default"},
+					"opencode":    {"options": map[string]interface{}{"a": 1, "b": 3}},
+				},
+				Model: "sonnet",
+			},
+		},
+		{
+			name: "replace takes the child's list as is",
+			child: Agent{
+				Name:    "go-reviewer",
+				Extends: "agent/base-reviewer",
+				Replace: []string{"tools"},
+				Tools:   []string{"bash"},
+			},
+			want: Agent{
+				Name:             "go-reviewer",
+				Description:      "Reviews code",
+				Tools:            []string{"bash"},
+				PermissionPolicy: PermissionPolicyRestrictive,
+				Permissions:      PermissionRules{"bash": {"*": PermissionAsk}},
+				Behavior:         AgentBehavior{Mode: "subagent", Temperature: &temp, Steps: 10, Hidden: true},
+				Targets:          base.Targets,
+				Model:            "sonnet",
+			},
+		},
+		{
+			name: "a set zero value overrides base",
+			child: Agent{
+				Name:     "go-reviewer",
+				Extends:  "agent/base-reviewer",
+				Behavior: AgentBehavior{Hidden: false, Steps: 0},
+			},
+			set: map[string]bool{"behavior.hidden": true},
+			want: Agent{
+				Name:             "go-reviewer",
+				Description:      "Reviews code",
+				Tools:            []string{"read", "grep"},
+				PermissionPolicy: PermissionPolicyRestrictive,
+				Permissions:      PermissionRules{"bash": {"*": PermissionAsk}},
+				Behavior:         AgentBehavior{Mode: "subagent", Temperature: &temp, Steps: 10},
+				Targets:          base.Targets,
+				Model:            "sonnet",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Extend(base, tt.child, tt.set)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExtend_UnknownReplaceField(t *testing.T) {
+	t.Parallel()
+
+	_, err := Extend(Skill{Name: "base"}, Skill{Name: "child", Replace: []string{"description"}}, nil)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "replace", verr.Field())
+	assert.Equal(t, "description", verr.Value())
+	assert.Contains(t, verr.Suggestions(), "extensions.compatibility")
+}
+
+func TestExtendContent(t *testing.T) {
+	t.Parallel()
+
+	base := "Review the diff.\n"
+
+	tests := []struct {
+		name  string
+		child string
+		want  string
+	}{
+		{name: "empty child inherits", child: "\n", want: base},
+		{name: "child without marker replaces", child: "Only this.\n", want: "Only this.\n"},
+		{name: "append after marker", child: ExtendsMarker + "\nAlso check Go style.\n", want: "Review the diff.\nAlso check Go style.\n"},
+		{name: "prepend before marker", child: "You are strict.\n" + ExtendsMarker + "\n", want: "You are strict.\nReview the diff.\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ExtendContent(base, tt.child))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Package gemini provides Gemini CLI-specific validation functions.
+package gemini
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+// ValidateAgentGemini rejects agents: Gemini CLI has no agent files, so an
+// agent cannot be adapted for Gemini CLI.
+func ValidateAgentGemini(a *core.Agent) core.Result[bool] {
+	return core.NewErrorResult[bool](
+		core.NewValidationError(
+			"Agent",
+			"",
+			a.Name,
+			"gemini does not support agent documents",
+		).WithSuggestions([]string{"target gemini with a command or memory document"}),
+	)
+}
+
+// ValidateSkillGemini rejects skills: Gemini CLI has no skill files, so a
+// skill cannot be adapted for Gemini CLI.
+func ValidateSkillGemini(s *core.Skill) core.Result[bool] {
+	return core.NewErrorResult[bool](
+		core.NewValidationError(
+			"Skill",
+			"",
+			s.Name,
+			"gemini does not support skill documents",
+		).WithSuggestions([]string{"target gemini with a command or memory document"}),
+	)
+}
+
+// IsGlob reports whether a memory path is a glob pattern, which a
+// GEMINI.md @file import cannot name.
+func IsGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[{")
+}
+
+// ValidateMemoryGemini validates that each path can be emitted as a
+// GEMINI.md @file import. Imports name a single file, so glob patterns
+// are rejected.
+func ValidateMemoryGemini(m *core.Memory) core.Result[bool] {
+	for i, p := range m.Paths {
+		if IsGlob(p) {
+			return core.NewErrorResult[bool](
+				core.NewValidationError(
+					"Memory",
+					fmt.Sprintf("paths[%d]", i),
+					p,
+					"gemini imports context files with @path; a path must not be a glob",
+				).WithSuggestions([]string{"list each file to import explicitly"}),
+			)
+		}
+	}
+	return core.NewResult(true)
+}
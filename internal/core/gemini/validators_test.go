@@ -0,0 +1,60 @@
+package gemini
+
+import (
+	"testing"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestValidateAgentGemini(t *testing.T) {
+	result := ValidateAgentGemini(&core.Agent{Name: "reviewer", Description: "Reviews code"})
+	if result.IsSuccess() {
+		t.Error("expected agents to be rejected for gemini")
+	}
+}
+
+func TestValidateSkillGemini(t *testing.T) {
+	result := ValidateSkillGemini(&core.Skill{Name: "commit", Description: "Commit helper"})
+	if result.IsSuccess() {
+		t.Error("expected skills to be rejected for gemini")
+	}
+}
+
+func TestValidateMemoryGemini(t *testing.T) {
+	tests := []struct {
+		name        string
+		memory      *core.Memory
+		expectError bool
+	}{
+		{
+			name:        "no paths passes",
+			memory:      &core.Memory{Content: "x"},
+			expectError: false,
+		},
+		{
+			name:        "file paths pass",
+			memory:      &core.Memory{Paths: []string{"docs/style.md", "README.md"}},
+			expectError: false,
+		},
+		{
+			name:        "glob fails",
+			memory:      &core.Memory{Paths: []string{"src/**/*.go"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateMemoryGemini(tt.memory)
+			if tt.expectError {
+				if result.IsSuccess() {
+					t.Error("expected error but got success")
+				}
+			} else {
+				if result.IsError() {
+					t.Errorf("expected success but got error: %v", result.Error)
+				}
+			}
+		})
+	}
+}
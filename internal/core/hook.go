@@ -0,0 +1,41 @@
+package core
+
+import "slices"
+
+// Hook represents a lifecycle hook: a shell command run when the coding
+// assistant fires Event. Matcher narrows tool events to the tools whose
+// names match it (a regular expression such as "Edit|Write"); an empty
+// Matcher matches every tool. Timeout is in seconds, 0 meaning the
+// platform default.
+//
+// Event names are Claude Code's (PreToolUse, PostToolUse, Stop, ...),
+// which the canonical format adopts as-is; other platforms map the
+// subset they can express.
+type Hook struct {
+	Name     string `yaml:"name" json:"name"`
+	FilePath string `yaml:"-" json:"-"`
+
+	Event   string `yaml:"event" json:"event"`
+	Matcher string `yaml:"matcher,omitempty" json:"matcher,omitempty"`
+	Command string `yaml:"command" json:"command"`
+	Timeout int    `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// hookEvents lists the canonical hook events in the order Claude Code
+// documents them.
+var hookEvents = []string{
+	"PreToolUse",
+	"PostToolUse",
+	"Notification",
+	"UserPromptSubmit",
+	"Stop",
+	"SubagentStop",
+	"PreCompact",
+	"SessionStart",
+	"SessionEnd",
+}
+
+// HookEvents returns a copy of the canonical hook events.
+func HookEvents() []string {
+	return slices.Clone(hookEvents)
+}
@@ -0,0 +1,479 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// JSONMerge selects how a rendered JSON fragment is merged into a JSON
+// config file shared by several resources.
+type JSONMerge string
+
+// JSONMerge strategies. The zero value means the layout is not JSON.
+const (
+	JSONMergeNone     JSONMerge = ""
+	JSONMergeEntries  JSONMerge = "entries"
+	JSONMergeHooks    JSONMerge = "hooks"
+	JSONMergeSettings JSONMerge = "settings"
+)
+
+// MergeJSON merges fragment into existing with strategy: MergeJSONEntries
+// for JSONMergeEntries, MergeJSONHooks for JSONMergeHooks, and
+// MergeJSONSettings for JSONMergeSettings. overwrite resolves conflicts
+// in the fragment's favor; strategies that never report conflicts
+// ignore it.
+func MergeJSON(strategy JSONMerge, existing, fragment string, overwrite bool) (string, error) {
+	switch strategy {
+	case JSONMergeEntries:
+		return MergeJSONEntries(existing, fragment)
+	case JSONMergeHooks:
+		return MergeJSONHooks(existing, fragment)
+	case JSONMergeSettings:
+		return MergeJSONSettings(existing, fragment, overwrite)
+	default:
+		return "", NewConfigError("merge", string(strategy), "unknown JSON merge strategy")
+	}
+}
+
+// MergeJSONEntries merges fragment, a JSON object rendered for one
+// resource, into existing, the current contents of a JSON config file
+// shared by several resources (e.g. .mcp.json). For each top-level key
+// of fragment:
+//
+//   - when both documents hold an object under the key, each entry of
+//     the fragment's object is set on the existing object, replacing an
+//     entry of the same name wholesale ({"mcpServers": {"github": ...}});
+//   - otherwise the fragment's value replaces the existing one.
+//
+// Keys keep their order in existing and new keys are appended in
+// fragment order, so hand-written settings stay where they were. An
+// empty existing document merges as {}. The result is indented with two
+// spaces and ends with a newline.
+//
+// Content that is not a JSON object is reported as a *ParseError rather
+// than overwritten.
+func MergeJSONEntries(existing, fragment string) (string, error) {
+	base, err := parseJSONObject(existing)
+	if err != nil {
+		return "", NewParseError("", "existing file is not a JSON object", err).
+			WithSuggestions([]string{"fix or remove the file, then rerun"})
+	}
+	frag, err := parseJSONObject(fragment)
+	if err != nil {
+		return "", NewParseError("", "rendered document is not a JSON object", err)
+	}
+
+	for _, key := range frag.keys {
+		value := frag.values[key]
+		if current, ok := base.values[key]; ok {
+			inner, innerErr := parseJSONObject(string(current))
+			entries, entriesErr := parseJSONObject(string(value))
+			if innerErr == nil && entriesErr == nil {
+				for _, k := range entries.keys {
+					inner.set(k, entries.values[k])
+				}
+				value = inner.raw()
+			}
+		}
+		base.set(key, value)
+	}
+
+	return formatJSON(base.raw())
+}
+
+// MergeJSONHooks merges fragment, a Claude Code settings object holding
+// hooks for one resource ({"hooks": {"<Event>": [{"matcher": ...,
+// "hooks": [{"type": "command", "command": ...}]}]}}), into existing,
+// the current settings file. Matcher groups are matched by matcher and
+// hook commands within a group by command: a command already present is
+// replaced (picking up a new timeout), any other one is appended. Other
+// events, groups, hooks, and settings are left as they are, so re-running
+// is idempotent. Top-level keys other than "hooks" merge as in
+// MergeJSONEntries.
+//
+// Because commands identify hooks, changing a hook's command adds a new
+// entry and leaves the old one in place.
+func MergeJSONHooks(existing, fragment string) (string, error) {
+	base, err := parseJSONObject(existing)
+	if err != nil {
+		return "", NewParseError("", "existing file is not a JSON object", err).
+			WithSuggestions([]string{"fix or remove the file, then rerun"})
+	}
+	frag, err := parseJSONObject(fragment)
+	if err != nil {
+		return "", NewParseError("", "rendered document is not a JSON object", err)
+	}
+
+	for _, key := range frag.keys {
+		value := frag.values[key]
+		if current, ok := base.values[key]; ok && key == "hooks" {
+			merged, mergeErr := mergeHookEvents(current, value)
+			if mergeErr != nil {
+				return "", NewParseError("", "existing hooks are malformed", mergeErr)
+			}
+			value = merged
+		}
+		base.set(key, value)
+	}
+	return formatJSON(base.raw())
+}
+
+// mergeHookEvents merges the fragment's event → groups object into the
+// existing one. A non-object existing value is replaced.
+func mergeHookEvents(current, value json.RawMessage) (json.RawMessage, error) {
+	events, err := parseJSONObject(string(current))
+	if err != nil {
+		return value, nil //nolint:nilerr // a non-object hooks value is replaced, as MergeJSONEntries does
+	}
+	fragEvents, err := parseJSONObject(string(value))
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range fragEvents.keys {
+		var groups, fragGroups []json.RawMessage
+		if existing, ok := events.values[event]; ok {
+			if err := json.Unmarshal(existing, &groups); err != nil {
+				return nil, fmt.Errorf("hooks.%s: %w", event, err)
+			}
+		}
+		if err := json.Unmarshal(fragEvents.values[event], &fragGroups); err != nil {
+			return nil, fmt.Errorf("hooks.%s: %w", event, err)
+		}
+		for _, group := range fragGroups {
+			groups, err = mergeHookGroup(groups, group)
+			if err != nil {
+				return nil, fmt.Errorf("hooks.%s: %w", event, err)
+			}
+		}
+		events.set(event, rawArray(groups))
+	}
+	return events.raw(), nil
+}
+
+// hookGroup is the part of a matcher group MergeJSONHooks inspects.
+type hookGroup struct {
+	Matcher string            `json:"matcher"`
+	Hooks   []json.RawMessage `json:"hooks"`
+}
+
+// hookCommand is the part of a hook MergeJSONHooks inspects.
+type hookCommand struct {
+	Command string `json:"command"`
+}
+
+// mergeHookGroup merges one fragment matcher group into groups.
+func mergeHookGroup(groups []json.RawMessage, group json.RawMessage) ([]json.RawMessage, error) {
+	var frag hookGroup
+	if err := json.Unmarshal(group, &frag); err != nil {
+		return nil, err //nolint:wrapcheck // wrapped by the caller
+	}
+	for i, raw := range groups {
+		var existing hookGroup
+		if err := json.Unmarshal(raw, &existing); err != nil || existing.Matcher != frag.Matcher {
+			continue
+		}
+		obj, err := parseJSONObject(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range frag.Hooks {
+			existing.Hooks = mergeHookCommand(existing.Hooks, hook)
+		}
+		obj.set("hooks", rawArray(existing.Hooks))
+		groups[i] = obj.raw()
+		return groups, nil
+	}
+	return append(groups, group), nil
+}
+
+// mergeHookCommand replaces the hook in hooks that runs the same command
+// as hook, or appends hook.
+func mergeHookCommand(hooks []json.RawMessage, hook json.RawMessage) []json.RawMessage {
+	var want hookCommand
+	_ = json.Unmarshal(hook, &want)
+	for i, raw := range hooks {
+		var have hookCommand
+		if json.Unmarshal(raw, &have) == nil && have.Command == want.Command {
+			hooks[i] = hook
+			return hooks
+		}
+	}
+	return append(hooks, hook)
+}
+
+// MergeJSONSettings merges fragment, the settings rendered for one
+// resource, into existing, the current settings file, structurally:
+//
+//   - objects merge key by key, recursively, and keys missing from
+//     existing are added;
+//   - arrays gain the fragment items they do not already hold, so
+//     permission rule lists accumulate;
+//   - equal values are left alone;
+//   - any other difference is a conflict, e.g. a different model.
+//
+// A Claude Code permission rule the fragment lists under one of
+// permissions.allow, ask, or deny while existing lists it under another
+// is a conflict too.
+//
+// Conflicts are reported together as a *ConfigError and nothing is
+// merged, so hand-edited settings are never silently replaced. With
+// overwrite the fragment's values win instead and a rule is moved to
+// the fragment's list. Key order and formatting follow MergeJSONEntries.
+func MergeJSONSettings(existing, fragment string, overwrite bool) (string, error) {
+	base, err := parseJSONObject(existing)
+	if err != nil {
+		return "", NewParseError("", "existing file is not a JSON object", err).
+			WithSuggestions([]string{"fix or remove the file, then rerun"})
+	}
+	frag, err := parseJSONObject(fragment)
+	if err != nil {
+		return "", NewParseError("", "rendered document is not a JSON object", err)
+	}
+
+	var conflicts []string
+	if current, ok := base.values["permissions"]; ok {
+		if value, ok := frag.values["permissions"]; ok {
+			base.set("permissions", movePermissionRules(current, value, overwrite, &conflicts))
+		}
+	}
+	merged := mergeJSONValue("", base.raw(), frag.raw(), overwrite, &conflicts)
+
+	if len(conflicts) > 0 && !overwrite {
+		return "", NewConfigError("settings", "", "conflicts with existing values: "+strings.Join(conflicts, "; ")).
+			WithSuggestions([]string{
+				"edit the file or the resource so the values agree",
+				"rerun with --force to take the resource's values",
+			})
+	}
+	return formatJSON(merged)
+}
+
+// permissionLists are the Claude Code permission rule lists.
+var permissionLists = []string{"allow", "ask", "deny"}
+
+// movePermissionRules returns current, a permissions object, with every
+// rule of value's lists that current holds under a different list
+// recorded as a conflict; with overwrite the rule is removed from
+// current's list so the merge leaves it only where value puts it.
+// Malformed lists are returned as they are and merged structurally.
+func movePermissionRules(current, value json.RawMessage, overwrite bool, conflicts *[]string) json.RawMessage {
+	have, err := parseJSONObject(string(current))
+	if err != nil {
+		return current
+	}
+	want, err := parseJSONObject(string(value))
+	if err != nil {
+		return current
+	}
+	lists := make(map[string][]string, len(permissionLists))
+	for _, name := range permissionLists {
+		var rules []string
+		if raw, ok := have.values[name]; ok && json.Unmarshal(raw, &rules) == nil {
+			lists[name] = rules
+		}
+	}
+	for _, name := range permissionLists {
+		var rules []string
+		if raw, ok := want.values[name]; !ok || json.Unmarshal(raw, &rules) != nil {
+			continue
+		}
+		for _, rule := range rules {
+			for _, other := range permissionLists {
+				if other == name || !slices.Contains(lists[other], rule) {
+					continue
+				}
+				*conflicts = append(*conflicts, fmt.Sprintf("permissions: file has %q under %s, resource under %s", rule, other, name))
+				if overwrite {
+					lists[other] = slices.DeleteFunc(lists[other], func(r string) bool { return r == rule })
+					have.set(other, stringArray(lists[other]))
+				}
+			}
+		}
+	}
+	return have.raw()
+}
+
+// mergeJSONValue merges value into current at path (dotted, "" for the
+// root) as described by MergeJSONSettings, appending a description of
+// every conflict to conflicts.
+func mergeJSONValue(path string, current, value json.RawMessage, overwrite bool, conflicts *[]string) json.RawMessage {
+	if isJSONKind(current, '{') && isJSONKind(value, '{') {
+		have, errHave := parseJSONObject(string(current))
+		want, errWant := parseJSONObject(string(value))
+		if errHave == nil && errWant == nil {
+			for _, key := range want.keys {
+				next := want.values[key]
+				if existing, ok := have.values[key]; ok {
+					next = mergeJSONValue(joinJSONPath(path, key), existing, next, overwrite, conflicts)
+				}
+				have.set(key, next)
+			}
+			return have.raw()
+		}
+	}
+	if isJSONKind(current, '[') && isJSONKind(value, '[') {
+		var have, want []json.RawMessage
+		if json.Unmarshal(current, &have) == nil && json.Unmarshal(value, &want) == nil {
+			for _, item := range want {
+				if !slices.ContainsFunc(have, func(h json.RawMessage) bool { return jsonEqual(h, item) }) {
+					have = append(have, item)
+				}
+			}
+			return rawArray(have)
+		}
+	}
+	if jsonEqual(current, value) {
+		return current
+	}
+	*conflicts = append(*conflicts, fmt.Sprintf("%s: file has %s, resource sets %s", path, compactJSON(current), compactJSON(value)))
+	if overwrite {
+		return value
+	}
+	return current
+}
+
+// isJSONKind reports whether data is a JSON value starting with delim
+// ('{' for an object, '[' for an array).
+func isJSONKind(data json.RawMessage, delim byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && trimmed[0] == delim
+}
+
+// jsonEqual reports whether a and b encode the same value, ignoring
+// formatting and object key order.
+func jsonEqual(a, b json.RawMessage) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// compactJSON renders data on one line for a conflict message.
+func compactJSON(data json.RawMessage) string {
+	var buf bytes.Buffer
+	if json.Compact(&buf, data) != nil {
+		return string(data)
+	}
+	return buf.String()
+}
+
+// joinJSONPath appends key to a dotted path.
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// stringArray encodes items as a JSON array without HTML escaping.
+func stringArray(items []string) json.RawMessage {
+	raw := make([]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		_ = enc.Encode(item)
+		raw = append(raw, bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	}
+	return rawArray(raw)
+}
+
+// rawArray encodes items compactly as a JSON array. Unlike json.Marshal
+// it leaves the items' bytes alone, so "&&" in a command is not escaped.
+func rawArray(items []json.RawMessage) json.RawMessage {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(item)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes()
+}
+
+// formatJSON indents data with two spaces and appends a newline.
+func formatJSON(data []byte) (string, error) {
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		return "", NewParseError("", "failed to format merged JSON", err)
+	}
+	out.WriteByte('\n')
+	return out.String(), nil
+}
+
+// jsonObject is a JSON object that remembers its key order. Values are
+// kept raw so nested content is not reordered either.
+type jsonObject struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+// parseJSONObject decodes data, which must hold exactly one JSON object.
+// Blank data decodes as an empty object.
+func parseJSONObject(data string) (*jsonObject, error) {
+	obj := &jsonObject{values: make(map[string]json.RawMessage)}
+	if strings.TrimSpace(data) == "" {
+		return obj, nil
+	}
+
+	dec := json.NewDecoder(strings.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // wrapped by the caller's *ParseError
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, errors.New("expected a JSON object")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err //nolint:wrapcheck // wrapped by the caller's *ParseError
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err //nolint:wrapcheck // wrapped by the caller's *ParseError
+		}
+		obj.set(key, value)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err //nolint:wrapcheck // wrapped by the caller's *ParseError
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected content after the JSON object")
+	}
+	return obj, nil
+}
+
+func (o *jsonObject) set(key string, value json.RawMessage) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// raw encodes the object compactly, in key order.
+func (o *jsonObject) raw() json.RawMessage {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(o.values[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
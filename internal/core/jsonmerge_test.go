@@ -0,0 +1,271 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeJSONEntries(t *testing.T) {
+	t.Parallel()
+
+	fragment := `{"mcpServers": {"github": {"command": "npx"}}}`
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name:     "empty file",
+			existing: "",
+			want:     "{\n  \"mcpServers\": {\n    \"github\": {\n      \"command\": \"npx\"\n    }\n  }\n}\n",
+		},
+		{
+			name:     "keeps other settings and entries in order",
+			existing: `{"$schema": "s", "mcpServers": {"docs": {"url": "u"}}, "theme": "dark"}`,
+			want: "{\n  \"$schema\": \"s\",\n  \"mcpServers\": {\n    \"docs\": {\n      \"url\": \"u\"\n    },\n" +
+				"    \"github\": {\n      \"command\": \"npx\"\n    }\n  },\n  \"theme\": \"dark\"\n}\n",
+		},
+		{
+			name:     "replaces an entry of the same name",
+			existing: `{"mcpServers": {"github": {"command": "old", "args": ["x"]}}}`,
+			want:     "{\n  \"mcpServers\": {\n    \"github\": {\n      \"command\": \"npx\"\n    }\n  }\n}\n",
+		},
+		{
+			name:     "replaces a non-object value",
+			existing: `{"mcpServers": null}`,
+			want:     "{\n  \"mcpServers\": {\n    \"github\": {\n      \"command\": \"npx\"\n    }\n  }\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := MergeJSONEntries(tt.existing, fragment)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			again, err := MergeJSONEntries(got, fragment)
+			require.NoError(t, err)
+			assert.Equal(t, got, again, "merge must be idempotent")
+		})
+	}
+}
+
+func TestMergeJSONEntries_RejectsNonObjects(t *testing.T) {
+	t.Parallel()
+
+	for _, existing := range []string{`[1, 2]`, `{"a": 1`, `{"a": 1} {}`, `// comment`} {
+		t.Run(existing, func(t *testing.T) {
+			t.Parallel()
+			_, err := MergeJSONEntries(existing, `{"mcp": {}}`)
+			var parseErr *ParseError
+			require.True(t, errors.As(err, &parseErr), "got %v", err)
+		})
+	}
+}
+
+func TestMergeJSONHooks(t *testing.T) {
+	t.Parallel()
+
+	lint := `{"hooks": {"PostToolUse": [{"matcher": "Edit", "hooks": [{"type": "command", "command": "lint && fmt", "timeout": 30}]}]}}`
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name:     "empty file",
+			existing: "",
+			want: `{
+  "hooks": {
+    "PostToolUse": [
+      {
+        "matcher": "Edit",
+        "hooks": [
+          {
+            "type": "command",
+            "command": "lint && fmt",
+            "timeout": 30
+          }
+        ]
+      }
+    ]
+  }
+}
+`,
+		},
+		{
+			name:     "appends to the group with the same matcher",
+			existing: `{"model": "x", "hooks": {"PostToolUse": [{"matcher": "Edit", "hooks": [{"type": "command", "command": "mine"}]}]}}`,
+			want: `{
+  "model": "x",
+  "hooks": {
+    "PostToolUse": [
+      {
+        "matcher": "Edit",
+        "hooks": [
+          {
+            "type": "command",
+            "command": "mine"
+          },
+          {
+            "type": "command",
+            "command": "lint && fmt",
+            "timeout": 30
+          }
+        ]
+      }
+    ]
+  }
+}
+`,
+		},
+		{
+			name:     "replaces the same command and keeps other events",
+			existing: `{"hooks": {"Stop": [{"hooks": [{"type": "command", "command": "say"}]}], "PostToolUse": [{"matcher": "Edit", "hooks": [{"type": "command", "command": "lint && fmt", "timeout": 5}]}]}}`,
+			want: `{
+  "hooks": {
+    "Stop": [
+      {
+        "hooks": [
+          {
+            "type": "command",
+            "command": "say"
+          }
+        ]
+      }
+    ],
+    "PostToolUse": [
+      {
+        "matcher": "Edit",
+        "hooks": [
+          {
+            "type": "command",
+            "command": "lint && fmt",
+            "timeout": 30
+          }
+        ]
+      }
+    ]
+  }
+}
+`,
+		},
+		{
+			name:     "adds a group for another matcher",
+			existing: `{"hooks": {"PostToolUse": [{"matcher": "Bash", "hooks": []}]}}`,
+			want: `{
+  "hooks": {
+    "PostToolUse": [
+      {
+        "matcher": "Bash",
+        "hooks": []
+      },
+      {
+        "matcher": "Edit",
+        "hooks": [
+          {
+            "type": "command",
+            "command": "lint && fmt",
+            "timeout": 30
+          }
+        ]
+      }
+    ]
+  }
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := MergeJSON(JSONMergeHooks, tt.existing, lint, false)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			again, err := MergeJSONHooks(got, lint)
+			require.NoError(t, err)
+			assert.Equal(t, got, again, "merge must be idempotent")
+		})
+	}
+}
+
+func TestMergeJSONHooks_MalformedHooks(t *testing.T) {
+	t.Parallel()
+
+	_, err := MergeJSONHooks(`{"hooks": {"Stop": {"not": "a list"}}}`, `{"hooks": {"Stop": [{"hooks": []}]}}`)
+	var parseErr *ParseError
+	require.ErrorAs(t, err, &parseErr)
+}
+
+func TestMergeJSONSettings(t *testing.T) {
+	t.Parallel()
+
+	fragment := `{"model": "sonnet", "permissions": {"allow": ["Bash(npm test)"], "defaultMode": "acceptEdits"}, "env": {"CI": "1"}}`
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name:     "empty file",
+			existing: "",
+			want:     `{"model":"sonnet","permissions":{"allow":["Bash(npm test)"],"defaultMode":"acceptEdits"},"env":{"CI":"1"}}`,
+		},
+		{
+			name:     "adds missing keys and list entries in place",
+			existing: `{"permissions": {"allow": ["Read(docs/**)"], "deny": ["Bash(rm:*)"]}, "theme": "dark"}`,
+			want: `{"permissions":{"allow":["Read(docs/**)","Bash(npm test)"],"deny":["Bash(rm:*)"],"defaultMode":"acceptEdits"},` +
+				`"theme":"dark","model":"sonnet","env":{"CI":"1"}}`,
+		},
+		{
+			name:     "equal values are not conflicts",
+			existing: `{"env": {"CI": "1", "GOFLAGS": "-mod=mod"}, "model": "sonnet"}`,
+			want: `{"env":{"CI":"1","GOFLAGS":"-mod=mod"},"model":"sonnet",` +
+				`"permissions":{"allow":["Bash(npm test)"],"defaultMode":"acceptEdits"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := MergeJSON(JSONMergeSettings, tt.existing, fragment, false)
+			require.NoError(t, err)
+			want, err := formatJSON([]byte(tt.want))
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+
+			again, err := MergeJSONSettings(got, fragment, false)
+			require.NoError(t, err)
+			assert.Equal(t, got, again, "merge must be idempotent")
+		})
+	}
+}
+
+func TestMergeJSONSettings_Conflicts(t *testing.T) {
+	t.Parallel()
+
+	existing := `{"model": "opus", "permissions": {"deny": ["Bash(npm test)", "Bash(rm:*)"]}, "env": {"CI": "0"}}`
+	fragment := `{"model": "sonnet", "permissions": {"allow": ["Bash(npm test)"]}, "env": {"CI": "1"}}`
+
+	_, err := MergeJSONSettings(existing, fragment, false)
+	var cfgErr *ConfigError
+	require.ErrorAs(t, err, &cfgErr)
+	assert.Contains(t, cfgErr.Message(), `permissions: file has "Bash(npm test)" under deny, resource under allow`)
+	assert.Contains(t, cfgErr.Message(), `model: file has "opus", resource sets "sonnet"`)
+	assert.Contains(t, cfgErr.Message(), `env.CI: file has "0", resource sets "1"`)
+
+	got, err := MergeJSONSettings(existing, fragment, true)
+	require.NoError(t, err)
+	want, err := formatJSON([]byte(`{"model":"sonnet","permissions":{"deny":["Bash(rm:*)"],"allow":["Bash(npm test)"]},"env":{"CI":"1"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, want, got, "overwrite takes the fragment's values and moves the rule")
+}
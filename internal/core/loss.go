@@ -0,0 +1,216 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnsupportedField declares a canonical field that a platform cannot
+// represent for one document type. Field is the dotted YAML path of the
+// canonical field ("tools", "behavior.steps"); a struct-valued path
+// ("behavior") covers every field beneath it.
+type UnsupportedField struct {
+	Field  string
+	Reason string
+}
+
+// FieldLoss records a canonical field that was set on a document but is
+// not carried over when the document is rendered for a platform.
+type FieldLoss struct {
+	// Field is the dotted YAML path of the canonical field.
+	Field string `json:"field"`
+	// Value is the source value rendered for display.
+	Value string `json:"value"`
+	// Reason explains why the target platform drops the field.
+	Reason string `json:"reason"`
+}
+
+// DetectLosses returns a FieldLoss for every field set on doc (a
+// *Agent, *Command, *Skill, or *Memory) that platform cannot represent:
+// fields covered by unsupported, and platform-specific settings under
+// targets.<other-platform>, which only apply to that other platform.
+// Losses are returned in canonical field order.
+func DetectLosses(doc any, platform string, unsupported []UnsupportedField) []FieldLoss {
+	var losses []FieldLoss
+	for _, f := range setFields(doc) {
+		if reason, ok := unsupportedReason(f.path, platform, unsupported); ok {
+			losses = append(losses, FieldLoss{Field: f.path, Value: f.value, Reason: reason})
+		}
+	}
+	return losses
+}
+
+// CheckLosses returns nil when losses is empty and otherwise a
+// *TransformError naming every dropped field. It backs --strict, which
+// refuses to write a rendering that loses information.
+func CheckLosses(platform string, losses []FieldLoss) error {
+	if len(losses) == 0 {
+		return nil
+	}
+	fields := make([]string, 0, len(losses))
+	for _, l := range losses {
+		fields = append(fields, l.Field)
+	}
+	return NewTransformError("strict", platform,
+		fmt.Sprintf("%d field(s) would be dropped: %s", len(losses), strings.Join(fields, ", ")), nil).
+		WithSuggestions([]string{
+			"remove the fields from the source document",
+			"rerun without --strict to accept the loss",
+		})
+}
+
+func unsupportedReason(field, platform string, unsupported []UnsupportedField) (string, bool) {
+	if rest, ok := strings.CutPrefix(field, "targets."); ok {
+		owner, _, _ := strings.Cut(rest, ".")
+		if owner != platform {
+			return "settings for " + owner + " do not apply to " + platform, true
+		}
+		return "", false
+	}
+	for _, u := range unsupported {
+		if field == u.Field || strings.HasPrefix(field, u.Field+".") {
+			if u.Reason != "" {
+				return u.Reason, true
+			}
+			return platform + " has no equivalent field", true
+		}
+	}
+	return "", false
+}
+
+// setField is one non-zero leaf of a canonical document.
+type setField struct {
+	path  string
+	value string
+}
+
+// setFields flattens the non-zero leaves of a canonical model into
+// dotted YAML paths. Fields tagged yaml:"-" (Content, FilePath) are
+// skipped; PlatformConfig entries expand to targets.<platform>.<key>
+// and PermissionRules entries to permissions.<tool>.
+func setFields(doc any) []setField {
+	v := reflect.ValueOf(doc)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	var out []setField
+	collectFields(v, "", &out)
+	return out
+}
+
+func collectFields(v reflect.Value, prefix string, out *[]setField) {
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		path := prefix + name
+		fv := v.Field(i)
+		if fv.IsZero() {
+			continue
+		}
+
+		switch {
+		case fv.Kind() == reflect.Struct:
+			collectFields(fv, path+".", out)
+		case fv.Type() == reflect.TypeFor[PlatformConfig]():
+			collectTargets(fv.Interface().(PlatformConfig), path, out) //nolint:forcetypeassert // type checked above
+		case fv.Kind() == reflect.Map && fv.Type().Elem().Kind() == reflect.Map:
+			collectNested(fv, path, out)
+		default:
+			*out = append(*out, setField{path: path, value: formatFieldValue(fv)})
+		}
+	}
+}
+
+func collectTargets(targets PlatformConfig, path string, out *[]setField) {
+	for _, platform := range sortedKeys(targets) {
+		settings := targets[platform]
+		for _, key := range sortedKeys(settings) {
+			if v := reflect.ValueOf(settings[key]); !v.IsValid() || v.IsZero() {
+				continue
+			}
+			*out = append(*out, setField{
+				path:  path + "." + platform + "." + key,
+				value: formatFieldValue(reflect.ValueOf(settings[key])),
+			})
+		}
+	}
+}
+
+// collectNested expands a map of maps (PermissionRules) one level, so
+// each tool is reported as its own field.
+func collectNested(v reflect.Value, path string, out *[]setField) {
+	keys := make([]string, 0, v.Len())
+	byKey := make(map[string]reflect.Value, v.Len())
+	for _, k := range v.MapKeys() {
+		ks := fmt.Sprint(k.Interface())
+		keys = append(keys, ks)
+		byKey[ks] = v.MapIndex(k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		*out = append(*out, setField{path: path + "." + k, value: formatFieldValue(byKey[k])})
+	}
+}
+
+// formatFieldValue renders a field value for a loss report: lists are
+// comma-joined, maps render as sorted key=value pairs, and pointers are
+// dereferenced.
+func formatFieldValue(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() { //nolint:exhaustive // remaining kinds use the default formatting
+	case reflect.Slice, reflect.Array:
+		items := make([]string, 0, v.Len())
+		for i := range v.Len() {
+			items = append(items, formatFieldValue(v.Index(i)))
+		}
+		return strings.Join(items, ", ")
+	case reflect.Map:
+		keys := make([]string, 0, v.Len())
+		values := make(map[string]string, v.Len())
+		for _, k := range v.MapKeys() {
+			ks := fmt.Sprint(k.Interface())
+			keys = append(keys, ks)
+			values[ks] = formatFieldValue(v.MapIndex(k))
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			pairs = append(pairs, k+"="+values[k])
+		}
+		return strings.Join(pairs, ", ")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
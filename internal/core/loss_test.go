@@ -0,0 +1,91 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectLosses(t *testing.T) {
+	t.Parallel()
+
+	temperature := 0.2
+	agent := &Agent{
+		Name:        "reviewer",
+		Description: "Reviews code",
+		Content:     "body is never reported",
+		Tools:       []string{"read", "grep"},
+		Behavior:    AgentBehavior{Steps: 5, Temperature: &temperature},
+		Extensions:  AgentExtensions{Hooks: map[string]string{"pre": "lint", "post": "test"}},
+		Targets: PlatformConfig{
+			"claude-code": {"skills": []interface{}{"commit", "review"}},
+			"opencode":    {"share": "manual"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		platform    string
+		unsupported []UnsupportedField
+		want        []FieldLoss
+	}{
+		{
+			name:     "nothing unsupported still drops other platform targets",
+			platform: "opencode",
+			want: []FieldLoss{
+				{Field: "targets.claude-code.skills", Value: "commit, review", Reason: "settings for claude-code do not apply to opencode"},
+			},
+		},
+		{
+			name:        "struct prefix covers set leaves only",
+			platform:    "claude-code",
+			unsupported: []UnsupportedField{{Field: "behavior", Reason: "no behavior settings"}},
+			want: []FieldLoss{
+				{Field: "behavior.temperature", Value: "0.2", Reason: "no behavior settings"},
+				{Field: "behavior.steps", Value: "5", Reason: "no behavior settings"},
+				{Field: "targets.opencode.share", Value: "manual", Reason: "settings for opencode do not apply to claude-code"},
+			},
+		},
+		{
+			name:        "default reason and value formatting",
+			platform:    "copilot",
+			unsupported: []UnsupportedField{{Field: "extensions.hooks"}, {Field: "model"}},
+			want: []FieldLoss{
+				{Field: "targets.claude-code.skills", Value: "commit, review", Reason: "settings for claude-code do not apply to copilot"},
+				{Field: "targets.opencode.share", Value: "manual", Reason: "settings for opencode do not apply to copilot"},
+				{Field: "extensions.hooks", Value: "post=test, pre=lint", Reason: "copilot has no equivalent field"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, DetectLosses(agent, tt.platform, tt.unsupported))
+		})
+	}
+}
+
+func TestDetectLosses_UnsetFieldsAreNotLost(t *testing.T) {
+	t.Parallel()
+
+	cmd := &Command{Name: "review", Description: "Review"}
+	assert.Empty(t, DetectLosses(cmd, "gemini", []UnsupportedField{{Field: "tools"}, {Field: "execution"}}))
+	assert.Empty(t, DetectLosses(nil, "gemini", nil))
+
+	cmd.Targets = PlatformConfig{"claude-code": {"disable-model-invocation": false}}
+	assert.Empty(t, DetectLosses(cmd, "opencode", nil), "zero-valued target settings are unset")
+}
+
+func TestCheckLosses(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, CheckLosses("opencode", nil))
+
+	err := CheckLosses("opencode", []FieldLoss{{Field: "tools"}, {Field: "arguments.hint"}})
+	var transformErr *TransformError
+	require.ErrorAs(t, err, &transformErr)
+	assert.Contains(t, err.Error(), "2 field(s) would be dropped: tools, arguments.hint")
+	assert.NotEmpty(t, transformErr.Suggestions())
+}
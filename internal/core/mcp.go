@@ -0,0 +1,28 @@
+package core
+
+// MCPServer represents a Model Context Protocol server definition. A
+// server is either local, started from Command with Args and Env, or
+// remote, reached at URL with Headers.
+type MCPServer struct {
+	Name     string `yaml:"name" json:"name"`
+	FilePath string `yaml:"-" json:"-"`
+
+	Command string            `yaml:"command,omitempty" json:"command,omitempty"`
+	Args    []string          `yaml:"args,omitempty" json:"args,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+	URL     string            `yaml:"url,omitempty" json:"url,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	Enabled *bool             `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+}
+
+// IsRemote reports whether the server is reached over HTTP rather than
+// started as a local process.
+func (s *MCPServer) IsRemote() bool {
+	return s.URL != ""
+}
+
+// IsEnabled reports whether the server is enabled. Servers are enabled
+// unless Enabled is explicitly false.
+func (s *MCPServer) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
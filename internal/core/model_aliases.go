@@ -0,0 +1,148 @@
+package core
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ModelAliases maps logical model names, written in canonical documents
+// (model: smart), to the model id each platform knows them by:
+//
+//	aliases["smart"]["claude-code"] = "opus"
+//	aliases["smart"]["opencode"] = "anthropic/claude-opus-4-1"
+//
+// Rendering replaces an alias with the platform's id (Resolve); parsing
+// a platform document replaces a known id with its alias (Canonical). A
+// model that is not an alias passes through verbatim.
+type ModelAliases map[string]map[string]string
+
+// DefaultModelAliases returns the built-in aliases: the Claude Code
+// model names sonnet, opus, and haiku, with their OpenCode and Copilot
+// equivalents. config.toml and library.yaml add to and override them
+// (Merge).
+//
+// Cursor, Gemini, and Codex have no entries: none of their document
+// formats carries a model (the adapters list it as an unsupported
+// field), so a model never needs resolving for them.
+func DefaultModelAliases() ModelAliases {
+	return ModelAliases{
+		"sonnet": {
+			PlatformClaudeCode: "sonnet",
+			PlatformOpenCode:   "anthropic/claude-sonnet-4-5",
+			PlatformCopilot:    "Claude Sonnet 4.5",
+		},
+		"opus": {
+			PlatformClaudeCode: "opus",
+			PlatformOpenCode:   "anthropic/claude-opus-4-1",
+			PlatformCopilot:    "Claude Opus 4.1",
+		},
+		"haiku": {
+			PlatformClaudeCode: "haiku",
+			PlatformOpenCode:   "anthropic/claude-haiku-4-5",
+			PlatformCopilot:    "Claude Haiku 4.5",
+		},
+	}
+}
+
+// Merge returns the aliases of m overlaid with over: an alias both
+// define keeps m's platform ids unless over sets the same platform.
+// Neither input is modified.
+func (m ModelAliases) Merge(over ModelAliases) ModelAliases {
+	merged := make(ModelAliases, len(m)+len(over))
+	for _, aliases := range []ModelAliases{m, over} {
+		for alias, ids := range aliases {
+			if merged[alias] == nil {
+				merged[alias] = make(map[string]string, len(ids))
+			}
+			maps.Copy(merged[alias], ids)
+		}
+	}
+	return merged
+}
+
+// Resolve returns the id platform knows model by: its mapping when
+// model is an alias mapped for platform, else model unchanged.
+func (m ModelAliases) Resolve(model, platform string) string {
+	if id, ok := m[model][platform]; ok && id != "" {
+		return id
+	}
+	return model
+}
+
+// Canonical returns the canonical name for id, a model read from a
+// platform document: id itself when it is an alias name, else the one
+// alias mapping to id on platform. An id no alias maps to, or more than
+// one does, is returned unchanged.
+func (m ModelAliases) Canonical(id, platform string) string {
+	if _, ok := m[id]; ok || id == "" {
+		return id
+	}
+	var found []string
+	for alias, ids := range m {
+		if ids[platform] == id {
+			found = append(found, alias)
+		}
+	}
+	if len(found) != 1 {
+		return id
+	}
+	return found[0]
+}
+
+// Check reports a model a document cannot be rendered with for
+// platform: an alias without an id for the platform, or a name that is
+// neither an alias nor a platform id. isID reports whether a name is
+// one of the platform's own model ids; ids some alias maps on platform
+// are accepted too. A nil isID accepts every non-alias name. The error
+// is a *ValidationError on the model field.
+func (m ModelAliases) Check(model, platform string, isID func(string) bool) error {
+	if model == "" {
+		return nil
+	}
+	if ids, ok := m[model]; ok {
+		if ids[platform] != "" {
+			return nil
+		}
+		return NewValidationError("", "model", model, "model alias "+model+" has no "+platform+" model").
+			WithSuggestions([]string{
+				"map it in config.toml: [models." + model + "] " + platform + " = \"<model>\"",
+				"or under models: in library.yaml",
+			})
+	}
+	if isID == nil || isID(model) {
+		return nil
+	}
+	var aliases []string
+	for alias, ids := range m {
+		if ids[platform] == model {
+			return nil
+		}
+		if ids[platform] != "" {
+			aliases = append(aliases, alias)
+		}
+	}
+	slices.Sort(aliases)
+	suggestions := []string{"define it as an alias under [models." + model + "] in config.toml or models: in library.yaml"}
+	if len(aliases) > 0 {
+		suggestions = append([]string{"use one of the aliases: " + strings.Join(aliases, ", ")}, suggestions...)
+	}
+	return NewValidationError("", "model", model, "unknown model "+model+": not a model alias or a model id for "+platform).
+		WithSuggestions(suggestions)
+}
+
+// Validate checks that every alias maps only known platforms to
+// non-empty ids. known lists the valid platform ids.
+func (m ModelAliases) Validate(known []string) error {
+	for _, alias := range slices.Sorted(maps.Keys(m)) {
+		for _, platform := range slices.Sorted(maps.Keys(m[alias])) {
+			if !slices.Contains(known, platform) {
+				return NewConfigError("models."+alias, platform, "unknown platform").WithSuggestions(known)
+			}
+			if strings.TrimSpace(m[alias][platform]) == "" {
+				return NewConfigError("models."+alias+"."+platform, "", "model id must not be empty")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelAliases_ResolveCanonical(t *testing.T) {
+	t.Parallel()
+
+	aliases := DefaultModelAliases().Merge(ModelAliases{
+		"smart": {PlatformClaudeCode: "opus", PlatformOpenCode: "anthropic/claude-opus-4-1"},
+		"fast":  {PlatformOpenCode: "openai/gpt-5-mini"},
+	})
+
+	tests := []struct {
+		name          string
+		model         string
+		platform      string
+		wantResolved  string
+		wantCanonical string
+	}{
+		{
+			name:          "built-in alias",
+			model:         "sonnet",
+			platform:      PlatformOpenCode,
+			wantResolved:  "anthropic/claude-sonnet-4-5",
+			wantCanonical: "sonnet",
+		},
+		{
+			name:          "alias is a platform id",
+			model:         "sonnet",
+			platform:      PlatformClaudeCode,
+			wantResolved:  "sonnet",
+			wantCanonical: "sonnet",
+		},
+		{
+			name:          "configured alias",
+			model:         "fast",
+			platform:      PlatformOpenCode,
+			wantResolved:  "openai/gpt-5-mini",
+			wantCanonical: "fast",
+		},
+		{
+			name:          "alias not mapped for platform",
+			model:         "fast",
+			platform:      PlatformClaudeCode,
+			wantResolved:  "fast",
+			wantCanonical: "fast",
+		},
+		{
+			name:          "platform id",
+			model:         "claude-sonnet-4-5-20250929",
+			platform:      PlatformClaudeCode,
+			wantResolved:  "claude-sonnet-4-5-20250929",
+			wantCanonical: "claude-sonnet-4-5-20250929",
+		},
+		{
+			name:          "empty",
+			platform:      PlatformOpenCode,
+			wantResolved:  "",
+			wantCanonical: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.wantResolved, aliases.Resolve(tt.model, tt.platform))
+			assert.Equal(t, tt.wantCanonical, aliases.Canonical(aliases.Resolve(tt.model, tt.platform), tt.platform))
+		})
+	}
+}
+
+func TestModelAliases_CanonicalAmbiguous(t *testing.T) {
+	t.Parallel()
+
+	aliases := DefaultModelAliases().Merge(ModelAliases{
+		"smart": {PlatformOpenCode: "anthropic/claude-opus-4-1"},
+	})
+
+	assert.Equal(t, "anthropic/claude-opus-4-1", aliases.Canonical("anthropic/claude-opus-4-1", PlatformOpenCode))
+	assert.Equal(t, "sonnet", aliases.Canonical("anthropic/claude-sonnet-4-5", PlatformOpenCode))
+}
+
+func TestModelAliases_Merge(t *testing.T) {
+	t.Parallel()
+
+	base := ModelAliases{"smart": {PlatformClaudeCode: "opus", PlatformOpenCode: "anthropic/claude-opus-4-1"}}
+	merged := base.Merge(ModelAliases{"smart": {PlatformOpenCode: "openai/gpt-5"}})
+
+	assert.Equal(t, ModelAliases{"smart": {PlatformClaudeCode: "opus", PlatformOpenCode: "openai/gpt-5"}}, merged)
+	assert.Equal(t, "anthropic/claude-opus-4-1", base["smart"][PlatformOpenCode], "inputs must not be modified")
+}
+
+func TestModelAliases_Check(t *testing.T) {
+	t.Parallel()
+
+	aliases := DefaultModelAliases().Merge(ModelAliases{"fast": {PlatformOpenCode: "openai/gpt-5-mini"}})
+	isClaudeID := func(model string) bool { return strings.HasPrefix(model, "claude-") }
+
+	require.NoError(t, aliases.Check("", PlatformClaudeCode, isClaudeID))
+	require.NoError(t, aliases.Check("fast", PlatformOpenCode, nil))
+	require.NoError(t, aliases.Check("claude-sonnet-4-5-20250929", PlatformClaudeCode, isClaudeID))
+	require.NoError(t, aliases.Check("Claude Opus 4.1", PlatformCopilot, func(string) bool { return false }),
+		"an id an alias maps on the platform is known")
+	require.NoError(t, aliases.Check("smartest", PlatformClaudeCode, nil), "nil isID accepts any name")
+
+	err := aliases.Check("fast", PlatformClaudeCode, isClaudeID)
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "model", verr.Field())
+	assert.Equal(t, "fast", verr.Value())
+	assert.Contains(t, verr.Error(), "has no claude-code model")
+
+	err = aliases.Check("smartest", PlatformClaudeCode, isClaudeID)
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "smartest", verr.Value())
+	assert.Contains(t, verr.Error(), "not a model alias or a model id for claude-code")
+	assert.Contains(t, verr.Suggestions()[0], "haiku, opus, sonnet")
+}
+
+func TestModelAliases_Validate(t *testing.T) {
+	t.Parallel()
+
+	known := []string{PlatformClaudeCode, PlatformOpenCode}
+
+	require.NoError(t, DefaultModelAliases().Merge(nil).Validate(append(known, PlatformCopilot)))
+
+	var cerr *ConfigError
+	err := ModelAliases{"smart": {"claude": "opus"}}.Validate(known)
+	require.ErrorAs(t, err, &cerr)
+	assert.Contains(t, err.Error(), "unknown platform")
+
+	err = ModelAliases{"smart": {PlatformOpenCode: " "}}.Validate(known)
+	require.ErrorAs(t, err, &cerr)
+	assert.Contains(t, err.Error(), "must not be empty")
+}
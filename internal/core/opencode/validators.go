@@ -3,6 +3,7 @@ package opencode
 
 import (
 	"fmt"
+	"strings"
 
 	"gitlab.com/amoconst/germinator/internal/core"
 )
@@ -56,22 +57,107 @@ func ValidateAgentTemperature(a *core.Agent) core.Result[bool] {
 	return core.NewResult(true)
 }
 
+// ValidateAgentModel validates that the agent's model, once model
+// aliases are resolved, uses OpenCode's provider/model form.
+func ValidateAgentModel(a *core.Agent) core.Result[bool] {
+	return validateModel("Agent", a.Model)
+}
+
 // ValidateAgentOpenCode composes all OpenCode-specific agent validators.
 func ValidateAgentOpenCode(a *core.Agent) core.Result[bool] {
 	return core.NewValidationPipeline(
 		ValidateAgentMode,
 		ValidateAgentTemperature,
+		ValidateAgentModel,
 	).Validate(a)
 }
 
-// ValidateCommandOpenCode validates OpenCode-specific command constraints.
-// Currently no OpenCode-specific validation for commands beyond generic rules.
-func ValidateCommandOpenCode(_ *core.Command) core.Result[bool] {
+// ValidateCommandOpenCode validates OpenCode-specific command constraints:
+// the model must use the provider/model form.
+func ValidateCommandOpenCode(c *core.Command) core.Result[bool] {
+	return validateModel("Command", c.Model)
+}
+
+// ValidateSkillOpenCode validates OpenCode-specific skill constraints:
+// the model must use the provider/model form.
+func ValidateSkillOpenCode(s *core.Skill) core.Result[bool] {
+	return validateModel("Skill", s.Model)
+}
+
+// hookEvents maps the canonical hook events an OpenCode plugin can
+// observe to the plugin hook or bus event that fires for them.
+var hookEvents = map[string]string{
+	"PreToolUse":   "tool.execute.before",
+	"PostToolUse":  "tool.execute.after",
+	"Stop":         "session.idle",
+	"SessionStart": "session.created",
+}
+
+// HookEvent returns the OpenCode plugin event for a canonical hook
+// event, and whether OpenCode has one. Tool events ("tool.*") are plugin
+// hooks; the others are bus events delivered to the plugin's event hook.
+func HookEvent(event string) (string, bool) {
+	e, ok := hookEvents[event]
+	return e, ok
+}
+
+// ValidateHookEvent validates that OpenCode can observe the hook's event
+// and, for non-tool events, that no matcher is set.
+func ValidateHookEvent(h *core.Hook) core.Result[bool] {
+	event, ok := HookEvent(h.Event)
+	if !ok {
+		return core.NewErrorResult[bool](
+			core.NewValidationError("Hook", "event", h.Event, "event has no OpenCode plugin equivalent").
+				WithSuggestions([]string{"use one of: PreToolUse, PostToolUse, Stop, SessionStart"}),
+		)
+	}
+	if h.Matcher != "" && !strings.HasPrefix(event, "tool.") {
+		return core.NewErrorResult[bool](
+			core.NewValidationError("Hook", "matcher", h.Matcher, "matcher applies only to PreToolUse and PostToolUse on OpenCode"),
+		)
+	}
 	return core.NewResult(true)
 }
 
-// ValidateSkillOpenCode validates OpenCode-specific skill constraints.
-// Currently no OpenCode-specific validation for skills beyond generic rules.
-func ValidateSkillOpenCode(_ *core.Skill) core.Result[bool] {
+// ValidateHookOpenCode composes all OpenCode-specific hook validators.
+func ValidateHookOpenCode(h *core.Hook) core.Result[bool] {
+	return core.NewValidationPipeline(
+		ValidateHookEvent,
+	).Validate(h)
+}
+
+// ValidateSettingsModel validates that the model uses OpenCode's
+// provider/model form, which opencode.json requires.
+func ValidateSettingsModel(s *core.Settings) core.Result[bool] {
+	return validateModel("Settings", s.Model)
+}
+
+// IsModelID reports whether model is written in OpenCode's
+// provider/model form.
+func IsModelID(model string) bool {
+	provider, name, ok := strings.Cut(model, "/")
+	return ok && provider != "" && name != ""
+}
+
+// validateModel reports a non-empty model that is not written as
+// provider/model.
+func validateModel(request, model string) core.Result[bool] {
+	if model == "" {
+		return core.NewResult(true)
+	}
+	if !IsModelID(model) {
+		return core.NewErrorResult[bool](
+			core.NewValidationError(request, "model", model, "OpenCode models must be written as provider/model").
+				WithSuggestions([]string{"use e.g. anthropic/claude-sonnet-4-5, or a model alias mapped for opencode"}),
+		)
+	}
 	return core.NewResult(true)
 }
+
+// ValidateSettingsOpenCode composes all OpenCode-specific settings
+// validators.
+func ValidateSettingsOpenCode(s *core.Settings) core.Result[bool] {
+	return core.NewValidationPipeline(
+		ValidateSettingsModel,
+	).Validate(s)
+}
@@ -224,3 +224,81 @@ func TestOpenCodePipelineComposition(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateHookOpenCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		hook        *core.Hook
+		expectError bool
+	}{
+		{
+			name: "tool event with matcher passes",
+			hook: &core.Hook{Event: "PreToolUse", Matcher: "Bash"},
+		},
+		{
+			name: "session event passes",
+			hook: &core.Hook{Event: "Stop"},
+		},
+		{
+			name:        "event without plugin equivalent fails",
+			hook:        &core.Hook{Event: "Notification"},
+			expectError: true,
+		},
+		{
+			name:        "matcher on session event fails",
+			hook:        &core.Hook{Event: "SessionStart", Matcher: "startup"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateHookOpenCode(tt.hook)
+			if tt.expectError && result.IsSuccess() {
+				t.Error("expected error but got success")
+			}
+			if !tt.expectError && result.IsError() {
+				t.Errorf("expected success but got error: %v", result.Error)
+			}
+		})
+	}
+}
+
+func TestValidateSettingsOpenCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		settings    *core.Settings
+		expectError bool
+	}{
+		{
+			name:     "provider/model passes",
+			settings: &core.Settings{Model: "anthropic/claude-sonnet-4-5"},
+		},
+		{
+			name:     "no model passes",
+			settings: &core.Settings{Env: map[string]string{"CI": "1"}},
+		},
+		{
+			name:        "bare model fails",
+			settings:    &core.Settings{Model: "sonnet"},
+			expectError: true,
+		},
+		{
+			name:        "empty provider fails",
+			settings:    &core.Settings{Model: "/sonnet"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateSettingsOpenCode(tt.settings)
+			if tt.expectError && result.IsSuccess() {
+				t.Error("expected error but got success")
+			}
+			if !tt.expectError && result.IsError() {
+				t.Errorf("expected success but got error: %v", result.Error)
+			}
+		})
+	}
+}
@@ -27,5 +27,58 @@ func (p PermissionPolicy) IsValid() bool {
 	}
 }
 
+// PermissionAction is the outcome of a permission rule.
+type PermissionAction string
+
+const (
+	// PermissionAllow lets the tool run without confirmation.
+	PermissionAllow PermissionAction = "allow"
+	// PermissionAsk requires user confirmation before the tool runs.
+	PermissionAsk PermissionAction = "ask"
+	// PermissionDeny prevents the tool from running.
+	PermissionDeny PermissionAction = "deny"
+)
+
+// IsValid returns true if the permission action is a valid enum value.
+func (a PermissionAction) IsValid() bool {
+	switch a {
+	case PermissionAllow, PermissionAsk, PermissionDeny:
+		return true
+	default:
+		return false
+	}
+}
+
+// PermissionRules holds fine-grained tool permissions, keyed by canonical
+// tool name and then by specifier:
+//
+//	bash:
+//	  "*": ask
+//	  "git push*": deny
+//	edit:
+//	  "docs/**": allow
+//
+// The specifier "*" covers every use of the tool; any other specifier
+// narrows the rule to matching uses (a command prefix for bash, a path
+// glob for file tools). A trailing "*" marks a prefix match.
+type PermissionRules map[string]map[string]PermissionAction
+
+// Merge returns a new rule set holding r with over layered on top: for
+// the same tool and specifier, the action from over wins.
+func (r PermissionRules) Merge(over PermissionRules) PermissionRules {
+	merged := make(PermissionRules, len(r)+len(over))
+	for _, rules := range []PermissionRules{r, over} {
+		for tool, specs := range rules {
+			if merged[tool] == nil {
+				merged[tool] = make(map[string]PermissionAction, len(specs))
+			}
+			for spec, action := range specs {
+				merged[tool][spec] = action
+			}
+		}
+	}
+	return merged
+}
+
 // PlatformConfig maps platform names to platform-specific configuration.
 type PlatformConfig map[string]map[string]interface{}
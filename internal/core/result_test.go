@@ -115,6 +115,7 @@ func TestInitializeResult_StructShape(t *testing.T) {
 		"Ref":        true,
 		"InputPath":  true,
 		"OutputPath": true,
+		"Dropped":    true,
 		"Error":      true,
 	}
 
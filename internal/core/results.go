@@ -4,6 +4,16 @@ package core
 type TransformResult struct {
 	// OutputPath is the path where the transformed document was written.
 	OutputPath string
+	// Dropped lists the source fields the target platform cannot represent.
+	Dropped []FieldLoss
+	// Warnings are the *ParseError warnings about frontmatter keys the
+	// document type has no field for.
+	Warnings []error
+	// DocType is the type the document was read as.
+	DocType string
+	// DetectedBy names the rule that detected DocType; empty when the
+	// type was given.
+	DetectedBy string
 }
 
 // ValidateResult contains the result of document validation.
@@ -11,6 +21,14 @@ type ValidateResult struct {
 	// Errors contains any validation errors found.
 	// These are business-level validation issues, not fatal errors.
 	Errors []error
+	// Warnings are the *ParseError warnings about frontmatter keys the
+	// document type has no field for; they do not make it invalid.
+	Warnings []error
+	// DocType is the type the document was read as.
+	DocType string
+	// DetectedBy names the rule that detected DocType; empty when the
+	// type was given.
+	DetectedBy string
 }
 
 // Valid returns true if no validation errors were found.
@@ -32,6 +50,56 @@ type InitializeResult struct {
 	InputPath string
 	// OutputPath is the destination file path.
 	OutputPath string
+	// Dropped lists the resource fields the target platform cannot represent.
+	Dropped []FieldLoss
 	// Error is any error that occurred during initialization.
 	Error error
 }
+
+// ConvertResult contains the result of a platform-to-platform conversion.
+type ConvertResult struct {
+	// Documents holds one entry per converted document, in discovery
+	// order. File mode produces exactly one.
+	Documents []ConvertedDocument
+}
+
+// ConvertedDocument describes the conversion of one document.
+type ConvertedDocument struct {
+	// DocType is the document type (agent, command, skill, memory).
+	DocType string
+	// InputPath is the source platform file.
+	InputPath string
+	// OutputPath is the rendered target platform file.
+	OutputPath string
+	// Dropped lists the source fields the target platform cannot represent.
+	Dropped []FieldLoss
+	// Warnings are the warnings about frontmatter keys the document type
+	// has no field for.
+	Warnings []error
+	// Error is any error that occurred converting this document.
+	Error error
+}
+
+// RoundtripResult contains the result of a round-trip check.
+type RoundtripResult struct {
+	// Documents holds one entry per document and platform checked.
+	Documents []RoundtripDocument
+}
+
+// RoundtripDocument describes rendering one canonical document for a
+// platform and parsing the output back.
+type RoundtripDocument struct {
+	// Ref is the resource reference (e.g., "agent/reviewer").
+	Ref string
+	// InputPath is the canonical source file.
+	InputPath string
+	// Platform is the platform the document was rendered for.
+	Platform string
+	// Dropped lists the source fields the platform cannot represent;
+	// they are expected to be lost and are not drift.
+	Dropped []FieldLoss
+	// Drift lists the fields that came back different.
+	Drift []FieldDrift
+	// Error is any error that occurred rendering or parsing the document.
+	Error error
+}
@@ -11,13 +11,13 @@ import (
 )
 
 func TestInitializeResult_Shape(t *testing.T) {
-	t.Run("has exactly four expected fields", func(t *testing.T) {
+	t.Run("has exactly five expected fields", func(t *testing.T) {
 		rt := reflect.TypeOf(InitializeResult{})
-		if rt.NumField() != 4 {
-			t.Fatalf("InitializeResult field count drift: got %d, want 4 (Ref, InputPath, OutputPath, Error)",
+		if rt.NumField() != 5 {
+			t.Fatalf("InitializeResult field count drift: got %d, want 5 (Ref, InputPath, OutputPath, Dropped, Error)",
 				rt.NumField())
 		}
-		expected := []string{"Ref", "InputPath", "OutputPath", "Error"}
+		expected := []string{"Ref", "InputPath", "OutputPath", "Dropped", "Error"}
 		for i, name := range expected {
 			got := rt.Field(i).Name
 			if got != name {
@@ -2,73 +2,155 @@ package core
 
 import (
 	"fmt"
+	"path"
 	"slices"
 	"strings"
 )
 
-// Platform identifiers recognized by ValidatePlatform and
-// ResolveOutputPath. Any caller needing the canonical strings
-// should reference these constants.
+// Platform identifiers for the built-in platforms. The authoritative
+// list of supported platforms is the registry in internal/platforms;
+// these constants exist so callers needing the canonical strings do
+// not have to repeat them.
 const (
 	PlatformClaudeCode = "claude-code"
 	PlatformOpenCode   = "opencode"
+	PlatformCursor     = "cursor"
+	PlatformCopilot    = "copilot"
+	PlatformGemini     = "gemini"
+	PlatformCodex      = "codex"
 )
 
-// ValidatePlatform returns nil if s is a recognized platform
-// identifier, otherwise a *ValidationError describing the invalid value.
-func ValidatePlatform(s string) error {
-	switch s {
-	case PlatformClaudeCode, PlatformOpenCode:
+// ValidatePlatform returns nil if s is one of the known platform
+// identifiers, otherwise a *ValidationError describing the invalid
+// value with one suggestion per known platform. The known list is
+// supplied by the caller (normally platforms.IDs()) so this rule stays
+// free of any registry state.
+func ValidatePlatform(s string, known []string) error {
+	if slices.Contains(known, s) {
 		return nil
-	default:
-		return NewValidationError(
-			"platform",
-			"platform",
-			s,
-			fmt.Sprintf("unknown platform %q", s),
-		).WithSuggestions([]string{
-			fmt.Sprintf("use %q", PlatformClaudeCode),
-			fmt.Sprintf("use %q", PlatformOpenCode),
-		})
 	}
+	suggestions := make([]string, 0, len(known))
+	for _, id := range known {
+		suggestions = append(suggestions, fmt.Sprintf("use %q", id))
+	}
+	return NewValidationError(
+		"platform",
+		"platform",
+		s,
+		fmt.Sprintf("unknown platform %q", s),
+	).WithSuggestions(suggestions)
+}
+
+// OutputPathConfig describes where a platform writes one resource type,
+// relative to the project output directory.
+type OutputPathConfig struct {
+	// Directory is the base directory name (e.g., ".opencode")
+	Directory string
+	// Subdirectory is the resource type subdirectory (e.g., "skills", "agents")
+	Subdirectory string
+	// FileSuffix is the suffix for the output file (e.g., "/SKILL.md" or ".md")
+	FileSuffix string
+	// UseSubdirectory indicates if the resource should be in a subdirectory
+	UseSubdirectory bool
+	// File, when set, is a fixed file path under Directory that every
+	// resource of the type is written to (e.g. "copilot-instructions.md");
+	// the resource name is ignored.
+	File string
+	// NestByPaths places a memory document in the directory its paths
+	// share (PathsDirectory) instead of under the output root, e.g.
+	// internal/api/AGENTS.md for paths under internal/api.
+	NestByPaths bool
+	// Merge indicates that several resources share the output file. Each
+	// is written as its own germinator-owned section (MergeSection) and
+	// text outside those sections is preserved.
+	Merge bool
+	// MergeJSON makes a Merge layout merge each rendered JSON object into
+	// the file's existing object with the given strategy instead of
+	// writing marked sections, e.g. MCP servers in .mcp.json.
+	MergeJSON JSONMerge
 }
 
-// ResolveOutputPath combines the document type, name, and platform
-// into the canonical output filename. Examples:
+// ResolveOutputPath combines an output layout and a resource name into
+// the slash-separated path of the rendered file. Examples:
 //
-//	ResolveOutputPath("skill", "commit", "claude-code") -> ".claude/skills/commit/SKILL.md"
-//	ResolveOutputPath("agent", "reviewer", "opencode")  -> ".opencode/agents/reviewer.md"
-//	ResolveOutputPath("command", "build", "claude-code") -> ".claude/commands/build.md"
-//	ResolveOutputPath("memory", "context", "opencode") -> ".opencode/memory/context.md"
-func ResolveOutputPath(docType, name, platform string) string {
-	root := ".opencode"
-	if platform == PlatformClaudeCode {
-		root = ".claude"
+//	{.claude skills /SKILL.md true} + "commit" -> ".claude/skills/commit/SKILL.md"
+//	{.opencode agents .md false}    + "reviewer" -> ".opencode/agents/reviewer.md"
+//	{.github File=copilot-instructions.md} + "style" -> ".github/copilot-instructions.md"
+func ResolveOutputPath(layout OutputPathConfig, name string) string {
+	if layout.File != "" {
+		return path.Join(layout.Directory, layout.File)
 	}
-	switch docType {
-	case "skill":
-		return fmt.Sprintf("%s/skills/%s/SKILL.md", root, name)
-	case "agent":
-		return fmt.Sprintf("%s/agents/%s.md", root, name)
-	case "command":
-		return fmt.Sprintf("%s/commands/%s.md", root, name)
-	case "memory":
-		return fmt.Sprintf("%s/memory/%s.md", root, name)
-	default:
-		return strings.Join([]string{root, docType, name + ".md"}, "/")
+	if layout.UseSubdirectory {
+		return path.Join(layout.Directory, layout.Subdirectory, name, layout.FileSuffix)
 	}
+	return path.Join(layout.Directory, layout.Subdirectory, name+layout.FileSuffix)
+}
+
+// globMeta lists the characters that start the non-literal part of a
+// path glob.
+const globMeta = "*?[{"
+
+// PathsDirectory returns the deepest slash-separated directory that
+// contains every path, considering only the literal prefix of each glob
+// (the part before the first metacharacter). It returns "" when the
+// paths share no directory, or when any path is absolute or escapes the
+// project with "..". A path that is not a glob names a directory when
+// it ends in a slash or its last segment has no extension. Examples:
+//
+//	["internal/api/**/*.go", "internal/api/doc.md"] -> "internal/api"
+//	["internal/api/*.go", "internal/cli/*.go"]      -> "internal"
+//	["src/api"]                                     -> "src/api"
+//	["*.go"]                                        -> ""
+func PathsDirectory(paths []string) string {
+	var common []string
+	for i, p := range paths {
+		literal := p
+		idx := strings.IndexAny(p, globMeta)
+		if idx >= 0 {
+			literal = p[:idx]
+		}
+		dir := literal
+		if !strings.HasSuffix(literal, "/") && (idx >= 0 || path.Ext(literal) != "") {
+			dir = path.Dir(literal)
+		}
+		dir = path.Clean(dir)
+		if path.IsAbs(dir) || dir == ".." || strings.HasPrefix(dir, "../") {
+			return ""
+		}
+
+		var segments []string
+		if dir != "." {
+			segments = strings.Split(dir, "/")
+		}
+		if i == 0 {
+			common = segments
+			continue
+		}
+		n := 0
+		for n < len(common) && n < len(segments) && common[n] == segments[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	return strings.Join(common, "/")
 }
 
 // validResourceTypes lists the recognized resource type segments of an
 // installable ref (e.g. "skill/commit").
-var validResourceTypes = []string{"skill", "agent", "command", "memory"}
+var validResourceTypes = []string{"skill", "agent", "command", "memory", "mcp", "hook", "settings"}
+
+// ResourceTypes returns a copy of the recognized resource types in
+// canonical order.
+func ResourceTypes() []string {
+	return slices.Clone(validResourceTypes)
+}
 
 // CanInstallResource validates the syntactic shape of a ref like
 // "skill/commit". It is a fast pre-flight check used by the library add
 // and library create preset commands before any I/O is performed.
 //
 // Returns nil if the ref is well-formed and the type segment is one of
-// {skill, agent, command, memory}. Otherwise returns a *core.ValidationError
+// {skill, agent, command, memory, mcp, hook, settings}. Otherwise returns a *core.ValidationError
 // describing the malformed component.
 //
 // This function is string-only — it does NOT look the resource up in the
@@ -83,9 +165,9 @@ func CanInstallResource(ref string) error {
 	if !slices.Contains(validResourceTypes, typ) {
 		return NewValidationError(
 			"library", "ref", ref,
-			"ref type must be one of skill, agent, command, memory",
+			"ref type must be one of skill, agent, command, memory, mcp, hook, settings",
 		).WithSuggestions([]string{
-			"use one of: skill, agent, command, memory",
+			"use one of: skill, agent, command, memory, mcp, hook, settings",
 		})
 	}
 	if name == "" {
@@ -97,7 +179,7 @@ func CanInstallResource(ref string) error {
 }
 
 // ValidateDocumentType validates a bare document type against the
-// canonical resource-type set {skill, agent, command, memory}. It is
+// canonical resource-type set {skill, agent, command, memory, mcp, hook, settings}. It is
 // the canonical guardrail for command-line --type validation
 // (e.g., `germinator canonicalize --type <docType>`) where the input
 // is a single type segment rather than a "type/name" ref.
@@ -123,8 +205,8 @@ func ValidateDocumentType(docType string) error {
 	}
 	return NewValidationError(
 		"canonicalize", "type", docType,
-		"type must be one of skill, agent, command, memory",
+		"type must be one of skill, agent, command, memory, mcp, hook, settings",
 	).WithSuggestions([]string{
-		"use one of: skill, agent, command, memory",
+		"use one of: skill, agent, command, memory, mcp, hook, settings",
 	})
 }
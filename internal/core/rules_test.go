@@ -27,7 +27,7 @@ func TestValidatePlatform(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			err := ValidatePlatform(tt.platform)
+			err := ValidatePlatform(tt.platform, []string{PlatformClaudeCode, PlatformOpenCode})
 			if tt.wantError {
 				var ve *ValidationError
 				require.ErrorAs(t, err, &ve)
@@ -39,30 +39,39 @@ func TestValidatePlatform(t *testing.T) {
 	}
 }
 
+func TestValidatePlatform_SuggestsKnownPlatforms(t *testing.T) {
+	t.Parallel()
+
+	err := ValidatePlatform("vscode", []string{"claude-code", "opencode", "cursor"})
+	var ve *ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, []string{`use "claude-code"`, `use "opencode"`, `use "cursor"`}, ve.Suggestions())
+}
+
 func TestResolveOutputPath(t *testing.T) {
 	t.Parallel()
 
+	skills := OutputPathConfig{Directory: ".claude", Subdirectory: "skills", FileSuffix: "/SKILL.md", UseSubdirectory: true}
+	agents := OutputPathConfig{Directory: ".opencode", Subdirectory: "agents", FileSuffix: ".md"}
+	rootFile := OutputPathConfig{FileSuffix: ".md"}
+	fixedFile := OutputPathConfig{Directory: ".github", File: "copilot-instructions.md"}
+
 	tests := []struct {
-		name     string
-		docType  string
-		resName  string
-		platform string
-		want     string
+		name    string
+		layout  OutputPathConfig
+		resName string
+		want    string
 	}{
-		{"skill opencode", "skill", "commit", "opencode", ".opencode/skills/commit/SKILL.md"},
-		{"skill claude-code", "skill", "commit", "claude-code", ".claude/skills/commit/SKILL.md"},
-		{"agent opencode", "agent", "reviewer", "opencode", ".opencode/agents/reviewer.md"},
-		{"agent claude-code", "agent", "reviewer", "claude-code", ".claude/agents/reviewer.md"},
-		{"command opencode", "command", "build", "opencode", ".opencode/commands/build.md"},
-		{"command claude-code", "command", "build", "claude-code", ".claude/commands/build.md"},
-		{"memory opencode", "memory", "context", "opencode", ".opencode/memory/context.md"},
-		{"memory claude-code", "memory", "context", "claude-code", ".claude/memory/context.md"},
+		{"skill in subdirectory", skills, "commit", ".claude/skills/commit/SKILL.md"},
+		{"agent flat file", agents, "reviewer", ".opencode/agents/reviewer.md"},
+		{"file at output root", rootFile, "context", "context.md"},
+		{"fixed file ignores name", fixedFile, "style", ".github/copilot-instructions.md"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := ResolveOutputPath(tt.docType, tt.resName, tt.platform)
+			got := ResolveOutputPath(tt.layout, tt.resName)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -157,8 +166,9 @@ func TestValidResourceTypesContainsAllExpected(t *testing.T) {
 	// Regression guard: if a new resource type is added (e.g. "hook"),
 	// both validResourceTypes and the AGENTS.md documentation must move
 	// in lockstep. Spec at library-library-resource-import/spec.md:23
-	// pins the literal list {skill, agent, command, memory}.
-	expected := []string{"skill", "agent", "command", "memory"}
+	// pins the literal list {skill, agent, command, memory}; mcp, hook,
+	// and settings were added with their resource types.
+	expected := []string{"skill", "agent", "command", "memory", "mcp", "hook", "settings"}
 	for _, et := range expected {
 		assert.True(t, slices.Contains(validResourceTypes, et),
 			"validResourceTypes missing %q", et)
@@ -169,7 +179,7 @@ func TestValidResourceTypesContainsAllExpected(t *testing.T) {
 	assert.False(t, slices.Contains(validResourceTypes, ""),
 		"validResourceTypes must not contain the empty string")
 	assert.Len(t, validResourceTypes, len(expected),
-		"validResourceTypes should contain exactly 4 entries")
+		"validResourceTypes should contain exactly 6 entries")
 }
 
 func TestValidateDocumentType(t *testing.T) {
@@ -271,3 +281,34 @@ func TestValidateDocumentType(t *testing.T) {
 		})
 	}
 }
+
+func TestPathsDirectory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  string
+	}{
+		{name: "no paths", paths: nil, want: ""},
+		{name: "single glob", paths: []string{"internal/api/**/*.go"}, want: "internal/api"},
+		{name: "single file", paths: []string{"internal/api/doc.md"}, want: "internal/api"},
+		{name: "trailing slash", paths: []string{"docs/"}, want: "docs"},
+		{name: "directory without trailing slash", paths: []string{"src/api"}, want: "src/api"},
+		{name: "directory and file", paths: []string{"src/api", "src/api/doc.md"}, want: "src/api"},
+		{name: "glob prefix is not a directory", paths: []string{"src/api*"}, want: "src"},
+		{name: "shared parent", paths: []string{"internal/api/*.go", "internal/cli/*.go"}, want: "internal"},
+		{name: "root glob", paths: []string{"*.go"}, want: ""},
+		{name: "disjoint", paths: []string{"cmd/*.go", "internal/*.go"}, want: ""},
+		{name: "escapes project", paths: []string{"../other/*.go"}, want: ""},
+		{name: "absolute", paths: []string{"/etc/*.conf"}, want: ""},
+		{name: "brace glob", paths: []string{"web/{src,test}/**"}, want: "web"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, PathsDirectory(tt.paths))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"strings"
+)
+
+// Section markers delimit the germinator-owned parts of a file that is
+// shared with hand-written text (e.g. AGENTS.md). The id is the library
+// ref that produced the section ("memory/go-style"), which keeps markers
+// stable across runs.
+const (
+	sectionBeginPrefix = "<!-- germinator:begin "
+	sectionEndPrefix   = "<!-- germinator:end "
+	sectionSuffix      = " -->"
+)
+
+// SectionMarkers returns the begin and end marker lines for a section id.
+func SectionMarkers(id string) (begin, end string) {
+	return sectionBeginPrefix + id + sectionSuffix, sectionEndPrefix + id + sectionSuffix
+}
+
+// MergeSection writes body into existing as the section named id. An
+// existing section with that id is replaced in place; otherwise the
+// section is appended after a blank line. Everything outside the
+// section, including other germinator sections, is left untouched.
+//
+// A begin marker without a matching end marker is reported as a
+// *ParseError rather than guessed at, so hand-written text after a
+// damaged marker is never overwritten.
+func MergeSection(existing, id, body string) (string, error) {
+	begin, end := SectionMarkers(id)
+	section := begin + "\n" + strings.TrimRight(body, "\n") + "\n" + end + "\n"
+
+	lines := strings.SplitAfter(existing, "\n")
+	start := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == begin && start < 0:
+			start = i
+		case trimmed == end && start >= 0:
+			return strings.Join(lines[:start], "") + section + strings.Join(lines[i+1:], ""), nil
+		}
+	}
+	if start >= 0 {
+		return "", NewParseError("", "germinator section "+id+" has no end marker", nil).
+			WithSuggestions([]string{"restore the line " + end + " or remove the section"})
+	}
+
+	switch {
+	case existing == "":
+		return section, nil
+	case strings.HasSuffix(existing, "\n\n"):
+		return existing + section, nil
+	case strings.HasSuffix(existing, "\n"):
+		return existing + "\n" + section, nil
+	default:
+		return existing + "\n\n" + section, nil
+	}
+}
+
+// StripSectionMarkers removes germinator section marker lines from
+// content, keeping the text between them. Used when reading a merged
+// file back so markers do not end up inside canonical documents.
+func StripSectionMarkers(content string) string {
+	lines := strings.SplitAfter(content, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasSuffix(trimmed, sectionSuffix) &&
+			(strings.HasPrefix(trimmed, sectionBeginPrefix) || strings.HasPrefix(trimmed, sectionEndPrefix)) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "")
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeSection(t *testing.T) {
+	t.Parallel()
+
+	section := "<!-- germinator:begin memory/style -->\nBe concise.\n<!-- germinator:end memory/style -->\n"
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name:     "empty file",
+			existing: "",
+			want:     section,
+		},
+		{
+			name:     "appends after hand-written text",
+			existing: "# Project\n\nHand-written.\n",
+			want:     "# Project\n\nHand-written.\n\n" + section,
+		},
+		{
+			name:     "appends when file lacks trailing newline",
+			existing: "# Project",
+			want:     "# Project\n\n" + section,
+		},
+		{
+			name:     "replaces existing section in place",
+			existing: "# Top\n<!-- germinator:begin memory/style -->\nOld.\n<!-- germinator:end memory/style -->\n# Bottom\n",
+			want:     "# Top\n" + section + "# Bottom\n",
+		},
+		{
+			name: "leaves other sections alone",
+			existing: "<!-- germinator:begin memory/other -->\nOther.\n<!-- germinator:end memory/other -->\n\n" +
+				"<!-- germinator:begin memory/style -->\nOld.\n<!-- germinator:end memory/style -->\n",
+			want: "<!-- germinator:begin memory/other -->\nOther.\n<!-- germinator:end memory/other -->\n\n" + section,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := MergeSection(tt.existing, "memory/style", "Be concise.\n")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMergeSection_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	once, err := MergeSection("# Project\n", "memory/style", "Be concise.")
+	require.NoError(t, err)
+	twice, err := MergeSection(once, "memory/style", "Be concise.")
+	require.NoError(t, err)
+	assert.Equal(t, once, twice)
+}
+
+func TestMergeSection_UnterminatedSection(t *testing.T) {
+	t.Parallel()
+
+	_, err := MergeSection("<!-- germinator:begin memory/style -->\nOld.\n", "memory/style", "New.")
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+}
+
+func TestStripSectionMarkers(t *testing.T) {
+	t.Parallel()
+
+	in := "# Project\n<!-- germinator:begin memory/style -->\nBe concise.\n<!-- germinator:end memory/style -->\n<!-- a normal comment -->\n"
+	assert.Equal(t, "# Project\nBe concise.\n<!-- a normal comment -->\n", StripSectionMarkers(in))
+}
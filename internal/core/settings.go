@@ -0,0 +1,49 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Settings represents project-wide assistant settings: the default
+// model, the default permission behavior, explicit permission rules,
+// and environment variables for the assistant's tools.
+//
+// PermissionPolicy and Permissions follow the agent fields of the same
+// name: a platform that has a permission mode (Claude Code's
+// defaultMode) renders the preset as that mode, other platforms expand
+// it into rules with Permissions layered on top.
+type Settings struct {
+	Name     string `yaml:"name" json:"name"`
+	FilePath string `yaml:"-" json:"-"`
+
+	Model            string            `yaml:"model,omitempty" json:"model,omitempty"`
+	PermissionPolicy PermissionPolicy  `yaml:"permissionPolicy,omitempty" json:"permissionPolicy,omitempty"`
+	Permissions      PermissionRules   `yaml:"permissions,omitempty" json:"permissions,omitempty"`
+	Env              map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+}
+
+// Scope selects which of a platform's settings files a resource is
+// installed into.
+type Scope string
+
+// Scope values. ScopeProject is the default, shared file (e.g.
+// .claude/settings.json); ScopeLocal is the personal file kept out of
+// version control (e.g. .claude/settings.local.json).
+const (
+	ScopeProject Scope = "project"
+	ScopeLocal   Scope = "local"
+)
+
+// scopes lists the valid Scope values.
+var scopes = []Scope{ScopeProject, ScopeLocal}
+
+// ValidateScope returns nil if s is a known scope and otherwise a
+// *ConfigError listing the valid values.
+func ValidateScope(s string) error {
+	if slices.Contains(scopes, Scope(s)) {
+		return nil
+	}
+	return NewConfigError("scope", s, "unknown scope").
+		WithSuggestions([]string{fmt.Sprintf("use %q or %q", ScopeProject, ScopeLocal)})
+}
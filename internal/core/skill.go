@@ -22,6 +22,9 @@ type Skill struct {
 	Content     string `yaml:"-" json:"-"`
 	FilePath    string `yaml:"-" json:"-"`
 
+	Extends string   `yaml:"extends,omitempty" json:"extends,omitempty"`
+	Replace []string `yaml:"replace,omitempty" json:"replace,omitempty"`
+
 	Tools      []string        `yaml:"tools,omitempty" json:"tools,omitempty"`
 	Extensions SkillExtensions `yaml:"extensions,omitempty" json:"extensions,omitempty"`
 	Execution  SkillExecution  `yaml:"execution,omitempty" json:"execution,omitempty"`
@@ -3,6 +3,8 @@ package core
 import (
 	"fmt"
 	"regexp"
+	"slices"
+	"strings"
 )
 
 // Agent validators
@@ -55,12 +57,48 @@ func ValidateAgentPermissionPolicy(a *Agent) Result[bool] {
 	return NewResult(true)
 }
 
+// ValidateAgentPermissions validates that every permission rule names a
+// tool and a specifier and uses a known action.
+func ValidateAgentPermissions(a *Agent) Result[bool] {
+	return validatePermissionRules("Agent", a.Permissions)
+}
+
+// validatePermissionRules checks rules on behalf of the request type
+// named by request (Agent, Settings).
+func validatePermissionRules(request string, rules PermissionRules) Result[bool] {
+	for _, tool := range sortedKeys(rules) {
+		specs := rules[tool]
+		if tool == "" {
+			return NewErrorResult[bool](
+				NewValidationError(request, "permissions", "", "permission rules must name a tool"),
+			)
+		}
+		for _, spec := range sortedKeys(specs) {
+			field := "permissions." + tool
+			if spec == "" {
+				return NewErrorResult[bool](
+					NewValidationError(request, field, "", "permission specifier must not be empty").
+						WithSuggestions([]string{`use "*" to match every use of the tool`}),
+				)
+			}
+			if action := specs[spec]; !action.IsValid() {
+				return NewErrorResult[bool](
+					NewValidationError(request, field+"."+spec, string(action),
+						"permission action must be one of: allow, ask, deny"),
+				)
+			}
+		}
+	}
+	return NewResult(true)
+}
+
 // ValidateAgent composes all agent validators into a pipeline.
 func ValidateAgent(a *Agent) Result[bool] {
 	return NewValidationPipeline(
 		ValidateAgentName,
 		ValidateAgentDescription,
 		ValidateAgentPermissionPolicy,
+		ValidateAgentPermissions,
 	).Validate(a)
 }
 
@@ -193,3 +231,211 @@ func ValidateMemory(m *Memory) Result[bool] {
 	}
 	return NewResult(true)
 }
+
+// MCP server validators
+
+// ValidateMCPServerName validates that the MCP server name is required
+// and usable as a JSON object key in platform config files.
+func ValidateMCPServerName(s *MCPServer) Result[bool] {
+	if s.Name == "" {
+		return NewErrorResult[bool](
+			NewValidationError("MCPServer", "name", "", "name is required"),
+		)
+	}
+	matched, err := regexp.MatchString(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`, s.Name)
+	if err != nil {
+		return NewErrorResult[bool](
+			NewValidationError("MCPServer", "name", s.Name, fmt.Sprintf("failed to validate name regex: %v", err)),
+		)
+	}
+	if !matched {
+		return NewErrorResult[bool](
+			NewValidationError("MCPServer", "name", s.Name, "name must match pattern ^[A-Za-z0-9][A-Za-z0-9_.-]*$"),
+		)
+	}
+	return NewResult(true)
+}
+
+// ValidateMCPServerTransport validates that the server is either local
+// (command, with optional args and env) or remote (an http(s) url, with
+// optional headers), but not both.
+func ValidateMCPServerTransport(s *MCPServer) Result[bool] {
+	switch {
+	case s.Command == "" && s.URL == "":
+		return NewErrorResult[bool](
+			NewValidationError("MCPServer", "command/url", "", "command or url is required").
+				WithSuggestions([]string{"set command for a local server or url for a remote one"}),
+		)
+	case s.Command != "" && s.URL != "":
+		return NewErrorResult[bool](
+			NewValidationError("MCPServer", "command/url", "", "command and url are mutually exclusive"),
+		)
+	case s.IsRemote() && (len(s.Args) > 0 || len(s.Env) > 0):
+		return NewErrorResult[bool](
+			NewValidationError("MCPServer", "args/env", "", "args and env apply only to local servers (command)"),
+		)
+	case !s.IsRemote() && len(s.Headers) > 0:
+		return NewErrorResult[bool](
+			NewValidationError("MCPServer", "headers", "", "headers apply only to remote servers (url)"),
+		)
+	case s.IsRemote() && !strings.HasPrefix(s.URL, "http://") && !strings.HasPrefix(s.URL, "https://"):
+		return NewErrorResult[bool](
+			NewValidationError("MCPServer", "url", s.URL, "url must start with http:// or https://"),
+		)
+	}
+	return NewResult(true)
+}
+
+// ValidateMCPServer composes all MCP server validators into a pipeline.
+func ValidateMCPServer(s *MCPServer) Result[bool] {
+	return NewValidationPipeline(
+		ValidateMCPServerName,
+		ValidateMCPServerTransport,
+	).Validate(s)
+}
+
+// Hook validators
+
+// ValidateHookName validates that the hook name is required and follows
+// the kebab-case naming used for resource file names.
+func ValidateHookName(h *Hook) Result[bool] {
+	if h.Name == "" {
+		return NewErrorResult[bool](
+			NewValidationError("Hook", "name", "", "name is required"),
+		)
+	}
+	matched, err := regexp.MatchString(`^[a-z0-9]+(-[a-z0-9]+)*$`, h.Name)
+	if err != nil {
+		return NewErrorResult[bool](
+			NewValidationError("Hook", "name", h.Name, fmt.Sprintf("failed to validate name regex: %v", err)),
+		)
+	}
+	if !matched {
+		return NewErrorResult[bool](
+			NewValidationError("Hook", "name", h.Name, "name must match pattern ^[a-z0-9]+(-[a-z0-9]+)*$"),
+		)
+	}
+	return NewResult(true)
+}
+
+// ValidateHookEvent validates that the event is one of the canonical
+// hook events.
+func ValidateHookEvent(h *Hook) Result[bool] {
+	if h.Event == "" {
+		return NewErrorResult[bool](
+			NewValidationError("Hook", "event", "", "event is required").
+				WithSuggestions([]string{"use one of: " + strings.Join(hookEvents, ", ")}),
+		)
+	}
+	if !slices.Contains(hookEvents, h.Event) {
+		return NewErrorResult[bool](
+			NewValidationError("Hook", "event", h.Event, "unknown hook event: "+h.Event).
+				WithSuggestions([]string{"use one of: " + strings.Join(hookEvents, ", ")}),
+		)
+	}
+	return NewResult(true)
+}
+
+// ValidateHookCommand validates that the hook has a command and a
+// non-negative timeout.
+func ValidateHookCommand(h *Hook) Result[bool] {
+	if strings.TrimSpace(h.Command) == "" {
+		return NewErrorResult[bool](
+			NewValidationError("Hook", "command", "", "command is required"),
+		)
+	}
+	if h.Timeout < 0 {
+		return NewErrorResult[bool](
+			NewValidationError("Hook", "timeout", fmt.Sprint(h.Timeout), "timeout must not be negative"),
+		)
+	}
+	return NewResult(true)
+}
+
+// ValidateHook composes all hook validators into a pipeline.
+func ValidateHook(h *Hook) Result[bool] {
+	return NewValidationPipeline(
+		ValidateHookName,
+		ValidateHookEvent,
+		ValidateHookCommand,
+	).Validate(h)
+}
+
+// Settings validators
+
+// ValidateSettingsName validates that the settings name is required and
+// follows the kebab-case naming used for resource file names.
+func ValidateSettingsName(s *Settings) Result[bool] {
+	if s.Name == "" {
+		return NewErrorResult[bool](
+			NewValidationError("Settings", "name", "", "name is required"),
+		)
+	}
+	matched, err := regexp.MatchString(`^[a-z0-9]+(-[a-z0-9]+)*$`, s.Name)
+	if err != nil {
+		return NewErrorResult[bool](
+			NewValidationError("Settings", "name", s.Name, fmt.Sprintf("failed to validate name regex: %v", err)),
+		)
+	}
+	if !matched {
+		return NewErrorResult[bool](
+			NewValidationError("Settings", "name", s.Name, "name must match pattern ^[a-z0-9]+(-[a-z0-9]+)*$"),
+		)
+	}
+	return NewResult(true)
+}
+
+// ValidateSettingsNotEmpty validates that the settings set at least one
+// field, so installing them is never a silent no-op.
+func ValidateSettingsNotEmpty(s *Settings) Result[bool] {
+	if s.Model == "" && s.PermissionPolicy == "" && len(s.Permissions) == 0 && len(s.Env) == 0 {
+		return NewErrorResult[bool](
+			NewValidationError("Settings", "", "", "settings must set at least one field").
+				WithSuggestions([]string{"set model, permissionPolicy, permissions, or env"}),
+		)
+	}
+	return NewResult(true)
+}
+
+// ValidateSettingsPermissions validates the permission policy and the
+// explicit permission rules.
+func ValidateSettingsPermissions(s *Settings) Result[bool] {
+	if s.PermissionPolicy != "" && !s.PermissionPolicy.IsValid() {
+		return NewErrorResult[bool](
+			NewValidationError(
+				"Settings",
+				"permissionPolicy",
+				string(s.PermissionPolicy),
+				"permissionPolicy must be one of: restrictive, balanced, permissive, analysis, unrestricted",
+			),
+		)
+	}
+	return validatePermissionRules("Settings", s.Permissions)
+}
+
+// ValidateSettingsEnv validates that every env key is a valid
+// environment variable name.
+func ValidateSettingsEnv(s *Settings) Result[bool] {
+	for _, key := range sortedKeys(s.Env) {
+		if !envNamePattern.MatchString(key) {
+			return NewErrorResult[bool](
+				NewValidationError("Settings", "env", key, "invalid environment variable name: "+key).
+					WithSuggestions([]string{"use letters, digits, and underscores, not starting with a digit"}),
+			)
+		}
+	}
+	return NewResult(true)
+}
+
+// envNamePattern matches a portable environment variable name.
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateSettings composes all settings validators into a pipeline.
+func ValidateSettings(s *Settings) Result[bool] {
+	return NewValidationPipeline(
+		ValidateSettingsName,
+		ValidateSettingsNotEmpty,
+		ValidateSettingsPermissions,
+		ValidateSettingsEnv,
+	).Validate(s)
+}
@@ -3,6 +3,9 @@ package core
 import (
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidateAgentName(t *testing.T) {
@@ -153,6 +156,58 @@ func TestValidateAgentPermissionPolicy(t *testing.T) {
 	}
 }
 
+func TestValidateAgentPermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		agent       *Agent
+		expectError bool
+	}{
+		{
+			name:        "no rules passes",
+			agent:       &Agent{},
+			expectError: false,
+		},
+		{
+			name: "valid rules pass",
+			agent: &Agent{Permissions: PermissionRules{
+				"bash": {"*": PermissionAsk, "git push*": PermissionDeny},
+				"edit": {"docs/**": PermissionAllow},
+			}},
+			expectError: false,
+		},
+		{
+			name:        "unknown action fails",
+			agent:       &Agent{Permissions: PermissionRules{"bash": {"rm*": "block"}}},
+			expectError: true,
+		},
+		{
+			name:        "empty specifier fails",
+			agent:       &Agent{Permissions: PermissionRules{"bash": {"": PermissionDeny}}},
+			expectError: true,
+		},
+		{
+			name:        "empty tool fails",
+			agent:       &Agent{Permissions: PermissionRules{"": {"*": PermissionDeny}}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateAgentPermissions(tt.agent)
+			if tt.expectError {
+				if result.IsSuccess() {
+					t.Error("expected error but got success")
+				}
+			} else {
+				if result.IsError() {
+					t.Errorf("expected success but got error: %v", result.Error)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateAgent(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -436,3 +491,189 @@ func TestValidatePipelineComposition(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateMCPServer(t *testing.T) {
+	tests := []struct {
+		name      string
+		server    *MCPServer
+		wantField string
+	}{
+		{
+			name:   "local server passes",
+			server: &MCPServer{Name: "github", Command: "npx", Args: []string{"-y", "server"}, Env: map[string]string{"TOKEN": "x"}},
+		},
+		{
+			name:   "remote server passes",
+			server: &MCPServer{Name: "docs", URL: "https://example.com/mcp", Headers: map[string]string{"Authorization": "Bearer x"}},
+		},
+		{
+			name:      "missing name",
+			server:    &MCPServer{Command: "npx"},
+			wantField: "name",
+		},
+		{
+			name:      "invalid name",
+			server:    &MCPServer{Name: "my server", Command: "npx"},
+			wantField: "name",
+		},
+		{
+			name:      "neither command nor url",
+			server:    &MCPServer{Name: "github"},
+			wantField: "command/url",
+		},
+		{
+			name:      "both command and url",
+			server:    &MCPServer{Name: "github", Command: "npx", URL: "https://example.com"},
+			wantField: "command/url",
+		},
+		{
+			name:      "headers on a local server",
+			server:    &MCPServer{Name: "github", Command: "npx", Headers: map[string]string{"A": "b"}},
+			wantField: "headers",
+		},
+		{
+			name:      "args on a remote server",
+			server:    &MCPServer{Name: "docs", URL: "https://example.com", Args: []string{"x"}},
+			wantField: "args/env",
+		},
+		{
+			name:      "non-http url",
+			server:    &MCPServer{Name: "docs", URL: "ftp://example.com"},
+			wantField: "url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateMCPServer(tt.server)
+			if tt.wantField == "" {
+				assert.True(t, result.IsSuccess(), "unexpected error: %v", result.Error)
+				return
+			}
+			require.True(t, result.IsError())
+			var valErr *ValidationError
+			require.ErrorAs(t, result.Error, &valErr)
+			assert.Equal(t, tt.wantField, valErr.Field())
+		})
+	}
+}
+
+func TestValidateHook(t *testing.T) {
+	tests := []struct {
+		name      string
+		hook      *Hook
+		wantField string
+	}{
+		{
+			name: "tool hook passes",
+			hook: &Hook{Name: "gofmt", Event: "PostToolUse", Matcher: "Edit|Write", Command: "gofmt -w .", Timeout: 30},
+		},
+		{
+			name: "session hook passes",
+			hook: &Hook{Name: "notify", Event: "Stop", Command: "notify-send done"},
+		},
+		{
+			name:      "missing name",
+			hook:      &Hook{Event: "Stop", Command: "x"},
+			wantField: "name",
+		},
+		{
+			name:      "missing event",
+			hook:      &Hook{Name: "x", Command: "x"},
+			wantField: "event",
+		},
+		{
+			name:      "unknown event",
+			hook:      &Hook{Name: "x", Event: "OnSave", Command: "x"},
+			wantField: "event",
+		},
+		{
+			name:      "blank command",
+			hook:      &Hook{Name: "x", Event: "Stop", Command: "  "},
+			wantField: "command",
+		},
+		{
+			name:      "negative timeout",
+			hook:      &Hook{Name: "x", Event: "Stop", Command: "x", Timeout: -1},
+			wantField: "timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateHook(tt.hook)
+			if tt.wantField == "" {
+				assert.True(t, result.IsSuccess(), "unexpected error: %v", result.Error)
+				return
+			}
+			require.True(t, result.IsError())
+			var valErr *ValidationError
+			require.ErrorAs(t, result.Error, &valErr)
+			assert.Equal(t, tt.wantField, valErr.Field())
+		})
+	}
+}
+
+func TestValidateSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  *Settings
+		wantErr   bool
+		wantField string
+	}{
+		{
+			name: "full settings pass",
+			settings: &Settings{
+				Name:             "team",
+				Model:            "sonnet",
+				PermissionPolicy: PermissionPolicyBalanced,
+				Permissions:      PermissionRules{"bash": {"git push*": PermissionDeny}},
+				Env:              map[string]string{"GOFLAGS": "-mod=mod"},
+			},
+		},
+		{
+			name:      "missing name",
+			settings:  &Settings{Model: "sonnet"},
+			wantErr:   true,
+			wantField: "name",
+		},
+		{
+			name:      "no fields set",
+			settings:  &Settings{Name: "empty"},
+			wantErr:   true,
+			wantField: "",
+		},
+		{
+			name:      "invalid permission policy",
+			settings:  &Settings{Name: "x", PermissionPolicy: "open"},
+			wantErr:   true,
+			wantField: "permissionPolicy",
+		},
+		{
+			name:      "invalid permission action",
+			settings:  &Settings{Name: "x", Permissions: PermissionRules{"bash": {"*": "maybe"}}},
+			wantErr:   true,
+			wantField: "permissions.bash.*",
+		},
+		{
+			name:      "invalid env name",
+			settings:  &Settings{Name: "x", Env: map[string]string{"1BAD": "x"}},
+			wantErr:   true,
+			wantField: "env",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateSettings(tt.settings)
+			if !tt.wantErr {
+				assert.True(t, result.IsSuccess(), "unexpected error: %v", result.Error)
+				return
+			}
+			require.True(t, result.IsError())
+			var valErr *ValidationError
+			require.ErrorAs(t, result.Error, &valErr)
+			assert.Equal(t, tt.wantField, valErr.Field())
+		})
+	}
+}
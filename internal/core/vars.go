@@ -0,0 +1,100 @@
+package core
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// ExpandVars executes content, the text of a canonical document that
+// declares variables, as a text/template whose data holds .Vars: for
+// each declared variable, its value from values, else its default.
+// declared maps each variable to its default; a nil default marks the
+// variable required. Values for variables the document does not declare
+// are ignored, so one set of values can serve many documents.
+//
+// A reference to an undeclared variable, or a required variable without
+// a value, is a *ValidationError naming the variable.
+func ExpandVars(content string, declared map[string]*string, values map[string]string) (string, error) {
+	tmpl, err := template.New("document").Option("missingkey=error").Parse(content)
+	if err != nil {
+		return "", NewParseError("", "invalid variable reference", err)
+	}
+
+	for _, name := range referencedVars(tmpl.Tree.Root) {
+		if _, ok := declared[name]; !ok {
+			return "", NewValidationError("", "vars", name, "variable "+name+" is not declared").
+				WithSuggestions([]string{"declare it under vars: in the frontmatter"})
+		}
+	}
+
+	vars := make(map[string]string, len(declared))
+	for _, name := range slices.Sorted(maps.Keys(declared)) {
+		switch value, ok := values[name]; {
+		case ok:
+			vars[name] = value
+		case declared[name] != nil:
+			vars[name] = *declared[name]
+		default:
+			return "", NewValidationError("", "vars", name, "required variable "+name+" is not set").
+				WithSuggestions([]string{"set it with --set " + name + "=<value> or in a values file", "give it a default under vars:"})
+		}
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, struct{ Vars map[string]string }{Vars: vars}); err != nil {
+		return "", NewParseError("", "failed to expand variables", err)
+	}
+	return sb.String(), nil
+}
+
+// referencedVars returns the names of the .Vars.<name> fields the
+// template tree under node references, sorted and without duplicates.
+func referencedVars(node parse.Node) []string {
+	names := make(map[string]bool)
+	var walk func(parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd)
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.FieldNode:
+			if len(n.Ident) >= 2 && n.Ident[0] == "Vars" {
+				names[n.Ident[1]] = true
+			}
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		}
+	}
+	walk(node)
+	return slices.Sorted(maps.Keys(names))
+}
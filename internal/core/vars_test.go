@@ -0,0 +1,64 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandVars(t *testing.T) {
+	t.Parallel()
+
+	def := "go test ./..."
+	declared := map[string]*string{"project_name": nil, "test_command": &def}
+
+	tests := []struct {
+		name      string
+		content   string
+		values    map[string]string
+		want      string
+		wantField string
+	}{
+		{
+			name:    "defaults and values",
+			content: "{{ .Vars.project_name }}: run {{ .Vars.test_command }}",
+			values:  map[string]string{"project_name": "germinator", "unrelated": "x"},
+			want:    "germinator: run go test ./...",
+		},
+		{
+			name:    "value overrides default",
+			content: "{{ if .Vars.test_command }}{{ .Vars.test_command }}{{ end }}",
+			values:  map[string]string{"project_name": "p", "test_command": "make test"},
+			want:    "make test",
+		},
+		{
+			name:      "required variable missing",
+			content:   "run {{ .Vars.test_command }}",
+			wantField: "project_name",
+		},
+		{
+			name:      "undeclared variable",
+			content:   "{{ .Vars.owner }}",
+			values:    map[string]string{"project_name": "p", "owner": "me"},
+			wantField: "owner",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ExpandVars(tt.content, declared, tt.values)
+			if tt.wantField != "" {
+				var verr *ValidationError
+				require.ErrorAs(t, err, &verr)
+				assert.Equal(t, "vars", verr.Field())
+				assert.Equal(t, tt.wantField, verr.Value())
+				assert.Contains(t, verr.Error(), tt.wantField)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package cursor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/permission"
+)
+
+// Adapter implements the Adapter interface for the Cursor platform.
+type Adapter struct{}
+
+// Cursor is the package-level singleton for the Cursor Adapter.
+// The adapter is stateless; a single shared instance is safe to use across goroutines.
+var Cursor = &Adapter{}
+
+// unsupportedFields lists, per document type, the canonical fields the
+// Cursor templates do not render.
+var unsupportedFields = map[string][]core.UnsupportedField{
+	"command": {
+		{Field: "tools", Reason: "Cursor commands are plain prompts"},
+		{Field: "execution", Reason: "Cursor commands are plain prompts"},
+		{Field: "arguments.hint", Reason: "Cursor commands are plain prompts"},
+		{Field: "model", Reason: "Cursor commands are plain prompts"},
+	},
+	"skill": {
+		{Field: "tools", Reason: "Cursor rules only carry a description and globs"},
+		{Field: "extensions", Reason: "Cursor rules only carry a description and globs"},
+		{Field: "execution", Reason: "Cursor rules only carry a description and globs"},
+		{Field: "model", Reason: "Cursor rules only carry a description and globs"},
+	},
+}
+
+// ToCanonical parses Cursor rule frontmatter into canonical domain models.
+// Agents are rejected because Cursor rules have no agent equivalent.
+func (a *Adapter) ToCanonical(input map[string]interface{}) (*core.Agent, *core.Command, *core.Skill, *core.Memory, error) {
+	docType, ok := input["__type"].(string)
+	if !ok {
+		return nil, nil, nil, nil, core.NewParseError("", "missing __type field", nil)
+	}
+
+	switch docType {
+	case "command":
+		return nil, a.parseCommand(input), nil, nil, nil
+	case "skill":
+		return nil, nil, a.parseSkill(input), nil, nil
+	case "memory":
+		return nil, nil, nil, a.parseMemory(input), nil
+	case "agent":
+		return nil, nil, nil, nil, core.NewParseError("", "cursor rules cannot be read as agent documents", nil).
+			WithSuggestions([]string{"use --type skill, command, or memory"})
+	default:
+		return nil, nil, nil, nil, core.NewParseError("", "unknown document type: "+docType, nil)
+	}
+}
+
+// FromCanonical converts canonical domain models to Cursor rule frontmatter maps.
+func (a *Adapter) FromCanonical(docType string, doc interface{}) (map[string]interface{}, error) {
+	switch docType {
+	case "command":
+		cmd, ok := doc.(*core.Command)
+		if !ok {
+			return nil, core.NewTransformError("from-canonical", core.PlatformCursor, fmt.Sprintf("expected *core.Command, got %T", doc), nil)
+		}
+		return renderRule("command", cmd.Description, nil, false), nil
+	case "skill":
+		skill, ok := doc.(*core.Skill)
+		if !ok {
+			return nil, core.NewTransformError("from-canonical", core.PlatformCursor, fmt.Sprintf("expected *core.Skill, got %T", doc), nil)
+		}
+		return renderRule("skill", skill.Description, nil, false), nil
+	case "memory":
+		mem, ok := doc.(*core.Memory)
+		if !ok {
+			return nil, core.NewTransformError("from-canonical", core.PlatformCursor, fmt.Sprintf("expected *core.Memory, got %T", doc), nil)
+		}
+		return renderRule("memory", "", mem.Paths, len(mem.Paths) == 0), nil
+	case "agent":
+		return nil, core.NewTransformError("from-canonical", core.PlatformCursor, "cursor does not support agent documents", nil)
+	default:
+		return nil, core.NewTransformError("from-canonical", core.PlatformCursor, "unknown document type: "+docType, nil)
+	}
+}
+
+// PermissionPolicyToPlatform validates the policy and returns nil: Cursor
+// rules carry no permission settings.
+func (a *Adapter) PermissionPolicyToPlatform(policy core.PermissionPolicy) (interface{}, error) {
+	if _, ok := permission.PermissionPolicyMappings[string(policy)]; !ok {
+		return nil, core.NewConfigError("permission-policy", string(policy), "unknown permission policy")
+	}
+	return nil, nil //nolint:nilnil // no permission representation exists; nil is the platform value
+}
+
+// NamesFromFile reports that every document is named by its file:
+// Cursor rules have no name field.
+func (a *Adapter) NamesFromFile(_ string) bool {
+	return true
+}
+
+// UnsupportedFields returns the canonical fields Cursor cannot represent
+// for docType; convert reports them as dropped when they are set.
+func (a *Adapter) UnsupportedFields(docType string) []core.UnsupportedField {
+	return unsupportedFields[docType]
+}
+
+// ConvertToolNameCase returns the canonical lowercase name. Cursor rules
+// do not reference tools, so no platform casing applies.
+func (a *Adapter) ConvertToolNameCase(name string) string {
+	return permission.ToLowerCase(name)
+}
+
+// NormalizeFrontmatter quotes a bare `globs:` value so patterns Cursor
+// writes unquoted (e.g. `globs: *.ts`) parse as a YAML string instead of
+// an alias. Already-quoted values and YAML lists are left untouched.
+func (a *Adapter) NormalizeFrontmatter(frontmatter string) string {
+	lines := strings.Split(frontmatter, "\n")
+	for i, line := range lines {
+		rest, ok := strings.CutPrefix(line, "globs:")
+		if !ok {
+			continue
+		}
+		value := strings.TrimSpace(rest)
+		if value == "" || strings.ContainsAny(value[:1], `"'[`) {
+			continue
+		}
+		lines[i] = "globs: " + strconv.Quote(value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (a *Adapter) parseCommand(input map[string]interface{}) *core.Command {
+	cmd := &core.Command{}
+	if name, ok := input["name"].(string); ok {
+		cmd.Name = name
+	}
+	if description, ok := input["description"].(string); ok {
+		cmd.Description = description
+	}
+	cmd.Targets = make(core.PlatformConfig)
+	return cmd
+}
+
+func (a *Adapter) parseSkill(input map[string]interface{}) *core.Skill {
+	skill := &core.Skill{}
+	if name, ok := input["name"].(string); ok {
+		skill.Name = name
+	}
+	if description, ok := input["description"].(string); ok {
+		skill.Description = description
+	}
+	skill.Targets = make(core.PlatformConfig)
+	return skill
+}
+
+func (a *Adapter) parseMemory(input map[string]interface{}) *core.Memory {
+	return &core.Memory{Paths: parseGlobs(input["globs"])}
+}
+
+// parseGlobs accepts Cursor's comma-separated string form as well as a
+// YAML list, dropping empty entries.
+func parseGlobs(value interface{}) []string {
+	var raw []string
+	switch v := value.(type) {
+	case string:
+		raw = strings.Split(v, ",")
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				raw = append(raw, s)
+			}
+		}
+	}
+
+	var globs []string
+	for _, g := range raw {
+		if g = strings.TrimSpace(g); g != "" {
+			globs = append(globs, g)
+		}
+	}
+	return globs
+}
+
+func renderRule(docType, description string, globs []string, alwaysApply bool) map[string]interface{} {
+	output := map[string]interface{}{
+		"__type":      docType,
+		"alwaysApply": alwaysApply,
+	}
+	if description != "" {
+		output["description"] = description
+	}
+	if len(globs) > 0 {
+		output["globs"] = strings.Join(globs, ",")
+	}
+	return output
+}
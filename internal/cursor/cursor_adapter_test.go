@@ -0,0 +1,111 @@
+package cursor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	canonical "gitlab.com/amoconst/germinator/internal/core"
+	yaml "gopkg.in/yaml.v3"
+)
+
+func TestToCanonicalMemory(t *testing.T) {
+	tests := []struct {
+		name  string
+		globs interface{}
+		want  []string
+	}{
+		{"comma separated", "src/**/*.go, cmd/**/*.go", []string{"src/**/*.go", "cmd/**/*.go"}},
+		{"yaml list", []interface{}{"*.ts", "*.tsx"}, []string{"*.ts", "*.tsx"}},
+		{"empty string", "", nil},
+		{"absent", nil, nil},
+		{"trailing comma", "*.md,", []string{"*.md"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := map[string]interface{}{"__type": "memory", "alwaysApply": false}
+			if tt.globs != nil {
+				input["globs"] = tt.globs
+			}
+			_, _, _, mem, err := Cursor.ToCanonical(input)
+			require.NoError(t, err)
+			require.NotNil(t, mem)
+			assert.Equal(t, tt.want, mem.Paths)
+		})
+	}
+}
+
+func TestToCanonicalSkillAndCommand(t *testing.T) {
+	input := map[string]interface{}{"__type": "skill", "description": "Go style rules", "alwaysApply": false}
+	_, _, skill, _, err := Cursor.ToCanonical(input)
+	require.NoError(t, err)
+	assert.Equal(t, "Go style rules", skill.Description)
+
+	input["__type"] = "command"
+	_, cmd, _, _, err := Cursor.ToCanonical(input)
+	require.NoError(t, err)
+	assert.Equal(t, "Go style rules", cmd.Description)
+}
+
+func TestToCanonicalRejectsAgent(t *testing.T) {
+	_, _, _, _, err := Cursor.ToCanonical(map[string]interface{}{"__type": "agent"})
+	var parseErr *canonical.ParseError
+	require.True(t, errors.As(err, &parseErr))
+}
+
+func TestFromCanonical(t *testing.T) {
+	out, err := Cursor.FromCanonical("memory", &canonical.Memory{Paths: []string{"*.go", "go.mod"}})
+	require.NoError(t, err)
+	assert.Equal(t, "*.go,go.mod", out["globs"])
+	assert.Equal(t, false, out["alwaysApply"])
+
+	out, err = Cursor.FromCanonical("memory", &canonical.Memory{Content: "always"})
+	require.NoError(t, err)
+	assert.Equal(t, true, out["alwaysApply"])
+	assert.NotContains(t, out, "globs")
+
+	out, err = Cursor.FromCanonical("skill", &canonical.Skill{Name: "s", Description: "d"})
+	require.NoError(t, err)
+	assert.Equal(t, "d", out["description"])
+
+	_, err = Cursor.FromCanonical("agent", &canonical.Agent{})
+	var transformErr *canonical.TransformError
+	require.True(t, errors.As(err, &transformErr))
+
+	_, err = Cursor.FromCanonical("skill", &canonical.Command{})
+	require.Error(t, err)
+}
+
+func TestPermissionPolicyToPlatform(t *testing.T) {
+	result, err := Cursor.PermissionPolicyToPlatform(canonical.PermissionPolicyBalanced)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+
+	_, err = Cursor.PermissionPolicyToPlatform("invalid")
+	require.Error(t, err)
+}
+
+func TestNormalizeFrontmatter(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  interface{}
+	}{
+		{"bare leading star", "globs: *.ts,src/**", "*.ts,src/**"},
+		{"already quoted", `globs: "*.ts"`, "*.ts"},
+		{"yaml list", "globs: [\"*.ts\"]", []interface{}{"*.ts"}},
+		{"empty", "globs:", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized := Cursor.NormalizeFrontmatter("description: x\n" + tt.input + "\nalwaysApply: false")
+			var got map[string]interface{}
+			require.NoError(t, yaml.Unmarshal([]byte(normalized), &got))
+			assert.Equal(t, tt.want, got["globs"])
+			assert.Equal(t, "x", got["description"])
+		})
+	}
+}
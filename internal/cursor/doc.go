@@ -0,0 +1,40 @@
+// Package cursor implements the Cursor adapter for bidirectional conversion
+// between canonical models and Cursor project rules (.cursor/rules/*.mdc).
+//
+// A Cursor rule is a Markdown file with three frontmatter keys:
+//
+//   - description: when the agent should pull the rule in
+//   - globs: comma-separated file patterns that auto-attach the rule
+//   - alwaysApply: whether the rule is always in context
+//
+// # Document Mapping
+//
+// Cursor has no agent concept, so only commands, skills, and memory are
+// supported:
+//
+//	memory   → .cursor/rules/<name>.mdc, globs from Memory.Paths;
+//	           alwaysApply when Paths is empty
+//	skill    → .cursor/rules/skills/<name>.mdc, description,
+//	           alwaysApply: false ("Agent Requested" rule)
+//	command  → .cursor/rules/commands/<name>.mdc, description,
+//	           alwaysApply: false (invoked manually via @name)
+//
+// Rules carry no name field; the rule name is the file name. Canonical
+// names are therefore derived from the filename when canonicalizing.
+//
+// # Globs
+//
+// Cursor writes globs unquoted (globs: *.ts,src/**), which is not valid
+// YAML when a pattern starts with "*". NormalizeFrontmatter quotes the
+// value before the YAML parser sees it, and parseGlobs accepts both the
+// comma-separated string and a YAML list.
+//
+// Usage Example
+//
+//	import "gitlab.com/amoconst/germinator/internal/cursor"
+//
+//	adapter := cursor.Cursor
+//
+//	// Convert a Cursor rule to canonical memory
+//	_, _, _, mem, err := adapter.ToCanonical(ruleMap)
+package cursor
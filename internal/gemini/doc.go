@@ -0,0 +1,50 @@
+// Package gemini implements the Gemini CLI adapter for bidirectional
+// conversion between canonical models and Gemini CLI's custom commands
+// and context file.
+//
+// Document Mapping
+//
+//	command → .gemini/commands/<name>.toml (TOML, not Markdown)
+//	memory  → GEMINI.md, paths → @file imports
+//
+// Gemini CLI has no agent or skill files; both are rejected.
+//
+// # TOML Commands
+//
+// A custom command is a TOML file with two keys:
+//
+//	description = "Review the staged diff"
+//	prompt = """
+//	Review the changes. Focus on {{args}}.
+//	"""
+//
+// The prompt is the command body. Gemini's {{args}} placeholder maps to
+// the canonical $ARGUMENTS placeholder in both directions. Commands carry
+// no name key; the name is the file name without .toml.
+//
+// # GEMINI.md
+//
+// Memory paths become @file import lines at the top of GEMINI.md, which
+// Gemini CLI inlines when loading context. Imports name single files, so
+// glob paths fail validation. Reading GEMINI.md back turns the leading
+// import lines into paths again.
+//
+// Several memory resources share GEMINI.md, each written as its own
+// germinator section (core.MergeSection) with its imports at the top of
+// the section; reading the file back drops the section markers.
+//
+// Neither file has YAML frontmatter, so the adapter implements
+// DecodeDocument and the parser reads both formats directly instead of
+// splitting frontmatter from a Markdown body.
+//
+// Usage Example
+//
+//	import "gitlab.com/amoconst/germinator/internal/gemini"
+//
+//	adapter := gemini.Gemini
+//
+//	// Decode a TOML command and convert it to a canonical command
+//	fields, body, _, err := adapter.DecodeDocument("command", tomlBytes)
+//	fields["__type"] = "command"
+//	_, cmd, _, _, err := adapter.ToCanonical(fields)
+package gemini
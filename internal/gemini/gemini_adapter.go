@@ -0,0 +1,190 @@
+package gemini
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/knadh/koanf/parsers/toml/v2"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/permission"
+)
+
+// Adapter implements the Adapter interface for the Gemini CLI platform.
+type Adapter struct{}
+
+// Gemini is the package-level singleton for the Gemini Adapter.
+// The adapter is stateless; a single shared instance is safe to use across goroutines.
+var Gemini = &Adapter{}
+
+// unsupportedFields lists, per document type, the canonical fields the
+// Gemini CLI templates do not render.
+var unsupportedFields = map[string][]core.UnsupportedField{
+	"command": {
+		{Field: "tools", Reason: "Gemini CLI commands only carry a description and prompt"},
+		{Field: "execution", Reason: "Gemini CLI commands only carry a description and prompt"},
+		{Field: "arguments.hint", Reason: "Gemini CLI commands only carry a description and prompt"},
+		{Field: "model", Reason: "Gemini CLI commands only carry a description and prompt"},
+	},
+}
+
+// Argument placeholders: Gemini CLI substitutes {{args}} in a command
+// prompt where canonical (Claude Code style) commands use $ARGUMENTS.
+const (
+	geminiArgs    = "{{args}}"
+	canonicalArgs = "$ARGUMENTS"
+)
+
+// ToCanonical parses Gemini CLI fields into canonical domain models.
+// Agents and skills are rejected because Gemini CLI has no equivalent.
+func (a *Adapter) ToCanonical(input map[string]interface{}) (*core.Agent, *core.Command, *core.Skill, *core.Memory, error) {
+	docType, ok := input["__type"].(string)
+	if !ok {
+		return nil, nil, nil, nil, core.NewParseError("", "missing __type field", nil)
+	}
+
+	switch docType {
+	case "command":
+		return nil, a.parseCommand(input), nil, nil, nil
+	case "memory":
+		return nil, nil, nil, a.parseMemory(input), nil
+	case "agent", "skill":
+		return nil, nil, nil, nil, core.NewParseError("", "gemini files cannot be read as "+docType+" documents", nil).
+			WithSuggestions([]string{"use --type command or memory"})
+	default:
+		return nil, nil, nil, nil, core.NewParseError("", "unknown document type: "+docType, nil)
+	}
+}
+
+// FromCanonical converts canonical domain models to Gemini CLI field maps.
+func (a *Adapter) FromCanonical(docType string, doc interface{}) (map[string]interface{}, error) {
+	switch docType {
+	case "command":
+		cmd, ok := doc.(*core.Command)
+		if !ok {
+			return nil, core.NewTransformError("from-canonical", core.PlatformGemini, fmt.Sprintf("expected *core.Command, got %T", doc), nil)
+		}
+		output := map[string]interface{}{"__type": "command"}
+		if cmd.Description != "" {
+			output["description"] = cmd.Description
+		}
+		return output, nil
+	case "memory":
+		mem, ok := doc.(*core.Memory)
+		if !ok {
+			return nil, core.NewTransformError("from-canonical", core.PlatformGemini, fmt.Sprintf("expected *core.Memory, got %T", doc), nil)
+		}
+		output := map[string]interface{}{"__type": "memory"}
+		if mem.Content != "" {
+			output["content"] = mem.Content
+		}
+		return output, nil
+	case "agent", "skill":
+		return nil, core.NewTransformError("from-canonical", core.PlatformGemini, "gemini does not support "+docType+" documents", nil)
+	default:
+		return nil, core.NewTransformError("from-canonical", core.PlatformGemini, "unknown document type: "+docType, nil)
+	}
+}
+
+// PermissionPolicyToPlatform validates the policy and returns nil: Gemini
+// CLI commands and context files carry no permission settings.
+func (a *Adapter) PermissionPolicyToPlatform(policy core.PermissionPolicy) (interface{}, error) {
+	if _, ok := permission.PermissionPolicyMappings[string(policy)]; !ok {
+		return nil, core.NewConfigError("permission-policy", string(policy), "unknown permission policy")
+	}
+	return nil, nil //nolint:nilnil // no permission representation exists; nil is the platform value
+}
+
+// NamesFromFile reports that every document is named by its file:
+// Gemini CLI commands have no name key.
+func (a *Adapter) NamesFromFile(_ string) bool {
+	return true
+}
+
+// UnsupportedFields returns the canonical fields Gemini CLI cannot represent
+// for docType; convert reports them as dropped when they are set.
+func (a *Adapter) UnsupportedFields(docType string) []core.UnsupportedField {
+	return unsupportedFields[docType]
+}
+
+// ConvertToolNameCase returns the canonical lowercase name. Gemini CLI
+// commands do not reference tools, so no platform casing applies.
+func (a *Adapter) ConvertToolNameCase(name string) string {
+	return permission.ToLowerCase(name)
+}
+
+// DecodeDocument reads Gemini CLI files, which have no YAML frontmatter.
+// A command is TOML: its keys (without prompt) become fields and the
+// prompt becomes the body. For GEMINI.md, germinator section markers are
+// dropped, then leading @file import lines become the "imports" field
+// and the remaining Markdown the body.
+func (a *Adapter) DecodeDocument(docType string, content []byte) (map[string]interface{}, string, bool, error) {
+	switch docType {
+	case "command":
+		fields, err := toml.Parser().Unmarshal(content)
+		if err != nil {
+			return nil, "", true, fmt.Errorf("parsing TOML command: %w", err)
+		}
+		var body string
+		if prompt, ok := fields["prompt"].(string); ok {
+			body = toCanonicalPrompt(prompt)
+		}
+		delete(fields, "prompt")
+		return fields, body, true, nil
+	case "memory":
+		imports, body := splitImports(core.StripSectionMarkers(string(content)))
+		return map[string]interface{}{"imports": imports}, body, true, nil
+	default:
+		return nil, "", false, nil
+	}
+}
+
+// splitImports separates the leading @path import lines of a GEMINI.md
+// file from the Markdown that follows them.
+func splitImports(content string) ([]interface{}, string) {
+	var imports []interface{}
+	lines := strings.Split(content, "\n")
+	i := 0
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+		target, ok := strings.CutPrefix(line, "@")
+		if !ok || target == "" || strings.ContainsAny(target, " \t") {
+			break
+		}
+		imports = append(imports, target)
+	}
+	return imports, strings.Join(lines[i:], "\n")
+}
+
+// toCanonicalPrompt rewrites Gemini CLI argument placeholders to their
+// canonical form. The command template performs the reverse rewrite.
+func toCanonicalPrompt(prompt string) string {
+	return strings.ReplaceAll(prompt, geminiArgs, canonicalArgs)
+}
+
+func (a *Adapter) parseMemory(input map[string]interface{}) *core.Memory {
+	mem := &core.Memory{}
+	if imports, ok := input["imports"].([]interface{}); ok {
+		for _, item := range imports {
+			if p, ok := item.(string); ok {
+				mem.Paths = append(mem.Paths, p)
+			}
+		}
+	}
+	return mem
+}
+
+func (a *Adapter) parseCommand(input map[string]interface{}) *core.Command {
+	cmd := &core.Command{}
+	if name, ok := input["name"].(string); ok {
+		cmd.Name = name
+	}
+	if description, ok := input["description"].(string); ok {
+		cmd.Description = description
+	}
+	cmd.Targets = make(core.PlatformConfig)
+	return cmd
+}
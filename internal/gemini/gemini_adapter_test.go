@@ -0,0 +1,82 @@
+package gemini
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	canonical "gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestDecodeDocumentCommand(t *testing.T) {
+	content := []byte("description = \"Review the diff\"\nprompt = \"\"\"\nReview {{args}} carefully.\n\"\"\"\n")
+
+	fields, body, handled, err := Gemini.DecodeDocument("command", content)
+	require.NoError(t, err)
+	assert.True(t, handled)
+	assert.Equal(t, "Review the diff", fields["description"])
+	assert.NotContains(t, fields, "prompt")
+	assert.Equal(t, "Review $ARGUMENTS carefully.\n", body)
+}
+
+func TestDecodeDocumentInvalidTOML(t *testing.T) {
+	_, _, handled, err := Gemini.DecodeDocument("command", []byte("description = \n"))
+	assert.True(t, handled)
+	require.Error(t, err)
+}
+
+func TestDecodeDocumentMemory(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantImports []interface{}
+		wantBody    string
+	}{
+		{"imports then body", "@docs/style.md\n@README.md\n\nBe concise.\n", []interface{}{"docs/style.md", "README.md"}, "Be concise.\n"},
+		{"body only", "Be concise.\n", nil, "Be concise.\n"},
+		{"mention is not an import", "@team please review\n", nil, "@team please review\n"},
+		{"merged section", "<!-- germinator:begin memory/style -->\n@docs/style.md\n\nBe concise.\n<!-- germinator:end memory/style -->\n",
+			[]interface{}{"docs/style.md"}, "Be concise.\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, body, handled, err := Gemini.DecodeDocument("memory", []byte(tt.content))
+			require.NoError(t, err)
+			assert.True(t, handled)
+			assert.Equal(t, tt.wantImports, fields["imports"])
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
+
+func TestToCanonical(t *testing.T) {
+	_, cmd, _, _, err := Gemini.ToCanonical(map[string]interface{}{"__type": "command", "description": "d"})
+	require.NoError(t, err)
+	assert.Equal(t, "d", cmd.Description)
+
+	_, _, _, mem, err := Gemini.ToCanonical(map[string]interface{}{"__type": "memory", "imports": []interface{}{"a.md"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.md"}, mem.Paths)
+
+	for _, docType := range []string{"agent", "skill"} {
+		_, _, _, _, err = Gemini.ToCanonical(map[string]interface{}{"__type": docType})
+		var parseErr *canonical.ParseError
+		require.True(t, errors.As(err, &parseErr), docType)
+	}
+}
+
+func TestFromCanonical(t *testing.T) {
+	out, err := Gemini.FromCanonical("command", &canonical.Command{Name: "review", Description: "d"})
+	require.NoError(t, err)
+	assert.Equal(t, "d", out["description"])
+	assert.NotContains(t, out, "name")
+
+	_, err = Gemini.FromCanonical("agent", &canonical.Agent{})
+	var transformErr *canonical.TransformError
+	require.True(t, errors.As(err, &transformErr))
+
+	_, err = Gemini.FromCanonical("command", &canonical.Memory{})
+	require.Error(t, err)
+}
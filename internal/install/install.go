@@ -46,6 +46,16 @@ type Request struct {
 	Refs      []string
 	DryRun    bool
 	Force     bool
+	// Strict fails a resource, without writing it, when the platform
+	// drops any of its fields.
+	Strict bool
+	// Scope selects the settings file for types the platform can also
+	// install locally (library.GetLocalOutputPath); empty means
+	// core.ScopeProject.
+	Scope core.Scope
+	// Vars are values for the variables resources declare, shared by
+	// every ref; each resource uses the ones it declares.
+	Vars map[string]string
 }
 
 // Service is the per-call contract for resource installation.
@@ -88,6 +98,15 @@ func NewService(p *parser.Parser, s *renderer.Serializer) Service {
 // supplied library, derives its output path, fails fast on existing
 // files unless --force or --dry-run, then runs the canonical
 // load → render → write pipeline under the matching output directory.
+// Each result lists the fields the platform drops, including under
+// --dry-run; with Strict any dropped field fails the resource.
+// Resources whose platform layout shares one file (e.g. Codex memory in
+// AGENTS.md) are merged into it as marked sections instead, so an
+// existing file is never an error for them; there Force only resolves
+// settings conflicts in the resource's favor.
+//
+// Templates come from the library first (see templateOptions), then
+// from the serializer's own override directories.
 //
 // Per-ref errors are recorded in result.Error and the loop continues
 // so the partial-success aggregate is consistent. The error return
@@ -123,7 +142,7 @@ func (i *installService) Initialize(ctx context.Context, req *Request) ([]core.I
 			continue
 		}
 
-		outputPath, err := library.GetOutputPath(typ, name, req.Platform, req.OutputDir)
+		outputPath, doc, err := i.resolveOutputPath(ctx, req, inputPath, typ, name)
 		if err != nil {
 			result.Error = err
 			results = append(results, result)
@@ -131,7 +150,12 @@ func (i *installService) Initialize(ctx context.Context, req *Request) ([]core.I
 		}
 		result.OutputPath = outputPath
 
-		if !req.DryRun && !req.Force {
+		mergeLayout, merge := library.MergedOutputLayout(typ, req.Platform)
+		if mem, ok := doc.(*parser.CanonicalMemory); ok {
+			mergeLayout, merge = library.MergedMemoryLayout(req.Platform, mem.Paths)
+		}
+
+		if !req.DryRun && !req.Force && !merge {
 			if _, err := os.Stat(outputPath); err == nil {
 				result.Error = core.NewFileError(outputPath, "write", "file exists (use --force to overwrite)", nil)
 				results = append(results, result)
@@ -139,25 +163,46 @@ func (i *installService) Initialize(ctx context.Context, req *Request) ([]core.I
 			}
 		}
 
-		if req.DryRun {
-			results = append(results, result)
-			continue
+		if doc == nil {
+			doc, err = i.parser.LoadDocumentWith(ctx, inputPath, req.Platform, parser.Options{Vars: req.Vars})
+			if err != nil {
+				result.Error = err
+				results = append(results, result)
+				continue
+			}
 		}
 
-		doc, err := i.parser.LoadDocument(ctx, inputPath, req.Platform)
+		result.Dropped, err = i.serializer.DroppedFields(doc, req.Platform)
+		if err == nil && req.Strict {
+			err = core.CheckLosses(req.Platform, result.Dropped)
+		}
 		if err != nil {
 			result.Error = err
 			results = append(results, result)
 			continue
 		}
 
-		rendered, err := i.serializer.RenderDocument(ctx, doc, req.Platform)
+		if req.DryRun {
+			results = append(results, result)
+			continue
+		}
+
+		rendered, err := i.serializer.RenderDocumentWith(ctx, doc, req.Platform, templateOptions(req, typ, name))
 		if err != nil {
 			result.Error = err
 			results = append(results, result)
 			continue
 		}
 
+		if merge {
+			rendered, err = MergeIntoExisting(outputPath, ref, rendered, mergeLayout.MergeJSON, req.Force)
+			if err != nil {
+				result.Error = err
+				results = append(results, result)
+				continue
+			}
+		}
+
 		outputDir := filepath.Dir(outputPath)
 		if err := os.MkdirAll(outputDir, 0o755); err != nil { //nolint:gosec // G301: user-owned output directory; 0755 is standard permission
 			result.Error = core.NewFileError(outputPath, "mkdir", "failed to create output directory", err)
@@ -176,3 +221,71 @@ func (i *installService) Initialize(ctx context.Context, req *Request) ([]core.I
 
 	return results, nil
 }
+
+// templateOptions returns the library's template overrides for one
+// resource: its per-platform template from library.yaml when set, else
+// the library's templates directory when set. The library's model
+// aliases ride along.
+func templateOptions(req *Request, typ, name string) renderer.TemplateOptions {
+	opts := renderer.TemplateOptions{
+		File:   req.Library.ResourceTemplate(typ, name, req.Platform),
+		Models: req.Library.Models,
+	}
+	if dir := req.Library.TemplatesDir(); dir != "" {
+		opts.Dirs = []string{dir}
+	}
+	return opts
+}
+
+// resolveOutputPath derives the output path for one resource, in the
+// request's scope for types the platform can install locally. Memory is
+// loaded up front because its paths can decide where it belongs
+// (library.GetMemoryOutputPath: Copilot's repository-wide file, Codex's
+// per-directory AGENTS.md); the loaded document is returned so
+// Initialize does not parse it twice. Other types return a nil document.
+func (i *installService) resolveOutputPath(ctx context.Context, req *Request, inputPath, typ, name string) (string, interface{}, error) {
+	getOutputPath := library.GetOutputPath
+	if req.Scope == core.ScopeLocal {
+		getOutputPath = library.GetLocalOutputPath
+	}
+	outputPath, err := getOutputPath(typ, name, req.Platform, req.OutputDir)
+	if err != nil || typ != "memory" {
+		return outputPath, nil, err //nolint:wrapcheck // typed *core.ConfigError propagates as-is
+	}
+
+	doc, err := i.parser.LoadDocumentWith(ctx, inputPath, req.Platform, parser.Options{Vars: req.Vars})
+	if err != nil {
+		return "", nil, err //nolint:wrapcheck // typed parser errors propagate as-is
+	}
+	if mem, ok := doc.(*parser.CanonicalMemory); ok {
+		outputPath, err = library.GetMemoryOutputPath(name, req.Platform, req.OutputDir, mem.Paths)
+		if err != nil {
+			return "", nil, err //nolint:wrapcheck // typed *core.ConfigError propagates as-is
+		}
+	}
+	return outputPath, doc, nil
+}
+
+// MergeIntoExisting wraps rendered in the germinator section for ref and
+// merges it into the current contents of outputPath (core.MergeSection),
+// so re-running init on a shared file such as AGENTS.md replaces only
+// germinator-owned sections. When mergeJSON is set, rendered is a JSON
+// object merged into the file with that strategy instead (core.MergeJSON),
+// as for .mcp.json; force resolves conflicts the strategy reports in the
+// rendered object's favor. A missing file merges into empty content.
+func MergeIntoExisting(outputPath, ref, rendered string, mergeJSON core.JSONMerge, force bool) (string, error) {
+	existing, err := os.ReadFile(outputPath) //nolint:gosec // G304: output path derived from the user's output directory
+	if err != nil && !os.IsNotExist(err) {
+		return "", core.NewFileError(outputPath, "read", "failed to read existing output file", err)
+	}
+	var merged string
+	if mergeJSON != core.JSONMergeNone {
+		merged, err = core.MergeJSON(mergeJSON, string(existing), rendered, force)
+	} else {
+		merged, err = core.MergeSection(string(existing), ref, rendered)
+	}
+	if err != nil {
+		return "", core.NewFileError(outputPath, "write", "cannot merge into existing file", err)
+	}
+	return merged, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -259,3 +260,592 @@ func TestNewService_ImplementsService(t *testing.T) {
 	s := NewService(parser.NewParser(), renderer.NewSerializer())
 	assert.NotNil(t, s, "NewService must return a non-nil Service")
 }
+
+func TestService_Initialize_CopilotMemoryPaths(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		paths    string
+		wantPath string
+	}{
+		{"unscoped memory is repository-wide", "", ".github/copilot-instructions.md"},
+		{"scoped memory is an instructions file", "paths:\n  - internal/**/*.go\n", ".github/instructions/style.instructions.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			libDir := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(libDir, "memory"), 0o750))
+			body := "---\n" + tt.paths + "content: |\n  Style rules\n---\nStyle rules\n"
+			require.NoError(t, os.WriteFile(filepath.Join(libDir, "memory", "memory-style.md"), []byte(body), 0o600))
+			lib := &library.Library{
+				Version:  "1",
+				RootPath: libDir,
+				Resources: map[string]map[string]library.Resource{
+					"memory": {"style": {Path: "memory/memory-style.md", Description: "style"}},
+				},
+				Presets: map[string]library.Preset{},
+			}
+
+			outDir := t.TempDir()
+			results, err := newInstallTestService().Initialize(context.Background(), &Request{
+				Library:   lib,
+				Platform:  core.PlatformCopilot,
+				OutputDir: outDir,
+				Refs:      []string{"memory/style"},
+			})
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			require.NoError(t, results[0].Error)
+			assert.Equal(t, filepath.Join(outDir, tt.wantPath), results[0].OutputPath)
+			_, statErr := os.Stat(results[0].OutputPath)
+			assert.NoError(t, statErr)
+		})
+	}
+}
+
+// writeMemoryLibrary scaffolds a library whose memory resources are
+// given as name → canonical document body.
+func writeMemoryLibrary(t *testing.T, memories map[string]string) *library.Library {
+	t.Helper()
+	libDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(libDir, "memory"), 0o750))
+	lib := &library.Library{
+		Version:   "1",
+		RootPath:  libDir,
+		Resources: map[string]map[string]library.Resource{"memory": {}},
+		Presets:   map[string]library.Preset{},
+	}
+	for name, body := range memories {
+		rel := "memory/memory-" + name + ".md"
+		require.NoError(t, os.WriteFile(filepath.Join(libDir, filepath.FromSlash(rel)), []byte(body), 0o600))
+		lib.Resources["memory"][name] = library.Resource{Path: rel, Description: name}
+	}
+	return lib
+}
+
+func TestService_Initialize_CodexMergesAgentsMD(t *testing.T) {
+	t.Parallel()
+
+	lib := writeMemoryLibrary(t, map[string]string{
+		"style":   "Be concise.\n",
+		"testing": "Run the tests.\n",
+		"api":     "---\npaths:\n  - internal/api/**/*.go\n---\nVersion every route.\n",
+	})
+
+	outDir := t.TempDir()
+	agentsMD := filepath.Join(outDir, "AGENTS.md")
+	require.NoError(t, os.WriteFile(agentsMD, []byte("# Project\n\nHand-written notes.\n"), 0o600))
+
+	req := &Request{
+		Library:   lib,
+		Platform:  core.PlatformCodex,
+		OutputDir: outDir,
+		Refs:      []string{"memory/style", "memory/testing", "memory/api"},
+	}
+	results, err := newInstallTestService().Initialize(context.Background(), req)
+	require.NoError(t, err)
+	for _, r := range results {
+		require.NoError(t, r.Error, r.Ref)
+	}
+
+	want := "# Project\n\nHand-written notes.\n\n" +
+		"<!-- germinator:begin memory/style -->\nBe concise.\n<!-- germinator:end memory/style -->\n\n" +
+		"<!-- germinator:begin memory/testing -->\nRun the tests.\n<!-- germinator:end memory/testing -->\n"
+	got, err := os.ReadFile(agentsMD)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got), "existing text must survive; sections are appended")
+
+	nested, err := os.ReadFile(filepath.Join(outDir, "internal", "api", "AGENTS.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(nested), "Version every route.")
+
+	// Re-running updates germinator sections in place without --force.
+	require.NoError(t, os.WriteFile(filepath.Join(lib.RootPath, "memory", "memory-style.md"),
+		[]byte("Be brief.\n"), 0o600))
+	results, err = newInstallTestService().Initialize(context.Background(), req)
+	require.NoError(t, err)
+	for _, r := range results {
+		require.NoError(t, r.Error, r.Ref)
+	}
+	got, err = os.ReadFile(agentsMD)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(want, "Be concise.", "Be brief.", 1), string(got))
+}
+
+func TestService_Initialize_CopilotMergesInstructions(t *testing.T) {
+	t.Parallel()
+
+	lib := writeMemoryLibrary(t, map[string]string{
+		"style":   "Be concise.\n",
+		"testing": "Run the tests.\n",
+	})
+
+	outDir := t.TempDir()
+	instructions := filepath.Join(outDir, ".github", "copilot-instructions.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(instructions), 0o750))
+	require.NoError(t, os.WriteFile(instructions, []byte("Hand-written notes.\n"), 0o600))
+
+	results, err := newInstallTestService().Initialize(context.Background(), &Request{
+		Library:   lib,
+		Platform:  core.PlatformCopilot,
+		OutputDir: outDir,
+		Refs:      []string{"memory/style", "memory/testing"},
+	})
+	require.NoError(t, err)
+	for _, r := range results {
+		require.NoError(t, r.Error, r.Ref)
+		assert.Equal(t, instructions, r.OutputPath)
+	}
+
+	want := "Hand-written notes.\n\n" +
+		"<!-- germinator:begin memory/style -->\nBe concise.\n<!-- germinator:end memory/style -->\n\n" +
+		"<!-- germinator:begin memory/testing -->\nRun the tests.\n<!-- germinator:end memory/testing -->\n"
+	got, err := os.ReadFile(instructions)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got), "both unscoped memories share the file")
+}
+
+func TestService_Initialize_GeminiMergesGeminiMD(t *testing.T) {
+	t.Parallel()
+
+	lib := writeMemoryLibrary(t, map[string]string{
+		"style":   "---\npaths:\n  - docs/style.md\n---\nBe concise.\n",
+		"testing": "Run the tests.\n",
+	})
+
+	outDir := t.TempDir()
+	results, err := newInstallTestService().Initialize(context.Background(), &Request{
+		Library:   lib,
+		Platform:  core.PlatformGemini,
+		OutputDir: outDir,
+		Refs:      []string{"memory/style", "memory/testing"},
+	})
+	require.NoError(t, err)
+	geminiMD := filepath.Join(outDir, "GEMINI.md")
+	for _, r := range results {
+		require.NoError(t, r.Error, r.Ref)
+		assert.Equal(t, geminiMD, r.OutputPath)
+	}
+
+	want := "<!-- germinator:begin memory/style -->\n@docs/style.md\n\nBe concise.\n<!-- germinator:end memory/style -->\n\n" +
+		"<!-- germinator:begin memory/testing -->\nRun the tests.\n<!-- germinator:end memory/testing -->\n"
+	got, err := os.ReadFile(geminiMD)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got), "both memories share GEMINI.md")
+}
+
+func TestService_Initialize_CodexDamagedSection(t *testing.T) {
+	t.Parallel()
+
+	lib := writeMemoryLibrary(t, map[string]string{"style": "Be concise.\n"})
+	outDir := t.TempDir()
+	agentsMD := filepath.Join(outDir, "AGENTS.md")
+	damaged := "<!-- germinator:begin memory/style -->\nOld.\nHand-written text after a lost end marker.\n"
+	require.NoError(t, os.WriteFile(agentsMD, []byte(damaged), 0o600))
+
+	results, err := newInstallTestService().Initialize(context.Background(), &Request{
+		Library:   lib,
+		Platform:  core.PlatformCodex,
+		OutputDir: outDir,
+		Refs:      []string{"memory/style"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	var fileErr *core.FileError
+	require.ErrorAs(t, results[0].Error, &fileErr)
+
+	got, err := os.ReadFile(agentsMD)
+	require.NoError(t, err)
+	assert.Equal(t, damaged, string(got), "a damaged file must not be rewritten")
+}
+
+func TestService_Initialize_DroppedFields(t *testing.T) {
+	t.Parallel()
+
+	libDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(libDir, "skills"), 0o750))
+	body := "---\nname: commit\ndescription: Commit changes\nmodel: sonnet\n---\nBody\n"
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "skills", "skill-commit.md"), []byte(body), 0o600))
+	lib := &library.Library{
+		Version:   "1",
+		RootPath:  libDir,
+		Resources: map[string]map[string]library.Resource{"skill": {"commit": {Path: "skills/skill-commit.md"}}},
+		Presets:   map[string]library.Preset{},
+	}
+
+	tests := []struct {
+		name   string
+		strict bool
+		dryRun bool
+	}{
+		{name: "reported"},
+		{name: "reported on dry run", dryRun: true},
+		{name: "strict fails", strict: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			outDir := t.TempDir()
+			results, err := newInstallTestService().Initialize(context.Background(), &Request{
+				Library:   lib,
+				Platform:  core.PlatformOpenCode,
+				OutputDir: outDir,
+				Refs:      []string{"skill/commit"},
+				Strict:    tt.strict,
+				DryRun:    tt.dryRun,
+			})
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+
+			r := results[0]
+			require.Len(t, r.Dropped, 1)
+			assert.Equal(t, core.FieldLoss{Field: "model", Value: "sonnet", Reason: "OpenCode skills run on the invoking agent's model"}, r.Dropped[0])
+			if tt.strict {
+				var te *core.TransformError
+				require.ErrorAs(t, r.Error, &te)
+				assert.NoFileExists(t, r.OutputPath, "strict must not write a lossy rendering")
+				return
+			}
+			assert.NoError(t, r.Error)
+		})
+	}
+}
+
+func TestService_Initialize_MergesMCPServers(t *testing.T) {
+	t.Parallel()
+
+	libDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(libDir, "mcp"), 0o750))
+	lib := &library.Library{
+		Version:   "1",
+		RootPath:  libDir,
+		Resources: map[string]map[string]library.Resource{"mcp": {}},
+		Presets:   map[string]library.Preset{},
+	}
+	servers := map[string]string{
+		"github": "---\nname: github\ncommand: npx\nargs:\n  - \"@scope/server-github\"\n---\n",
+		"docs":   "---\nname: docs\nurl: https://example.com/mcp?a=1&b=2\n---\n",
+	}
+	for name, body := range servers {
+		rel := "mcp/mcp-" + name + ".md"
+		require.NoError(t, os.WriteFile(filepath.Join(libDir, filepath.FromSlash(rel)), []byte(body), 0o600))
+		lib.Resources["mcp"][name] = library.Resource{Path: rel, Description: name}
+	}
+
+	tests := []struct {
+		platform string
+		file     string
+		existing string
+		want     string
+	}{
+		{
+			platform: core.PlatformClaudeCode,
+			file:     ".mcp.json",
+			existing: `{"mcpServers": {"local": {"command": "mine"}}}`,
+			want: `{
+  "mcpServers": {
+    "local": {
+      "command": "mine"
+    },
+    "github": {
+      "args": [
+        "@scope/server-github"
+      ],
+      "command": "npx"
+    },
+    "docs": {
+      "type": "http",
+      "url": "https://example.com/mcp?a=1&b=2"
+    }
+  }
+}
+`,
+		},
+		{
+			platform: core.PlatformOpenCode,
+			file:     "opencode.json",
+			existing: `{"$schema": "https://opencode.ai/config.json", "theme": "dark"}`,
+			want: `{
+  "$schema": "https://opencode.ai/config.json",
+  "theme": "dark",
+  "mcp": {
+    "github": {
+      "command": [
+        "npx",
+        "@scope/server-github"
+      ],
+      "enabled": true,
+      "type": "local"
+    },
+    "docs": {
+      "enabled": true,
+      "type": "remote",
+      "url": "https://example.com/mcp?a=1&b=2"
+    }
+  }
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			t.Parallel()
+
+			outDir := t.TempDir()
+			target := filepath.Join(outDir, tt.file)
+			require.NoError(t, os.WriteFile(target, []byte(tt.existing), 0o600))
+
+			req := &Request{
+				Library:   lib,
+				Platform:  tt.platform,
+				OutputDir: outDir,
+				Refs:      []string{"mcp/github", "mcp/docs"},
+			}
+			for range 2 {
+				results, err := newInstallTestService().Initialize(context.Background(), req)
+				require.NoError(t, err)
+				for _, r := range results {
+					require.NoError(t, r.Error, r.Ref)
+					assert.Equal(t, target, r.OutputPath)
+				}
+				got, err := os.ReadFile(target)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(got), "re-running must not duplicate entries")
+			}
+		})
+	}
+}
+
+func TestService_Initialize_Hooks(t *testing.T) {
+	t.Parallel()
+
+	libDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(libDir, "hooks"), 0o750))
+	lib := &library.Library{
+		Version:   "1",
+		RootPath:  libDir,
+		Resources: map[string]map[string]library.Resource{"hook": {}},
+		Presets:   map[string]library.Preset{},
+	}
+	hooks := map[string]string{
+		"gofmt":  "---\nname: gofmt\nevent: PostToolUse\nmatcher: Edit|Write\ncommand: gofmt -w .\n---\n",
+		"notify": "---\nname: notify\nevent: Stop\ncommand: notify-send done\n---\n",
+	}
+	for name, body := range hooks {
+		rel := "hooks/hook-" + name + ".md"
+		require.NoError(t, os.WriteFile(filepath.Join(libDir, filepath.FromSlash(rel)), []byte(body), 0o600))
+		lib.Resources["hook"][name] = library.Resource{Path: rel, Description: name}
+	}
+	refs := []string{"hook/gofmt", "hook/notify"}
+
+	t.Run("claude-code merges into settings.json", func(t *testing.T) {
+		t.Parallel()
+
+		outDir := t.TempDir()
+		settings := filepath.Join(outDir, ".claude", "settings.json")
+		require.NoError(t, os.MkdirAll(filepath.Dir(settings), 0o750))
+		require.NoError(t, os.WriteFile(settings, []byte(`{"permissions": {"allow": ["Bash(ls)"]}}`), 0o600))
+
+		req := &Request{Library: lib, Platform: core.PlatformClaudeCode, OutputDir: outDir, Refs: refs}
+		for range 2 {
+			results, err := newInstallTestService().Initialize(context.Background(), req)
+			require.NoError(t, err)
+			for _, r := range results {
+				require.NoError(t, r.Error, r.Ref)
+			}
+		}
+
+		got, err := os.ReadFile(settings)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+  "permissions": {"allow": ["Bash(ls)"]},
+  "hooks": {
+    "PostToolUse": [{"matcher": "Edit|Write", "hooks": [{"type": "command", "command": "gofmt -w ."}]}],
+    "Stop": [{"hooks": [{"type": "command", "command": "notify-send done"}]}]
+  }
+}`, string(got))
+	})
+
+	t.Run("opencode writes one plugin per hook", func(t *testing.T) {
+		t.Parallel()
+
+		outDir := t.TempDir()
+		req := &Request{Library: lib, Platform: core.PlatformOpenCode, OutputDir: outDir, Refs: refs}
+		results, err := newInstallTestService().Initialize(context.Background(), req)
+		require.NoError(t, err)
+		for _, r := range results {
+			require.NoError(t, r.Error, r.Ref)
+		}
+		assert.FileExists(t, filepath.Join(outDir, ".opencode", "plugins", "gofmt.js"))
+		assert.FileExists(t, filepath.Join(outDir, ".opencode", "plugins", "notify.js"))
+	})
+}
+
+func TestService_Initialize_Settings(t *testing.T) {
+	t.Parallel()
+
+	libDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(libDir, "settings"), 0o750))
+	body := "---\nname: team\nmodel: anthropic/claude-sonnet-4-5\npermissions:\n  bash:\n    \"git push*\": deny\n---\n"
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "settings", "settings-team.md"), []byte(body), 0o600))
+	lib := &library.Library{
+		Version:  "1",
+		RootPath: libDir,
+		Resources: map[string]map[string]library.Resource{
+			"settings": {"team": {Path: "settings/settings-team.md", Description: "team"}},
+		},
+		Presets: map[string]library.Preset{},
+	}
+	refs := []string{"settings/team"}
+
+	writeSettings := func(t *testing.T, path, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	t.Run("merges into existing settings", func(t *testing.T) {
+		t.Parallel()
+
+		outDir := t.TempDir()
+		settings := filepath.Join(outDir, ".claude", "settings.json")
+		writeSettings(t, settings, `{"permissions": {"deny": ["Bash(rm:*)"]}, "theme": "dark"}`)
+
+		req := &Request{Library: lib, Platform: core.PlatformClaudeCode, OutputDir: outDir, Refs: refs}
+		for range 2 {
+			results, err := newInstallTestService().Initialize(context.Background(), req)
+			require.NoError(t, err)
+			require.NoError(t, results[0].Error)
+		}
+
+		got, err := os.ReadFile(settings)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+  "permissions": {"deny": ["Bash(rm:*)", "Bash(git push:*)"]},
+  "theme": "dark",
+  "model": "anthropic/claude-sonnet-4-5"
+}`, string(got))
+	})
+
+	t.Run("conflicts leave the file unchanged unless forced", func(t *testing.T) {
+		t.Parallel()
+
+		outDir := t.TempDir()
+		settings := filepath.Join(outDir, ".claude", "settings.json")
+		existing := `{"model": "opus"}`
+		writeSettings(t, settings, existing)
+
+		req := &Request{Library: lib, Platform: core.PlatformClaudeCode, OutputDir: outDir, Refs: refs}
+		results, err := newInstallTestService().Initialize(context.Background(), req)
+		require.NoError(t, err)
+		var cfgErr *core.ConfigError
+		require.ErrorAs(t, results[0].Error, &cfgErr)
+		got, err := os.ReadFile(settings)
+		require.NoError(t, err)
+		assert.Equal(t, existing, string(got))
+
+		req.Force = true
+		results, err = newInstallTestService().Initialize(context.Background(), req)
+		require.NoError(t, err)
+		require.NoError(t, results[0].Error)
+		got, err = os.ReadFile(settings)
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `"model": "anthropic/claude-sonnet-4-5"`)
+	})
+
+	t.Run("local scope writes settings.local.json", func(t *testing.T) {
+		t.Parallel()
+
+		outDir := t.TempDir()
+		req := &Request{Library: lib, Platform: core.PlatformClaudeCode, OutputDir: outDir, Refs: refs, Scope: core.ScopeLocal}
+		results, err := newInstallTestService().Initialize(context.Background(), req)
+		require.NoError(t, err)
+		require.NoError(t, results[0].Error)
+		assert.Equal(t, filepath.Join(outDir, ".claude", "settings.local.json"), results[0].OutputPath)
+		assert.NoFileExists(t, filepath.Join(outDir, ".claude", "settings.json"))
+	})
+
+	t.Run("opencode merges into opencode.json", func(t *testing.T) {
+		t.Parallel()
+
+		outDir := t.TempDir()
+		config := filepath.Join(outDir, "opencode.json")
+		writeSettings(t, config, `{"mcp": {"docs": {"type": "remote", "url": "u"}}}`)
+
+		req := &Request{Library: lib, Platform: core.PlatformOpenCode, OutputDir: outDir, Refs: refs, Scope: core.ScopeLocal}
+		results, err := newInstallTestService().Initialize(context.Background(), req)
+		require.NoError(t, err)
+		require.NoError(t, results[0].Error)
+		assert.Equal(t, config, results[0].OutputPath, "opencode has no local settings file")
+
+		got, err := os.ReadFile(config)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+  "mcp": {"docs": {"type": "remote", "url": "u"}},
+  "model": "anthropic/claude-sonnet-4-5",
+  "permission": {"bash": {"git push*": "deny"}}
+}`, string(got))
+	})
+}
+
+func TestService_Initialize_TemplateOverrides(t *testing.T) {
+	t.Parallel()
+
+	writeTemplate := func(path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	libDir := installFixtureLibrary(t, "commit")
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "skills", "skill-review.md"),
+		[]byte("---\nname: review\ndescription: review fixture\n---\nBody\n"), 0o600))
+	writeTemplate(filepath.Join(libDir, "templates", "opencode", "skill.tmpl"), "library {{.Doc.Name}}")
+	writeTemplate(filepath.Join(libDir, "templates", "review.tmpl"), "resource {{.Doc.Name}}")
+
+	lib, err := library.LoadLibrary(context.Background(), libDir)
+	require.NoError(t, err)
+	lib.Templates = "templates"
+	lib.Resources["skill"]["review"] = library.Resource{
+		Path:      "skills/skill-review.md",
+		Templates: map[string]string{core.PlatformOpenCode: "templates/review.tmpl"},
+	}
+
+	userDir := t.TempDir()
+	writeTemplate(filepath.Join(userDir, "opencode", "skill.tmpl"), "user {{.Doc.Name}}")
+	writeTemplate(filepath.Join(userDir, "claude-code", "skill.tmpl"), "user {{.Doc.Name}}")
+	svc := NewService(parser.NewParser(), renderer.NewSerializer(userDir))
+
+	tests := []struct {
+		platform string
+		ref      string
+		want     string
+	}{
+		{core.PlatformOpenCode, "skill/review", "resource review"},
+		{core.PlatformOpenCode, "skill/commit", "library commit"},
+		{core.PlatformClaudeCode, "skill/commit", "user commit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.platform+"/"+tt.ref, func(t *testing.T) {
+			results, err := svc.Initialize(context.Background(), &Request{
+				Library:   lib,
+				Platform:  tt.platform,
+				OutputDir: t.TempDir(),
+				Refs:      []string{tt.ref},
+			})
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			require.NoError(t, results[0].Error)
+
+			content, err := os.ReadFile(results[0].OutputPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(content))
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"sync"
 
 	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 	"golang.org/x/sync/errgroup"
 	yaml "gopkg.in/yaml.v3"
 )
@@ -169,11 +170,10 @@ func DetectPlatform(source string) string {
 
 	// Check filename for platform indicators
 	lower := strings.ToLower(source)
-	if strings.Contains(lower, "opencode") {
-		return "opencode"
-	}
-	if strings.Contains(lower, "claude-code") || strings.Contains(lower, "claudecode") {
-		return "claude-code"
+	for _, id := range platforms.IDs() {
+		if strings.Contains(lower, id) || strings.Contains(lower, strings.ReplaceAll(id, "-", "")) {
+			return id
+		}
 	}
 
 	return ""
@@ -358,6 +358,30 @@ func DetectTypeFromFilename(filepath string) string {
 		return "memory"
 	}
 
+	// Check mcp patterns
+	if matched, _ := regexp.MatchString(`mcp-.*\..*$`, base); matched {
+		return "mcp"
+	}
+	if matched, _ := regexp.MatchString(`.*-mcp\..*$`, base); matched {
+		return "mcp"
+	}
+
+	// Check hook patterns
+	if matched, _ := regexp.MatchString(`hook-.*\..*$`, base); matched {
+		return "hook"
+	}
+	if matched, _ := regexp.MatchString(`.*-hook\..*$`, base); matched {
+		return "hook"
+	}
+
+	// Check settings patterns
+	if matched, _ := regexp.MatchString(`settings-.*\..*$`, base); matched {
+		return "settings"
+	}
+	if matched, _ := regexp.MatchString(`.*-settings\..*$`, base); matched {
+		return "settings"
+	}
+
 	// Check skill patterns
 	if matched, _ := regexp.MatchString(`skill-.*\..*$`, base); matched {
 		return "skill"
@@ -677,7 +701,7 @@ func processBatchAddFile(ctx context.Context, lib *Library, source string, opts
 	if opts.DryRun {
 		result.Added = append(result.Added, BatchAddSuccess{
 			Ref:  resourceKey,
-			Path: filepath.Join(lib.RootPath, docType+"s", name+".md"),
+			Path: filepath.Join(lib.RootPath, ResourceType(docType).Directory(), name+".md"),
 		})
 		result.Summary.Added++
 		return nil
@@ -709,7 +733,7 @@ func processBatchAddFile(ctx context.Context, lib *Library, source string, opts
 
 	result.Added = append(result.Added, BatchAddSuccess{
 		Ref:  resourceKey,
-		Path: filepath.Join(lib.RootPath, docType+"s", name+".md"),
+		Path: filepath.Join(lib.RootPath, ResourceType(docType).Directory(), name+".md"),
 	})
 	result.Summary.Added++
 	return nil
@@ -45,21 +45,17 @@ func CreateLibrary(ctx context.Context, opts CreateOptions, stdout io.Writer) er
 		if stdout != nil {
 			_, _ = fmt.Fprintln(stdout, "Would create library at:", opts.Path)
 			_, _ = fmt.Fprintln(stdout, "  -", filepath.Join(opts.Path, "library.yaml"))
-			_, _ = fmt.Fprintln(stdout, "  -", filepath.Join(opts.Path, "skills")+"/")
-			_, _ = fmt.Fprintln(stdout, "  -", filepath.Join(opts.Path, "agents")+"/")
-			_, _ = fmt.Fprintln(stdout, "  -", filepath.Join(opts.Path, "commands")+"/")
-			_, _ = fmt.Fprintln(stdout, "  -", filepath.Join(opts.Path, "memory")+"/")
+			for _, rt := range ValidResourceTypes {
+				_, _ = fmt.Fprintln(stdout, "  -", filepath.Join(opts.Path, rt.Directory())+"/")
+			}
 		}
 		return nil
 	}
 
 	// Create directory structure
-	dirs := []string{
-		opts.Path,
-		filepath.Join(opts.Path, "skills"),
-		filepath.Join(opts.Path, "agents"),
-		filepath.Join(opts.Path, "commands"),
-		filepath.Join(opts.Path, "memory"),
+	dirs := []string{opts.Path}
+	for _, rt := range ValidResourceTypes {
+		dirs = append(dirs, filepath.Join(opts.Path, rt.Directory()))
 	}
 
 	for _, dir := range dirs {
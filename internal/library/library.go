@@ -17,10 +17,13 @@ type ResourceType string
 
 // ResourceType constants define the type of resource in the library.
 const (
-	ResourceTypeSkill   ResourceType = "skill"
-	ResourceTypeAgent   ResourceType = "agent"
-	ResourceTypeCommand ResourceType = "command"
-	ResourceTypeMemory  ResourceType = "memory"
+	ResourceTypeSkill    ResourceType = "skill"
+	ResourceTypeAgent    ResourceType = "agent"
+	ResourceTypeCommand  ResourceType = "command"
+	ResourceTypeMemory   ResourceType = "memory"
+	ResourceTypeMCP      ResourceType = "mcp"
+	ResourceTypeHook     ResourceType = "hook"
+	ResourceTypeSettings ResourceType = "settings"
 )
 
 // ValidResourceTypes contains all valid resource types.
@@ -29,6 +32,21 @@ var ValidResourceTypes = []ResourceType{
 	ResourceTypeAgent,
 	ResourceTypeCommand,
 	ResourceTypeMemory,
+	ResourceTypeMCP,
+	ResourceTypeHook,
+	ResourceTypeSettings,
+}
+
+// Directory returns the library directory that holds resources of this
+// type: the plural for countable types ("skills"), the type itself for
+// memory, mcp, and settings.
+func (rt ResourceType) Directory() string {
+	switch rt {
+	case ResourceTypeMemory, ResourceTypeMCP, ResourceTypeSettings:
+		return string(rt)
+	default:
+		return string(rt) + "s"
+	}
 }
 
 // IsValid checks if the resource type is valid.
@@ -52,6 +70,10 @@ type Resource struct {
 	Path string `yaml:"path"`
 	// Description is a human-readable description of the resource.
 	Description string `yaml:"description"`
+	// Templates maps a platform ID to a template file, relative to the
+	// library root, that renders this resource for that platform instead
+	// of the platform's template for the resource type.
+	Templates map[string]string `yaml:"templates,omitempty"`
 }
 
 // Validate checks if the resource has valid fields.
@@ -62,6 +84,15 @@ func (r *Resource) Validate() error {
 	if strings.TrimSpace(r.Path) == "" {
 		return gerrors.NewValidationError("", "path", "", "resource path cannot be whitespace only")
 	}
+	for platform, tmpl := range r.Templates {
+		if !IsValidPlatform(platform) {
+			return gerrors.NewValidationError("", "templates", platform, "unknown platform for template override").
+				WithSuggestions(ValidPlatforms())
+		}
+		if strings.TrimSpace(tmpl) == "" {
+			return gerrors.NewValidationError("", "templates", platform, "template path is required")
+		}
+	}
 	return nil
 }
 
@@ -100,11 +131,37 @@ type Library struct {
 	Version string `yaml:"version"`
 	// RootPath is the absolute path to the library directory.
 	RootPath string `yaml:"-"`
+	// Templates is a template override directory, relative to the
+	// library root, laid out like the bundled set (<platform>/<type>.tmpl).
+	Templates string `yaml:"templates,omitempty"`
 	// Resources maps resource type to name to resource entry.
 	// Structure: Resources["skill"]["commit"] = Resource{Path: "skills/commit.yaml", ...}
 	Resources map[string]map[string]Resource `yaml:"resources"`
 	// Presets maps preset name to preset definition.
 	Presets map[string]Preset `yaml:"presets"`
+	// Models maps logical model names to each platform's model id. They
+	// take precedence over the built-in and config.toml aliases for the
+	// library's resources.
+	Models gerrors.ModelAliases `yaml:"models,omitempty"`
+}
+
+// TemplatesDir returns the absolute path of the library's template
+// override directory, or "" when library.yaml sets none.
+func (lib *Library) TemplatesDir() string {
+	if lib.Templates == "" {
+		return ""
+	}
+	return filepath.Join(lib.RootPath, lib.Templates)
+}
+
+// ResourceTemplate returns the absolute path of the template overriding
+// resource typ/name on platform, or "" when the resource has none.
+func (lib *Library) ResourceTemplate(typ, name, platform string) string {
+	tmpl := lib.Resources[typ][name].Templates[platform]
+	if tmpl == "" {
+		return ""
+	}
+	return filepath.Join(lib.RootPath, tmpl)
 }
 
 // ParseRef parses a resource reference in "type/name" format.
@@ -340,7 +397,7 @@ func (lib *Library) Add(ctx context.Context, req *AddRequest) error {
 		return nil
 	}
 
-	targetDir := filepath.Join(lib.RootPath, docType+"s")
+	targetDir := filepath.Join(lib.RootPath, ResourceType(docType).Directory())
 	targetFile := filepath.Join(targetDir, name+".md")
 
 	if err := os.MkdirAll(targetDir, 0o750); err != nil {
@@ -474,11 +531,9 @@ func (lib *Library) DiscoverOrphans(ctx context.Context, opts *DiscoverOptions)
 
 	result := &DiscoverResult{}
 
-	directories := map[string]string{
-		"skills":   "skill",
-		"agents":   "agent",
-		"commands": "command",
-		"memory":   "memory",
+	directories := make(map[string]string, len(ValidResourceTypes))
+	for _, rt := range ValidResourceTypes {
+		directories[rt.Directory()] = string(rt)
 	}
 
 	for dir, resType := range directories {
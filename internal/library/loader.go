@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	gerrors "gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 	yaml "gopkg.in/yaml.v3"
 )
 
@@ -16,8 +17,10 @@ const SupportedVersion = "1"
 // libraryYAML is the internal structure for YAML parsing.
 type libraryYAML struct {
 	Version   string                         `yaml:"version"`
+	Templates string                         `yaml:"templates"`
 	Resources map[string]map[string]Resource `yaml:"resources"`
 	Presets   map[string]Preset              `yaml:"presets"`
+	Models    gerrors.ModelAliases           `yaml:"models,omitempty"`
 }
 
 // LoadLibrary loads a library from the given directory path.
@@ -81,8 +84,10 @@ func LoadLibrary(ctx context.Context, path string) (*Library, error) {
 	lib := &Library{
 		Version:   libYAML.Version,
 		RootPath:  path,
+		Templates: libYAML.Templates,
 		Resources: libYAML.Resources,
 		Presets:   libYAML.Presets,
+		Models:    libYAML.Models,
 	}
 
 	// Initialize empty maps if nil
@@ -109,6 +114,10 @@ func LoadLibrary(ctx context.Context, path string) (*Library, error) {
 		}
 	}
 
+	if err := lib.Models.Validate(platforms.IDs()); err != nil {
+		return nil, fmt.Errorf("invalid models: %w", err)
+	}
+
 	// Validate presets
 	for name, preset := range lib.Presets {
 		// Ensure name matches key
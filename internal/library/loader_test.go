@@ -139,6 +139,85 @@ resources:
 	require.Error(t, err)
 }
 
+func TestLoadLibrary_TemplateOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	yamlContent := `
+version: "1"
+templates: templates
+resources:
+  agent:
+    reviewer:
+      path: agents/reviewer.md
+      templates:
+        claude-code: templates/reviewer.tmpl
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "library.yaml"), []byte(yamlContent), 0644))
+
+	lib, err := LoadLibrary(context.Background(), tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmpDir, "templates"), lib.TemplatesDir())
+	assert.Equal(t, filepath.Join(tmpDir, "templates", "reviewer.tmpl"), lib.ResourceTemplate("agent", "reviewer", "claude-code"))
+	assert.Empty(t, lib.ResourceTemplate("agent", "reviewer", "opencode"))
+	assert.Empty(t, lib.ResourceTemplate("agent", "missing", "claude-code"))
+}
+
+func TestLoadLibrary_TemplateOverrideUnknownPlatform(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	yamlContent := `
+version: "1"
+resources:
+  agent:
+    reviewer:
+      path: agents/reviewer.md
+      templates:
+        nope: templates/reviewer.tmpl
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "library.yaml"), []byte(yamlContent), 0644))
+
+	_, err := LoadLibrary(context.Background(), tmpDir)
+	var valErr *core.ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, "templates", valErr.Field())
+}
+
+func TestLoadLibrary_Models(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	yamlContent := `
+version: "1"
+models:
+  smart:
+    claude-code: opus
+    opencode: anthropic/claude-opus-4-1
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "library.yaml"), []byte(yamlContent), 0644))
+
+	lib, err := LoadLibrary(context.Background(), tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, core.ModelAliases{
+		"smart": {"claude-code": "opus", "opencode": "anthropic/claude-opus-4-1"},
+	}, lib.Models)
+}
+
+func TestLoadLibrary_ModelsUnknownPlatform(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	yamlContent := `
+version: "1"
+models:
+  smart:
+    claude: opus
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "library.yaml"), []byte(yamlContent), 0644))
+
+	_, err := LoadLibrary(context.Background(), tmpDir)
+	var cfgErr *core.ConfigError
+	require.ErrorAs(t, err, &cfgErr)
+	assert.Contains(t, err.Error(), "unknown platform")
+}
+
 func TestLoadLibrary_EmptyLibrary(t *testing.T) {
 	tmpDir := t.TempDir()
 
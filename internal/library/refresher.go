@@ -120,14 +120,14 @@ func processResource(opts RefreshOptions, lib *Library, ref, resType, name strin
 
 	// Check if file exists - if not, search directory
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		dirPath := filepath.Join(lib.RootPath, resType+"s")
+		dirPath := filepath.Join(lib.RootPath, ResourceType(resType).Directory())
 		foundPath, foundName := searchForFile(dirPath, name)
 
 		if foundPath != "" && foundName == name {
 			// Found renamed file, update path
 			filePath = foundPath
 			if !opts.DryRun {
-				res.Path = resType + "s/" + filepath.Base(foundPath)
+				res.Path = ResourceType(resType).Directory() + "/" + filepath.Base(foundPath)
 				lib.Resources[resType][name] = res
 			}
 			result.Refreshed = append(result.Refreshed, RefreshChange{
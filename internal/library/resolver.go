@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	gerrors "gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 )
 
 // ResolveResource resolves a resource reference to an absolute file path.
@@ -123,62 +124,124 @@ func ResolvePresetEntry(lib *Library, name string) (*Preset, error) {
 	return &preset, nil
 }
 
-// OutputPathConfig holds configuration for output path derivation.
-type OutputPathConfig struct {
-	// Directory is the base directory name (e.g., ".opencode")
-	Directory string
-	// Subdirectory is the resource type subdirectory (e.g., "skills", "agents")
-	Subdirectory string
-	// FileSuffix is the suffix for the output file (e.g., "/SKILL.md" or ".md")
-	FileSuffix string
-	// UseSubdirectory indicates if the resource should be in a subdirectory
-	UseSubdirectory bool
-}
-
-// PlatformOutputPaths maps platforms to their output path configurations by resource type.
-var PlatformOutputPaths = map[string]map[ResourceType]OutputPathConfig{
-	"opencode": {
-		ResourceTypeSkill:   {Directory: ".opencode", Subdirectory: "skills", FileSuffix: "/SKILL.md", UseSubdirectory: true},
-		ResourceTypeAgent:   {Directory: ".opencode", Subdirectory: "agents", FileSuffix: ".md", UseSubdirectory: false},
-		ResourceTypeCommand: {Directory: ".opencode", Subdirectory: "commands", FileSuffix: ".md", UseSubdirectory: false},
-		ResourceTypeMemory:  {Directory: ".opencode", Subdirectory: "memory", FileSuffix: ".md", UseSubdirectory: false},
-	},
-	"claude-code": {
-		ResourceTypeSkill:   {Directory: ".claude", Subdirectory: "skills", FileSuffix: "/SKILL.md", UseSubdirectory: true},
-		ResourceTypeAgent:   {Directory: ".claude", Subdirectory: "agents", FileSuffix: ".md", UseSubdirectory: false},
-		ResourceTypeCommand: {Directory: ".claude", Subdirectory: "commands", FileSuffix: ".md", UseSubdirectory: false},
-		ResourceTypeMemory:  {Directory: ".claude", Subdirectory: "memory", FileSuffix: ".md", UseSubdirectory: false},
-	},
-}
-
 // GetOutputPath returns the platform-specific output path for a resource.
 // The outputDir is the base directory (e.g., "." for current directory).
+// The layout comes from the platform registry (platforms.Platform.OutputPath).
 func GetOutputPath(typ, name, platform, outputDir string) (string, error) {
 	resourceType := ResourceType(typ)
 	if !resourceType.IsValid() {
 		return "", gerrors.NewConfigError("resource-type", typ, "invalid resource type")
 	}
 
-	platformConfigs, ok := PlatformOutputPaths[platform]
+	target, ok := platforms.Lookup(platform)
 	if !ok {
 		return "", gerrors.NewConfigError("platform", platform, "unknown platform")
 	}
 
-	config, ok := platformConfigs[resourceType]
+	layout, ok := target.OutputPath(typ)
 	if !ok {
 		return "", gerrors.NewConfigError("resource-type", typ, "unsupported resource type for platform")
 	}
 
-	var path string
-	if config.UseSubdirectory {
-		// e.g., .opencode/skills/commit/SKILL.md
-		path = filepath.Join(outputDir, config.Directory, config.Subdirectory, name, config.FileSuffix)
-	} else {
-		// e.g., .opencode/agents/commit.md
-		path = filepath.Join(outputDir, config.Directory, config.Subdirectory, name+config.FileSuffix)
+	return filepath.Join(outputDir, filepath.FromSlash(gerrors.ResolveOutputPath(layout, name))), nil
+}
+
+// GetUnscopedOutputPath returns the output path for a document without a
+// path scope (memory with no paths). Platforms that keep such documents
+// in a dedicated location (Copilot's .github/copilot-instructions.md)
+// override it; everywhere else it equals GetOutputPath.
+func GetUnscopedOutputPath(typ, name, platform, outputDir string) (string, error) {
+	scoped, err := GetOutputPath(typ, name, platform, outputDir)
+	if err != nil {
+		return "", err
+	}
+	target, _ := platforms.Lookup(platform)
+	layout, ok := target.UnscopedOutputPath(typ)
+	if !ok {
+		return scoped, nil
+	}
+	return filepath.Join(outputDir, filepath.FromSlash(gerrors.ResolveOutputPath(layout, name))), nil
+}
+
+// GetLocalOutputPath returns the output path for a resource installed
+// with core.ScopeLocal. Platforms that keep personal settings in a
+// separate file (Claude Code's .claude/settings.local.json) override
+// the layout of the types stored there; everywhere else it equals
+// GetOutputPath.
+func GetLocalOutputPath(typ, name, platform, outputDir string) (string, error) {
+	shared, err := GetOutputPath(typ, name, platform, outputDir)
+	if err != nil {
+		return "", err
+	}
+	target, _ := platforms.Lookup(platform)
+	layout, ok := target.LocalOutputPath(typ)
+	if !ok {
+		return shared, nil
+	}
+	return filepath.Join(outputDir, filepath.FromSlash(gerrors.ResolveOutputPath(layout, name))), nil
+}
+
+// GetMemoryOutputPath returns the output path for a memory document with
+// the given paths. Memory without paths goes to GetUnscopedOutputPath;
+// on platforms whose memory layout nests by paths (Codex's per-directory
+// AGENTS.md) scoped memory goes under the directory its paths share
+// (core.PathsDirectory); otherwise it is GetOutputPath.
+func GetMemoryOutputPath(name, platform, outputDir string, paths []string) (string, error) {
+	if len(paths) == 0 {
+		return GetUnscopedOutputPath("memory", name, platform, outputDir)
+	}
+	scoped, err := GetOutputPath("memory", name, platform, outputDir)
+	if err != nil {
+		return "", err
+	}
+	target, _ := platforms.Lookup(platform)
+	layout, _ := target.OutputPath("memory")
+	if !layout.NestByPaths {
+		return scoped, nil
+	}
+	dir := filepath.FromSlash(gerrors.PathsDirectory(paths))
+	return filepath.Join(outputDir, dir, filepath.FromSlash(gerrors.ResolveOutputPath(layout, name))), nil
+}
+
+// IsMergedOutput reports whether resources of typ share one output file
+// on platform, each written as its own marked section
+// (core.OutputPathConfig.Merge). Unknown platforms and types report false.
+func IsMergedOutput(typ, platform string) bool {
+	_, ok := MergedOutputLayout(typ, platform)
+	return ok
+}
+
+// MergedOutputLayout returns the layout of typ on platform when its
+// resources share one output file, so callers can tell section merging
+// from JSON merging (core.OutputPathConfig.MergeJSON).
+func MergedOutputLayout(typ, platform string) (gerrors.OutputPathConfig, bool) {
+	target, ok := platforms.Lookup(platform)
+	if !ok {
+		return gerrors.OutputPathConfig{}, false
+	}
+	layout, ok := target.OutputPath(typ)
+	if !ok || !layout.Merge {
+		return gerrors.OutputPathConfig{}, false
 	}
+	return layout, true
+}
 
-	return path, nil
+// MergedMemoryLayout is MergedOutputLayout for a memory document with
+// the given paths. Memory without paths is written to the platform's
+// unscoped layout when it has one (GetUnscopedOutputPath), so that
+// layout decides whether it is merged, e.g. Copilot's shared
+// .github/copilot-instructions.md.
+func MergedMemoryLayout(platform string, paths []string) (gerrors.OutputPathConfig, bool) {
+	target, ok := platforms.Lookup(platform)
+	if !ok {
+		return gerrors.OutputPathConfig{}, false
+	}
+	if len(paths) == 0 {
+		if layout, ok := target.UnscopedOutputPath("memory"); ok {
+			return layout, layout.Merge
+		}
+	}
+	return MergedOutputLayout("memory", platform)
 }
 
 // GetOutputPaths returns all output paths for a list of resource references.
@@ -200,17 +263,13 @@ func GetOutputPaths(_ *Library, refs []string, platform, outputDir string) (map[
 
 // IsValidPlatform checks if the platform is supported.
 func IsValidPlatform(platform string) bool {
-	_, ok := PlatformOutputPaths[platform]
+	_, ok := platforms.Lookup(platform)
 	return ok
 }
 
-// ValidPlatforms returns the list of valid platforms.
+// ValidPlatforms returns the list of valid platforms in registration order.
 func ValidPlatforms() []string {
-	platforms := make([]string, 0, len(PlatformOutputPaths))
-	for p := range PlatformOutputPaths {
-		platforms = append(platforms, p)
-	}
-	return platforms
+	return platforms.IDs()
 }
 
 // ValidateRef validates a resource reference format and checks if the type is valid.
@@ -240,6 +240,85 @@ func TestGetOutputPath(t *testing.T) {
 			wantPath:  ".claude/agents/reviewer.md",
 			wantErr:   false,
 		},
+		{
+			name:      "skill to cursor",
+			typ:       "skill",
+			resName:   "commit",
+			platform:  "cursor",
+			outputDir: ".",
+			wantPath:  ".cursor/rules/skills/commit.mdc",
+			wantErr:   false,
+		},
+		{
+			name:      "memory to cursor",
+			typ:       "memory",
+			resName:   "go-style",
+			platform:  "cursor",
+			outputDir: ".",
+			wantPath:  ".cursor/rules/go-style.mdc",
+			wantErr:   false,
+		},
+		{
+			name:      "agent to cursor unsupported",
+			typ:       "agent",
+			resName:   "reviewer",
+			platform:  "cursor",
+			outputDir: ".",
+			wantErr:   true,
+		},
+		{
+			name:      "command to copilot",
+			typ:       "command",
+			resName:   "review",
+			platform:  "copilot",
+			outputDir: ".",
+			wantPath:  ".github/prompts/review.prompt.md",
+			wantErr:   false,
+		},
+		{
+			name:      "agent to copilot",
+			typ:       "agent",
+			resName:   "planner",
+			platform:  "copilot",
+			outputDir: ".",
+			wantPath:  ".github/chatmodes/planner.chatmode.md",
+			wantErr:   false,
+		},
+		{
+			name:      "memory to copilot",
+			typ:       "memory",
+			resName:   "go-style",
+			platform:  "copilot",
+			outputDir: ".",
+			wantPath:  ".github/instructions/go-style.instructions.md",
+			wantErr:   false,
+		},
+		{
+			name:      "command to gemini",
+			typ:       "command",
+			resName:   "review",
+			platform:  "gemini",
+			outputDir: ".",
+			wantPath:  ".gemini/commands/review.toml",
+			wantErr:   false,
+		},
+		{
+			name:      "memory to gemini",
+			typ:       "memory",
+			resName:   "go-style",
+			platform:  "gemini",
+			outputDir: ".",
+			wantPath:  "GEMINI.md",
+			wantErr:   false,
+		},
+		{
+			name:      "skill to gemini unsupported",
+			typ:       "skill",
+			resName:   "commit",
+			platform:  "gemini",
+			outputDir: ".",
+			wantErr:   true,
+		},
 		{
 			name:      "custom output dir",
 			typ:       "skill",
@@ -280,6 +359,89 @@ func TestGetOutputPath(t *testing.T) {
 	}
 }
 
+func TestGetUnscopedOutputPath(t *testing.T) {
+	got, err := GetUnscopedOutputPath("memory", "go-style", "copilot", "/project")
+	require.NoError(t, err)
+	assert.Equal(t, "/project/.github/copilot-instructions.md", got)
+
+	got, err = GetUnscopedOutputPath("memory", "go-style", "claude-code", ".")
+	require.NoError(t, err)
+	assert.Equal(t, ".claude/memory/go-style.md", got, "platforms without an override fall back to GetOutputPath")
+
+	_, err = GetUnscopedOutputPath("memory", "go-style", "invalid", ".")
+	require.Error(t, err)
+}
+
+func TestGetLocalOutputPath(t *testing.T) {
+	got, err := GetLocalOutputPath("settings", "team", "claude-code", "/project")
+	require.NoError(t, err)
+	assert.Equal(t, "/project/.claude/settings.local.json", got)
+
+	got, err = GetLocalOutputPath("settings", "team", "opencode", "/project")
+	require.NoError(t, err)
+	assert.Equal(t, "/project/opencode.json", got, "platforms without a local file fall back to GetOutputPath")
+
+	got, err = GetLocalOutputPath("agent", "reviewer", "claude-code", ".")
+	require.NoError(t, err)
+	assert.Equal(t, ".claude/agents/reviewer.md", got)
+}
+
+func TestGetMemoryOutputPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		paths    []string
+		want     string
+	}{
+		{"codex unscoped", "codex", nil, "/project/AGENTS.md"},
+		{"codex nested by paths", "codex", []string{"internal/api/**/*.go"}, "/project/internal/api/AGENTS.md"},
+		{"codex paths without shared directory", "codex", []string{"cmd/*.go", "main.go"}, "/project/AGENTS.md"},
+		{"copilot unscoped", "copilot", nil, "/project/.github/copilot-instructions.md"},
+		{"copilot scoped ignores directory", "copilot", []string{"internal/api/*.go"}, "/project/.github/instructions/style.instructions.md"},
+		{"opencode", "opencode", []string{"internal/api/*.go"}, "/project/.opencode/memory/style.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetMemoryOutputPath("style", tt.platform, "/project", tt.paths)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := GetMemoryOutputPath("style", "invalid", "/project", nil)
+	require.Error(t, err)
+}
+
+func TestIsMergedOutput(t *testing.T) {
+	assert.True(t, IsMergedOutput("memory", "codex"))
+	assert.False(t, IsMergedOutput("memory", "opencode"))
+	assert.False(t, IsMergedOutput("skill", "codex"), "unsupported types are not merged")
+	assert.False(t, IsMergedOutput("memory", "invalid"))
+}
+
+func TestMergedMemoryLayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		paths    []string
+		want     bool
+	}{
+		{"copilot unscoped memory shares copilot-instructions.md", "copilot", nil, true},
+		{"copilot scoped memory has its own file", "copilot", []string{"*.go"}, false},
+		{"codex memory is always merged", "codex", []string{"*.go"}, true},
+		{"opencode memory is never merged", "opencode", nil, false},
+		{"unknown platform", "invalid", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got := MergedMemoryLayout(tt.platform, tt.paths)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestIsValidPlatform(t *testing.T) {
 	assert.True(t, IsValidPlatform("opencode"), "opencode should be valid platform")
 	assert.True(t, IsValidPlatform("claude-code"), "claude-code should be valid platform")
@@ -288,7 +450,7 @@ func TestIsValidPlatform(t *testing.T) {
 
 func TestValidPlatforms(t *testing.T) {
 	platforms := ValidPlatforms()
-	assert.Len(t, platforms, 2)
+	assert.Len(t, platforms, 6)
 }
 
 func TestValidateRef(t *testing.T) {
@@ -116,7 +116,8 @@ func ValidateLibrary(lib *Library) (*ValidationResult, error) {
 	return result, nil
 }
 
-// CheckMissingFiles verifies that entries in library.yaml have corresponding files on disk.
+// CheckMissingFiles verifies that entries in library.yaml have corresponding files on disk:
+// resource files, per-resource templates, and the library's templates directory.
 func CheckMissingFiles(lib *Library) ([]Issue, error) {
 	var issues []Issue
 
@@ -136,6 +137,29 @@ func CheckMissingFiles(lib *Library) ([]Issue, error) {
 					Message:  fmt.Sprintf("resource %q references file %q which does not exist", ref, res.Path),
 				})
 			}
+			for platform, tmpl := range res.Templates {
+				if _, err := os.Stat(filepath.Join(lib.RootPath, tmpl)); os.IsNotExist(err) {
+					ref := FormatRef(typ, name)
+					issues = append(issues, Issue{
+						Type:     IssueTypeMissingFile,
+						Severity: SeverityError,
+						Ref:      ref,
+						Path:     tmpl,
+						Message:  fmt.Sprintf("resource %q references %s template %q which does not exist", ref, platform, tmpl),
+					})
+				}
+			}
+		}
+	}
+
+	if lib.Templates != "" {
+		if info, err := os.Stat(lib.TemplatesDir()); err != nil || !info.IsDir() {
+			issues = append(issues, Issue{
+				Type:     IssueTypeMissingFile,
+				Severity: SeverityError,
+				Path:     lib.Templates,
+				Message:  fmt.Sprintf("templates directory %q does not exist", lib.Templates),
+			})
 		}
 	}
 
@@ -70,6 +70,26 @@ presets: {}
 			expectedIssues: 1,
 			expectedRefs:   []string{"skill/merge"},
 		},
+		{
+			name: "missing template overrides detected",
+			libraryYAML: `
+version: "1"
+templates: templates
+resources:
+  agent:
+    reviewer:
+      path: agents/reviewer.md
+      description: Reviewer
+      templates:
+        claude-code: templates/reviewer.tmpl
+presets: {}
+`,
+			files: map[string]string{
+				"agents/reviewer.md": "---\nname: reviewer\n---\nContent",
+			},
+			expectedIssues: 2,
+			expectedRefs:   []string{"agent/reviewer"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,81 @@
+package opencode
+
+import (
+	"encoding/json"
+	"sort"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+// mcpConfig is the part of opencode.json that holds MCP servers.
+type mcpConfig struct {
+	MCP map[string]mcpServerEntry `json:"mcp"`
+}
+
+// mcpServerEntry is one server under the "mcp" key of opencode.json.
+// Local servers carry the executable and its arguments in one Command
+// array; remote servers carry URL and Headers.
+type mcpServerEntry struct {
+	Type        string            `json:"type"`
+	Command     []string          `json:"command,omitempty"`
+	Environment map[string]string `json:"environment,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Enabled     *bool             `json:"enabled,omitempty"`
+}
+
+// DecodeMCPServers reads the servers of an opencode.json file, sorted by
+// name. Other opencode.json settings are ignored.
+func (a *Adapter) DecodeMCPServers(content []byte) ([]core.MCPServer, error) {
+	var cfg mcpConfig
+	if err := json.Unmarshal(content, &cfg); err != nil {
+		return nil, core.NewParseError("", "invalid opencode.json", err)
+	}
+
+	servers := make([]core.MCPServer, 0, len(cfg.MCP))
+	for name, entry := range cfg.MCP {
+		server := core.MCPServer{
+			Name:    name,
+			Env:     entry.Environment,
+			URL:     entry.URL,
+			Headers: entry.Headers,
+		}
+		// Only a disabled server is worth carrying: enabled is the
+		// default everywhere, and keeping it set would be reported as a
+		// dropped field on platforms without the flag.
+		if entry.Enabled != nil && !*entry.Enabled {
+			server.Enabled = entry.Enabled
+		}
+		if len(entry.Command) > 0 {
+			server.Command = entry.Command[0]
+			server.Args = entry.Command[1:]
+			if len(server.Args) == 0 {
+				server.Args = nil
+			}
+		}
+		servers = append(servers, server)
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].Name < servers[j].Name })
+	return servers, nil
+}
+
+func (a *Adapter) renderMCPServer(server *core.MCPServer) (map[string]interface{}, error) {
+	entry := map[string]interface{}{"enabled": server.IsEnabled()}
+	if server.IsRemote() {
+		entry["type"] = "remote"
+		entry["url"] = server.URL
+		if len(server.Headers) > 0 {
+			entry["headers"] = server.Headers
+		}
+	} else {
+		entry["type"] = "local"
+		entry["command"] = append([]string{server.Command}, server.Args...)
+		if len(server.Env) > 0 {
+			entry["environment"] = server.Env
+		}
+	}
+	return map[string]interface{}{
+		"__type": "mcp",
+		"mcp":    map[string]interface{}{server.Name: entry},
+	}, nil
+}
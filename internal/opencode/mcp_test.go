@@ -0,0 +1,45 @@
+package opencode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	canonical "gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestDecodeMCPServers(t *testing.T) {
+	servers, err := OpenCode.DecodeMCPServers([]byte(`{
+  "$schema": "https://opencode.ai/config.json",
+  "mcp": {
+    "github": {"type": "local", "command": ["npx", "-y", "server-github"], "environment": {"TOKEN": "x"}, "enabled": true},
+    "docs": {"type": "remote", "url": "https://example.com/mcp", "enabled": false},
+    "lint": {"type": "local", "command": ["lint-server"]}
+  }
+}`))
+	require.NoError(t, err)
+
+	disabled := false
+	assert.Equal(t, []canonical.MCPServer{
+		{Name: "docs", URL: "https://example.com/mcp", Enabled: &disabled},
+		{Name: "github", Command: "npx", Args: []string{"-y", "server-github"}, Env: map[string]string{"TOKEN": "x"}},
+		{Name: "lint", Command: "lint-server"},
+	}, servers, "enabled: true is the default and is not carried")
+}
+
+func TestFromCanonicalMCP(t *testing.T) {
+	local, err := OpenCode.FromCanonical("mcp", &canonical.MCPServer{
+		Name: "github", Command: "npx", Args: []string{"-y"}, Env: map[string]string{"TOKEN": "x"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"type": "local", "command": []string{"npx", "-y"}, "environment": map[string]string{"TOKEN": "x"}, "enabled": true,
+	}, local["mcp"].(map[string]interface{})["github"])
+
+	disabled := false
+	remote, err := OpenCode.FromCanonical("mcp", &canonical.MCPServer{Name: "docs", URL: "https://example.com/mcp", Enabled: &disabled})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"type": "remote", "url": "https://example.com/mcp", "enabled": false,
+	}, remote["mcp"].(map[string]interface{})["docs"])
+}
@@ -15,6 +15,28 @@ type Adapter struct{}
 // The adapter is stateless; a single shared instance is safe to use across goroutines.
 var OpenCode = &Adapter{}
 
+// unsupportedFields lists, per document type, the canonical fields the
+// OpenCode templates do not render.
+var unsupportedFields = map[string][]core.UnsupportedField{
+	"agent": {
+		{Field: "extensions.hooks", Reason: "OpenCode agents have no hooks"},
+	},
+	"command": {
+		{Field: "tools", Reason: "OpenCode commands inherit tools from their agent"},
+		{Field: "arguments.hint", Reason: "OpenCode commands have no argument hint"},
+	},
+	"skill": {
+		{Field: "tools", Reason: "OpenCode skills inherit tools from their agent"},
+		{Field: "model", Reason: "OpenCode skills run on the invoking agent's model"},
+	},
+	"hook": {
+		{Field: "timeout", Reason: "OpenCode plugin stubs do not enforce a timeout"},
+	},
+	"settings": {
+		{Field: "env", Reason: "opencode.json has no environment variables setting"},
+	},
+}
+
 // ToCanonical converts OpenCode format to canonical models.
 // It parses the input map based on the __type field and returns the appropriate canonical document type.
 func (a *Adapter) ToCanonical(input map[string]interface{}) (*core.Agent, *core.Command, *core.Skill, *core.Memory, error) {
@@ -69,6 +91,12 @@ func (a *Adapter) FromCanonical(docType string, doc interface{}) (map[string]int
 			return nil, core.NewTransformError("from-canonical", "opencode", fmt.Sprintf("expected *core.Memory, got %T", doc), nil)
 		}
 		return a.renderMemory(mem)
+	case "mcp":
+		server, ok := doc.(*core.MCPServer)
+		if !ok {
+			return nil, core.NewTransformError("from-canonical", "opencode", fmt.Sprintf("expected *core.MCPServer, got %T", doc), nil)
+		}
+		return a.renderMCPServer(server)
 	default:
 		return nil, core.NewTransformError("from-canonical", "opencode", "unknown document type: "+docType, nil)
 	}
@@ -84,6 +112,42 @@ func (a *Adapter) PermissionPolicyToPlatform(policy core.PermissionPolicy) (inte
 	return mapping.OpenCode, nil
 }
 
+// knownFields lists, per document type, the frontmatter keys
+// ToCanonical reads from an OpenCode file. Any other key, such as an
+// agent's top_p, is kept under targets.opencode by the parser and
+// written back by the templates.
+var knownFields = map[string][]string{
+	"agent": {
+		"name", "description", "tools", "mode", "temperature", "maxSteps", "prompt", "hidden", "disable",
+		"permissionMode", "permission", "model", "targets", "skills",
+	},
+	"command": {
+		"name", "description", "agent", "subtask", "context", "allowed-tools", "argument-hint", "model", "targets",
+	},
+	"skill": {
+		"name", "description", "allowed-tools", "license", "compatibility", "metadata", "hooks",
+		"agent", "context", "user-invocable", "model", "targets",
+	},
+}
+
+// KnownFields returns the frontmatter keys OpenCode documents of
+// docType are read from, or nil for types without frontmatter targets.
+func (a *Adapter) KnownFields(docType string) []string {
+	return knownFields[docType]
+}
+
+// UnsupportedFields returns the canonical fields OpenCode cannot represent
+// for docType; convert reports them as dropped when they are set.
+func (a *Adapter) UnsupportedFields(docType string) []core.UnsupportedField {
+	return unsupportedFields[docType]
+}
+
+// NamesFromFile reports whether docType is named by its file: OpenCode
+// agents and commands have no name field, skills do.
+func (a *Adapter) NamesFromFile(docType string) bool {
+	return docType == "agent" || docType == "command"
+}
+
 // ConvertToolNameCase converts a tool name to lowercase for OpenCode.
 // OpenCode uses lowercase tool names, so this is an identity operation.
 func (a *Adapter) ConvertToolNameCase(name string) string {
@@ -120,8 +184,12 @@ func (a *Adapter) parseAgent(input map[string]interface{}) (*core.Agent, error)
 	if temperature, ok := input["temperature"].(float64); ok {
 		agent.Behavior.Temperature = &temperature
 	}
-	if maxSteps, ok := input["maxSteps"].(int); ok {
+	// YAML frontmatter decodes whole numbers as int, JSON as float64.
+	switch maxSteps := input["maxSteps"].(type) {
+	case int:
 		agent.Behavior.Steps = maxSteps
+	case float64:
+		agent.Behavior.Steps = int(maxSteps)
 	}
 	if prompt, ok := input["prompt"].(string); ok {
 		agent.Behavior.Prompt = prompt
@@ -139,7 +207,15 @@ func (a *Adapter) parseAgent(input map[string]interface{}) (*core.Agent, error)
 		if err := permission.ValidateActionStrings(perm); err != nil {
 			return nil, err //nolint:wrapcheck // typed *core.ConfigError propagates as-is for callers to errors.As dispatch
 		}
-		agent.PermissionPolicy = a.mapPermissionObjectToPolicy(perm)
+		if rules := permission.ParseOpenCodePermission(perm); permission.IsPresetShaped(rules) {
+			agent.PermissionPolicy = a.mapPermissionObjectToPolicy(perm)
+		} else {
+			var rest core.PermissionRules
+			agent.PermissionPolicy, rest = permission.InferPolicy(rules)
+			if len(rest) > 0 {
+				agent.Permissions = rest
+			}
+		}
 	}
 
 	if model, ok := input["model"].(string); ok {
@@ -209,7 +285,13 @@ func (a *Adapter) renderAgent(agent *core.Agent) (map[string]interface{}, error)
 		output["disable"] = agent.Behavior.Disabled
 	}
 
-	if agent.PermissionPolicy != "" {
+	if len(agent.Permissions) > 0 {
+		rules, err := permission.EffectiveRules(agent.PermissionPolicy, agent.Permissions)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // typed *core.ConfigError propagates as-is
+		}
+		output["permission"] = rules
+	} else if agent.PermissionPolicy != "" {
 		permission, err := a.PermissionPolicyToPlatform(agent.PermissionPolicy)
 		if err != nil {
 			return nil, err
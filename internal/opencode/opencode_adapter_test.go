@@ -159,6 +159,22 @@ func TestToCanonical(t *testing.T) {
 			t.Errorf("agent.Behavior.Steps = %d, want 10", agent.Behavior.Steps)
 		}
 	})
+
+	t.Run("agent with maxSteps decoded from JSON", func(t *testing.T) {
+		input := map[string]interface{}{
+			"__type":      "agent",
+			"description": "Test agent",
+			"maxSteps":    float64(10),
+		}
+
+		agent, _, _, _, err := adapter.ToCanonical(input)
+		if err != nil {
+			t.Fatalf("ToCanonical() error = %v", err)
+		}
+		if agent.Behavior.Steps != 10 {
+			t.Errorf("agent.Behavior.Steps = %d, want 10", agent.Behavior.Steps)
+		}
+	})
 }
 
 func TestToCanonicalCommand(t *testing.T) {
@@ -74,6 +74,9 @@ func writeErrOut(io *iostreams.IOStreams, msg string) {
 
 func formatParseError(io *iostreams.IOStreams, e *core.ParseError) string {
 	body := fmt.Sprintf("parse failed at %s: %s", e.Path(), e.Message())
+	if e.Line() > 0 {
+		body = fmt.Sprintf("%s: %s", e.Location(), e.Message())
+	}
 	if e.Cause() != nil {
 		body += fmt.Sprintf(": %v", e.Cause())
 	}
@@ -24,7 +24,7 @@ import (
 // rendering).
 //
 // The output groups resources by type in canonical order
-// (skill, agent, command, memory) and renders each entry as
+// (skill, agent, command, memory, mcp, hook, settings) and renders each entry as
 // "<type>/<name>" followed by a description when present. The
 // "No resources found." sentinel is returned (with a trailing
 // newline) when the library holds no resources so the caller can
@@ -40,6 +40,9 @@ func FormatResourcesList(lib *library.Library) string {
 		string(library.ResourceTypeAgent),
 		string(library.ResourceTypeCommand),
 		string(library.ResourceTypeMemory),
+		string(library.ResourceTypeMCP),
+		string(library.ResourceTypeHook),
+		string(library.ResourceTypeSettings),
 	}
 
 	hasContent := false
@@ -31,6 +31,11 @@ func TestFormatErrorDispatch(t *testing.T) {
 			err:      core.NewParseError("/tmp/foo.md", "bad yaml", errors.New("yaml: line 1")),
 			contains: "parse failed at /tmp/foo.md",
 		},
+		{
+			name:     "ParseError with position",
+			err:      core.NewParseError("/tmp/foo.md", "bad yaml", errors.New("did not find expected key")).WithPosition(3, 5),
+			contains: "Error: /tmp/foo.md:3:5: bad yaml: did not find expected key",
+		},
 		{
 			name:     "ValidationError",
 			err:      core.NewValidationError("adapt", "name", "", "name is required"),
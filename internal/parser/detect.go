@@ -0,0 +1,170 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Detection is the document type Detect settled on and the rule that
+// decided it, e.g. "frontmatter type: agent" or "filename matches
+// agent-*.md". A zero Detection means no rule applied.
+type Detection struct {
+	Type string
+	Rule string
+}
+
+// Detect determines the type of the canonical document at path. The
+// first rule that applies wins:
+//
+//  1. a type: or kind: key in the frontmatter;
+//  2. a filename pattern such as agent-*.md or *-skill.yaml;
+//  3. a field only one type has, e.g. permissionPolicy or behavior for
+//     an agent, event for a hook, or paths alone for memory.
+//
+// A file that cannot be read is detected by its name alone; the read
+// error surfaces when the document is parsed. A type: or kind: value
+// that is not a document type is a *core.ParseError.
+func Detect(ctx context.Context, path string) (Detection, error) {
+	if err := ctx.Err(); err != nil {
+		return Detection{}, fmt.Errorf("parser: detect cancelled: %w", err)
+	}
+
+	frontmatter := readFrontmatter(path)
+	for _, key := range []string{"type", "kind"} {
+		value, ok := frontmatter[key].(string)
+		if !ok {
+			continue
+		}
+		if !slices.Contains(core.ResourceTypes(), value) {
+			return Detection{}, core.NewParseError(path, "frontmatter "+key+": "+value+" is not a document type", nil).
+				WithSuggestions([]string{"use one of: " + strings.Join(core.ResourceTypes(), ", ")})
+		}
+		return Detection{Type: value, Rule: "frontmatter " + key + ": " + value}, nil
+	}
+
+	for _, p := range detectTypePatterns() {
+		if matched, _ := regexp.MatchString(p.pattern, path); matched {
+			return Detection{Type: p.docType, Rule: "filename matches " + patternGlob(p.pattern)}, nil
+		}
+	}
+
+	if frontmatter == nil {
+		return Detection{}, nil
+	}
+	for _, r := range fieldRules() {
+		if lookupField(frontmatter, r.field) != nil && (r.when == nil || r.when(frontmatter)) {
+			return Detection{Type: r.docType, Rule: "frontmatter field " + r.field + r.note}, nil
+		}
+	}
+	return Detection{}, nil
+}
+
+// ResolveType returns the type to parse the document at path as:
+// docType when set, with an empty Rule, else Detect's result. A document
+// no rule types is an UndetectedTypeError.
+func ResolveType(ctx context.Context, path, docType string) (Detection, error) {
+	if docType != "" {
+		return Detection{Type: docType}, nil
+	}
+	detection, err := Detect(ctx, path)
+	if err != nil {
+		return Detection{}, err
+	}
+	if detection.Type == "" {
+		return Detection{}, UndetectedTypeError(path)
+	}
+	return detection, nil
+}
+
+// UndetectedTypeError is the error for a document Detect cannot type.
+func UndetectedTypeError(path string) *core.ParseError {
+	return core.NewParseError(path, "cannot detect document type (expected: a type: key, a filename like agent-*.md, or type-specific fields)", nil).
+		WithSuggestions([]string{
+			"add type: <" + strings.Join(core.ResourceTypes(), "|") + "> to the frontmatter, or pass --type",
+		})
+}
+
+// fieldRule types a document by a frontmatter field. Field names a
+// top-level key or, dotted, a nested one; when, if set, further
+// restricts the documents the rule applies to, and note explains it.
+type fieldRule struct {
+	field   string
+	docType string
+	when    func(map[string]any) bool
+	note    string
+}
+
+// fieldRules returns the field heuristics in the order they are tried.
+// Fields several types share are guarded: agents and settings both take
+// permissionPolicy, but only agents have a description.
+func fieldRules() []fieldRule {
+	noDescription := func(fm map[string]any) bool { return fm["description"] == nil }
+	pathsOnly := func(fm map[string]any) bool { return fm["name"] == nil && fm["description"] == nil }
+	return []fieldRule{
+		{field: "event", docType: "hook"},
+		{field: "url", docType: "mcp"},
+		{field: "command", docType: "mcp"},
+		{field: "args", docType: "mcp"},
+		{field: "headers", docType: "mcp"},
+		{field: "env", docType: "settings"},
+		{field: "permissionPolicy", docType: "settings", when: noDescription, note: " without description"},
+		{field: "permissions", docType: "settings", when: noDescription, note: " without description"},
+		{field: "permissionPolicy", docType: "agent"},
+		{field: "permissions", docType: "agent"},
+		{field: "behavior", docType: "agent"},
+		{field: "disallowedTools", docType: "agent"},
+		{field: "arguments", docType: "command"},
+		{field: "execution.subtask", docType: "command"},
+		{field: "execution.userInvocable", docType: "skill"},
+		{field: "extensions.license", docType: "skill"},
+		{field: "extensions.compatibility", docType: "skill"},
+		{field: "extensions.metadata", docType: "skill"},
+		{field: "paths", docType: "memory", when: pathsOnly, note: " without name or description"},
+	}
+}
+
+// lookupField returns the value of a top-level or dotted field of
+// frontmatter, or nil.
+func lookupField(frontmatter map[string]any, field string) any {
+	var value any = frontmatter
+	for _, key := range strings.Split(field, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
+
+// readFrontmatter returns the decoded frontmatter of the file at path,
+// or nil when it cannot be read or has none. Template actions are
+// blanked first, as in declaredVars.
+func readFrontmatter(path string) map[string]any {
+	content, err := os.ReadFile(path) //nolint:gosec // G304: User provides file path, tool must read user documents
+	if err != nil {
+		return nil
+	}
+	yamlContent, _, _ := extractFrontmatter(string(content)) //nolint:errcheck // extractFrontmatter never fails
+	if yamlContent == "" {
+		return nil
+	}
+	var frontmatter map[string]any
+	if err := yaml.Unmarshal([]byte(templateAction.ReplaceAllString(yamlContent, "_")), &frontmatter); err != nil {
+		return nil
+	}
+	return frontmatter
+}
+
+// patternGlob renders a detectTypePatterns regex as the glob it stands
+// for: `agent-.*\.md$` as agent-*.md.
+func patternGlob(pattern string) string {
+	return strings.NewReplacer(`.*`, "*", `\.`, ".", "$", "").Replace(pattern)
+}
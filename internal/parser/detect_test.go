@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		wantType string
+		wantRule string
+	}{
+		{
+			name:     "type key",
+			filename: "reviewer.md",
+			content:  "---\ntype: agent\nname: reviewer\ndescription: Reviews code\n---\nBody",
+			wantType: "agent",
+			wantRule: "frontmatter type: agent",
+		},
+		{
+			name:     "kind key",
+			filename: "release.md",
+			content:  "---\nkind: skill\nname: release\ndescription: Cut a release\n---\nBody",
+			wantType: "skill",
+			wantRule: "frontmatter kind: skill",
+		},
+		{
+			name:     "type key beats filename",
+			filename: "agent-release.md",
+			content:  "---\ntype: command\nname: release\ndescription: Cut a release\n---\nBody",
+			wantType: "command",
+			wantRule: "frontmatter type: command",
+		},
+		{
+			name:     "filename",
+			filename: "agent-reviewer.md",
+			content:  "---\nname: reviewer\ndescription: Reviews code\n---\nBody",
+			wantType: "agent",
+			wantRule: "filename matches agent-*.md",
+		},
+		{
+			name:     "behavior means agent",
+			filename: "reviewer.md",
+			content:  "---\nname: reviewer\ndescription: Reviews code\nbehavior:\n  mode: primary\n---\nBody",
+			wantType: "agent",
+			wantRule: "frontmatter field behavior",
+		},
+		{
+			name:     "permissionPolicy with description means agent",
+			filename: "reviewer.md",
+			content:  "---\nname: reviewer\ndescription: Reviews code\npermissionPolicy: balanced\n---\nBody",
+			wantType: "agent",
+			wantRule: "frontmatter field permissionPolicy",
+		},
+		{
+			name:     "permissionPolicy without description means settings",
+			filename: "project.md",
+			content:  "---\nname: project\npermissionPolicy: balanced\n---\n",
+			wantType: "settings",
+			wantRule: "frontmatter field permissionPolicy without description",
+		},
+		{
+			name:     "event means hook",
+			filename: "format.md",
+			content:  "---\nname: format\nevent: PostToolUse\ncommand: gofmt -w .\n---\n",
+			wantType: "hook",
+			wantRule: "frontmatter field event",
+		},
+		{
+			name:     "url means mcp",
+			filename: "docs.md",
+			content:  "---\nname: docs\nurl: https://example.com/mcp\n---\n",
+			wantType: "mcp",
+			wantRule: "frontmatter field url",
+		},
+		{
+			name:     "nested field",
+			filename: "release.md",
+			content:  "---\nname: release\ndescription: Cut a release\nextensions:\n  license: MIT\n---\nBody",
+			wantType: "skill",
+			wantRule: "frontmatter field extensions.license",
+		},
+		{
+			name:     "paths only means memory",
+			filename: "go-style.md",
+			content:  "---\npaths:\n  - \"**/*.go\"\n---\nUse gofmt.",
+			wantType: "memory",
+			wantRule: "frontmatter field paths without name or description",
+		},
+		{
+			name:     "templated frontmatter",
+			filename: "reviewer.md",
+			content:  "---\nname: {{ .Vars.name }}\ndescription: Reviews code\nbehavior:\n  mode: primary\nvars:\n  name: reviewer\n---\nBody",
+			wantType: "agent",
+			wantRule: "frontmatter field behavior",
+		},
+		{
+			name:     "ambiguous",
+			filename: "reviewer.md",
+			content:  "---\nname: reviewer\ndescription: Reviews code\n---\nBody",
+		},
+		{
+			name:     "no frontmatter",
+			filename: "notes.md",
+			content:  "Just notes.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), tt.filename)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			detection, err := Detect(context.Background(), path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, detection.Type)
+			assert.Equal(t, tt.wantRule, detection.Rule)
+		})
+	}
+}
+
+func TestDetect_InvalidTypeKey(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "reviewer.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\ntype: widget\n---\n"), 0o600))
+
+	_, err := Detect(context.Background(), path)
+	var perr *core.ParseError
+	require.ErrorAs(t, err, &perr)
+	assert.Contains(t, err.Error(), "type: widget is not a document type")
+}
+
+func TestLoadDocumentWith_Type(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "reviewer.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\nname: reviewer\ndescription: Reviews code\n---\nBody"), 0o600))
+
+	_, err := LoadDocument(context.Background(), path, "claude-code")
+	var perr *core.ParseError
+	require.ErrorAs(t, err, &perr, "name and description alone do not identify a type")
+
+	doc, err := LoadDocumentWith(context.Background(), path, "claude-code", Options{Type: "agent"})
+	require.NoError(t, err)
+	agent, ok := doc.(*CanonicalAgent)
+	require.True(t, ok)
+	assert.Equal(t, "reviewer", agent.Name)
+}
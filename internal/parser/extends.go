@@ -0,0 +1,199 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/library"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// resolveExtends composes doc, parsed from path, on the document its
+// `extends` ref names, which is itself resolved first, so a chain of any
+// length collapses into one document (core.Extend, core.ExtendContent).
+// Refs are resolved through the library holding path: the nearest
+// directory above it with a library.yaml. chain lists the absolute paths
+// of the documents being resolved, outermost first; extending one of
+// them again is reported as a cycle. Extended documents have their
+// variables expanded with the same opts, and are read from their files
+// even when opts.Content holds the extending document.
+func resolveExtends(ctx context.Context, path string, doc interface{}, opts Options, chain []string) (interface{}, error) {
+	ref := extendsRef(doc)
+	if ref == "" {
+		return doc, nil
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, core.NewFileError(path, "resolve", "failed to resolve document path", err)
+	}
+	chain = append(chain, abs)
+
+	typ, _, err := library.ParseRef(ref)
+	if err != nil {
+		return nil, core.NewParseError(path, "invalid extends", err)
+	}
+	if want := docTypeOf(doc); typ != want {
+		return nil, core.NewParseError(path, "extends "+ref+" is not of type "+want, nil).
+			WithSuggestions([]string{"extend a " + want + " (" + want + "/<name>)"})
+	}
+
+	lib, err := EnclosingLibrary(ctx, abs)
+	if err != nil {
+		return nil, err
+	}
+	if lib == nil {
+		return nil, core.NewParseError(path, "cannot resolve extends "+ref+": document is not inside a library", nil).
+			WithSuggestions([]string{"move the document into a library (a directory holding library.yaml)"})
+	}
+	basePath, err := library.ResolveResource(lib, ref)
+	if err != nil {
+		return nil, core.NewParseError(path, "cannot resolve extends "+ref, err)
+	}
+
+	for i, p := range chain {
+		if p == basePath {
+			return nil, core.NewParseError(path, "extends cycle: "+cycleString(lib, chain[i:], basePath), nil).
+				WithSuggestions([]string{"remove extends from one of the documents in the cycle"})
+		}
+	}
+
+	baseOpts := opts
+	baseOpts.Content = nil
+	base, err := parseFile(ctx, basePath, typ, baseOpts)
+	if err != nil {
+		return nil, err
+	}
+	base, err = resolveExtends(ctx, basePath, base, baseOpts, chain)
+	if err != nil {
+		return nil, err
+	}
+	return extend(path, base, doc, frontmatterPaths(path, opts))
+}
+
+// extend composes doc on its fully resolved base. set holds the
+// frontmatter paths doc sets (frontmatterPaths).
+func extend(path string, base, doc interface{}, set map[string]bool) (interface{}, error) {
+	var err error
+	switch d := doc.(type) {
+	case *CanonicalAgent:
+		b := base.(*CanonicalAgent) //nolint:forcetypeassert // type checked against the ref by resolveExtends
+		d.Agent, err = core.Extend(b.Agent, d.Agent, set)
+		d.Content = core.ExtendContent(b.Content, d.Content)
+	case *CanonicalCommand:
+		b := base.(*CanonicalCommand) //nolint:forcetypeassert // type checked against the ref by resolveExtends
+		d.Command, err = core.Extend(b.Command, d.Command, set)
+		d.Content = core.ExtendContent(b.Content, d.Content)
+	case *CanonicalSkill:
+		b := base.(*CanonicalSkill) //nolint:forcetypeassert // type checked against the ref by resolveExtends
+		d.Skill, err = core.Extend(b.Skill, d.Skill, set)
+		d.Content = core.ExtendContent(b.Content, d.Content)
+	}
+	if err != nil {
+		return nil, core.NewParseError(path, "failed to apply extends", err)
+	}
+	return doc, nil
+}
+
+// frontmatterPaths returns the dotted path of every key the frontmatter
+// of the document at path (or opts.Content) sets, e.g. "behavior.hidden",
+// so core.Extend can tell a value set to false from one left unset.
+// Template actions are blanked before decoding, as in declaredVars. It
+// returns nil when the frontmatter cannot be read.
+func frontmatterPaths(path string, opts Options) map[string]bool {
+	content, err := readContent(path, opts)
+	if err != nil {
+		return nil
+	}
+	frontmatter, _, _ := extractFrontmatter(string(content)) //nolint:errcheck // extractFrontmatter never fails
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(templateAction.ReplaceAllString(frontmatter, "_")), &root); err != nil || len(root.Content) == 0 {
+		return nil
+	}
+	set := make(map[string]bool)
+	collectPaths(root.Content[0], "", set)
+	return set
+}
+
+// collectPaths adds the dotted path of every key of the mapping n, and
+// of the mappings nested in it, to set.
+func collectPaths(n *yaml.Node, prefix string, set map[string]bool) {
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		path := prefix + n.Content[i].Value
+		set[path] = true
+		collectPaths(n.Content[i+1], path+".", set)
+	}
+}
+
+// extendsRef returns the `extends` ref of the document types that
+// support composition, or "".
+func extendsRef(doc interface{}) string {
+	switch d := doc.(type) {
+	case *CanonicalAgent:
+		return d.Extends
+	case *CanonicalCommand:
+		return d.Extends
+	case *CanonicalSkill:
+		return d.Extends
+	default:
+		return ""
+	}
+}
+
+func docTypeOf(doc interface{}) string {
+	switch doc.(type) {
+	case *CanonicalAgent:
+		return "agent"
+	case *CanonicalCommand:
+		return "command"
+	default:
+		return "skill"
+	}
+}
+
+// EnclosingLibrary loads the library holding the document at abs, an
+// absolute path, or returns nil when no directory above it has a
+// library.yaml.
+func EnclosingLibrary(ctx context.Context, abs string) (*library.Library, error) {
+	for dir := filepath.Dir(abs); ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "library.yaml")); err == nil {
+			lib, err := library.LoadLibrary(ctx, dir)
+			if err != nil {
+				return nil, err //nolint:wrapcheck // typed core error from the library package
+			}
+			return lib, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, core.NewFileError(dir, "access", "failed to look for library.yaml", err)
+		}
+		if filepath.Dir(dir) == dir {
+			return nil, nil
+		}
+	}
+}
+
+// cycleString renders a cycle as the refs of its documents, closed by
+// the ref that starts it again. Documents outside lib are shown by path.
+func cycleString(lib *library.Library, cycle []string, again string) string {
+	refs := make(map[string]string)
+	for typ, resources := range lib.Resources {
+		for name, res := range resources {
+			refs[filepath.Join(lib.RootPath, res.Path)] = library.FormatRef(typ, name)
+		}
+	}
+	parts := make([]string, 0, len(cycle)+1)
+	for _, p := range append(cycle, again) {
+		if ref, ok := refs[p]; ok {
+			parts = append(parts, ref)
+		} else {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " → ")
+}
@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+// writeExtendsLibrary writes a library with the given agent files
+// (name → content) and returns its root.
+func writeExtendsLibrary(t *testing.T, agents map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "agents"), 0o755))
+	index := "version: \"1\"\nresources:\n  agent:\n"
+	for name, content := range agents {
+		path := filepath.Join("agents", "agent-"+name+".md")
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(content), 0o644))
+		index += "    " + name + ":\n      path: " + path + "\n"
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(root, "library.yaml"), []byte(index), 0o644))
+	return root
+}
+
+func TestParseDocument_Extends(t *testing.T) {
+	t.Parallel()
+
+	root := writeExtendsLibrary(t, map[string]string{
+		"base": "---\nname: base\ndescription: Reviews code\ntools:\n  - read\nmodel: sonnet\n---\nReview the diff.\n",
+		"strict": "---\nname: strict\nextends: agent/base\ntools:\n  - grep\n---\nYou are strict.\n" +
+			core.ExtendsMarker + "\n",
+		"go": "---\nname: go\nextends: agent/strict\ndescription: Reviews Go code\nreplace: [tools]\ntools:\n  - bash\n---\n" +
+			core.ExtendsMarker + "\nCheck gofmt.\n",
+	})
+
+	doc, err := ParseDocument(context.Background(), filepath.Join(root, "agents", "agent-go.md"), "agent")
+	require.NoError(t, err)
+
+	agent, ok := doc.(*CanonicalAgent)
+	require.True(t, ok)
+	assert.Equal(t, "go", agent.Name)
+	assert.Equal(t, "Reviews Go code", agent.Description)
+	assert.Equal(t, []string{"bash"}, agent.Tools)
+	assert.Equal(t, "sonnet", agent.Model)
+	assert.Empty(t, agent.Extends)
+	assert.Empty(t, agent.Replace)
+	assert.Equal(t, "You are strict.\nReview the diff.\nCheck gofmt.\n", agent.Content)
+
+	doc, err = ParseDocument(context.Background(), filepath.Join(root, "agents", "agent-strict.md"), "agent")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"read", "grep"}, doc.(*CanonicalAgent).Tools)
+}
+
+func TestParseDocument_ExtendsOverridesBoolToFalse(t *testing.T) {
+	t.Parallel()
+
+	root := writeExtendsLibrary(t, map[string]string{
+		"base":    "---\nname: base\ndescription: Reviews code\nbehavior:\n  hidden: true\n  steps: 10\n---\nReview.\n",
+		"visible": "---\nname: visible\nextends: agent/base\nbehavior:\n  hidden: false\n---\n",
+		"quiet":   "---\nname: quiet\nextends: agent/base\n---\n",
+	})
+
+	doc, err := ParseDocument(context.Background(), filepath.Join(root, "agents", "agent-visible.md"), "agent")
+	require.NoError(t, err)
+	behavior := doc.(*CanonicalAgent).Behavior
+	assert.False(t, behavior.Hidden, "hidden: false on the child overrides the base")
+	assert.Equal(t, 10, behavior.Steps, "unset fields are still inherited")
+
+	doc, err = ParseDocument(context.Background(), filepath.Join(root, "agents", "agent-quiet.md"), "agent")
+	require.NoError(t, err)
+	assert.True(t, doc.(*CanonicalAgent).Behavior.Hidden, "an unset bool is inherited")
+}
+
+func TestParseDocument_ExtendsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		agents  map[string]string
+		file    string
+		wantErr string
+	}{
+		{
+			name: "cycle",
+			agents: map[string]string{
+				"a": "---\nname: a\nextends: agent/b\n---\n",
+				"b": "---\nname: b\nextends: agent/c\n---\n",
+				"c": "---\nname: c\nextends: agent/a\n---\n",
+			},
+			file:    "a",
+			wantErr: "extends cycle: agent/a → agent/b → agent/c → agent/a",
+		},
+		{
+			name:    "self",
+			agents:  map[string]string{"a": "---\nname: a\nextends: agent/a\n---\n"},
+			file:    "a",
+			wantErr: "extends cycle: agent/a → agent/a",
+		},
+		{
+			name:    "unknown ref",
+			agents:  map[string]string{"a": "---\nname: a\nextends: agent/missing\n---\n"},
+			file:    "a",
+			wantErr: "cannot resolve extends agent/missing",
+		},
+		{
+			name:    "type mismatch",
+			agents:  map[string]string{"a": "---\nname: a\nextends: skill/commit\n---\n"},
+			file:    "a",
+			wantErr: "extends skill/commit is not of type agent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := writeExtendsLibrary(t, tt.agents)
+
+			_, err := ParseDocument(context.Background(), filepath.Join(root, "agents", "agent-"+tt.file+".md"), "agent")
+
+			var perr *core.ParseError
+			require.ErrorAs(t, err, &perr)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParseDocument_ExtendsOutsideLibrary(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "agent-a.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\nname: a\nextends: agent/base\n---\n"), 0o644))
+
+	_, err := ParseDocument(context.Background(), path, "agent")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "document is not inside a library")
+}
+
+func TestParseDocumentWith_Content(t *testing.T) {
+	t.Parallel()
+
+	root := writeExtendsLibrary(t, map[string]string{
+		"base": "---\nname: base\ndescription: Reviews code\ntools:\n  - read\n---\nReview the diff.\n",
+	})
+	content := []byte("---\nname: piped\nextends: agent/base\n---\nFrom stdin.\n")
+
+	// The path names the document and locates its library; it is not read.
+	path := filepath.Join(root, "agents", "-")
+	doc, err := ParseDocumentWith(context.Background(), path, "agent", Options{Content: content})
+	require.NoError(t, err)
+
+	agent, ok := doc.(*CanonicalAgent)
+	require.True(t, ok)
+	assert.Equal(t, "piped", agent.Name)
+	assert.Equal(t, "Reviews code", agent.Description, "the extended document is read from its file")
+	assert.Equal(t, []string{"read"}, agent.Tools)
+	assert.Equal(t, "From stdin.\n", agent.Content)
+}
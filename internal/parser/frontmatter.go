@@ -0,0 +1,284 @@
+package parser
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// frontmatterLine is the file line the frontmatter YAML starts on: the
+// one after the opening ---. yaml.v3 counts lines from the start of the
+// frontmatter, so a YAML line n is file line n+frontmatterLine-1.
+const frontmatterLine = 2
+
+// frontmatterKeys are the top-level keys every canonical document may
+// carry without a field for them: the type it declares (Detect) and the
+// variables it declares (expandVars).
+var frontmatterKeys = []string{"type", "kind", "vars"}
+
+// fieldAliases maps keys that are commonly written by mistake, mostly
+// platform spellings of a canonical field, to the field meant. They are
+// suggested ahead of the closest key by edit distance.
+var fieldAliases = map[string]string{
+	"permissionMode":   "permissionPolicy",
+	"permission-mode":  "permissionPolicy",
+	"allowedTools":     "tools",
+	"allowed-tools":    "tools",
+	"disallowed-tools": "disallowedTools",
+}
+
+// yamlLine matches the "line N: message" yaml.v3 errors start with.
+var yamlLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// yamlError is a yaml.v3 error with its frontmatter-relative line
+// number stripped; the *core.ParseError wrapping it carries the line in
+// file terms.
+type yamlError struct {
+	msg string
+	err error
+}
+
+func (e *yamlError) Error() string { return e.msg }
+
+func (e *yamlError) Unwrap() error { return e.err }
+
+// decodeFrontmatter decodes the frontmatter yamlContent of the document
+// at path into out, a pointer to a core document struct. A syntax or
+// type error is a *core.ParseError "failed to parse <what>" positioned
+// at its line in the file. Every key out has no field for is passed to
+// warn, when set, as a positioned *core.ParseError suggesting the key
+// most likely meant (suggestField).
+func decodeFrontmatter(path, yamlContent, what string, out any, warn func(*core.ParseError)) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(yamlContent), &root); err != nil {
+		return frontmatterError(path, what, yamlContent, &root, err)
+	}
+	if len(root.Content) == 0 {
+		return nil
+	}
+	if err := root.Decode(out); err != nil {
+		return frontmatterError(path, what, yamlContent, &root, err)
+	}
+	if warn != nil {
+		for _, w := range unknownFields(path, root.Content[0], reflect.TypeOf(out).Elem(), "", frontmatterKeys) {
+			warn(w)
+		}
+	}
+	return nil
+}
+
+// frontmatterError wraps a yaml.v3 error as a *core.ParseError located
+// at the file line at fault and, when a node of root is on that line,
+// its column. Only the first error of a *yaml.TypeError is reported.
+func frontmatterError(path, what, yamlContent string, root *yaml.Node, err error) *core.ParseError {
+	var line int
+	msg := yamlProblem(err)
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		// A type error names the line of the node it is about.
+		m := yamlLine.FindStringSubmatch(typeErr.Errors[0])
+		if m == nil {
+			return core.NewParseError(path, "failed to parse "+what, err)
+		}
+		line, _ = strconv.Atoi(m[1]) //nolint:errcheck // the pattern only matches digits
+		msg = m[2]
+	} else {
+		line = syntaxErrorLine(yamlContent, msg)
+	}
+	if line == 0 {
+		return core.NewParseError(path, "failed to parse "+what, err)
+	}
+	return core.NewParseError(path, "failed to parse "+what, &yamlError{msg: msg, err: err}).
+		WithPosition(line+frontmatterLine-1, columnAt(root, line))
+}
+
+// syntaxErrorLine returns the line of yamlContent a syntax error with
+// the problem msg is on, or 0 when none is found. yaml.v3 names the
+// line of the construct it was parsing, counted from 0 or from 1
+// depending on the stage that failed, and no line at all on the first,
+// so the line is that of the shortest prefix of yamlContent that fails
+// with the same problem.
+func syntaxErrorLine(yamlContent, msg string) int {
+	lines := strings.SplitAfter(yamlContent, "\n")
+	for n := 1; n <= len(lines); n++ {
+		var node yaml.Node
+		err := yaml.Unmarshal([]byte(strings.Join(lines[:n], "")), &node)
+		if err != nil && yamlProblem(err) == msg {
+			return n
+		}
+	}
+	return 0
+}
+
+// yamlProblem returns the message of a yaml.v3 syntax error without its
+// "yaml: line N:" prefix.
+func yamlProblem(err error) string {
+	msg := err.Error()
+	if m := yamlLine.FindStringSubmatch(msg); m != nil {
+		return m[2]
+	}
+	return strings.TrimPrefix(msg, "yaml: ")
+}
+
+// columnAt returns the column of the first value under n on line, or of
+// the key on it when its value starts on a later line; 0 when no node
+// is on line.
+func columnAt(n *yaml.Node, line int) int {
+	switch n.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, c := range n.Content {
+			if col := columnAt(c, line); col > 0 {
+				return col
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if col := columnAt(n.Content[i+1], line); col > 0 {
+				return col
+			}
+			if n.Content[i].Line == line {
+				return n.Content[i].Column
+			}
+		}
+	default:
+		if n.Line == line {
+			return n.Column
+		}
+	}
+	return 0
+}
+
+// unknownFields returns a warning for every key of the mapping n that
+// no field of the struct type t decodes and extra does not list,
+// descending into fields that are structs. prefix is the dotted path of
+// n, e.g. "behavior.".
+func unknownFields(path string, n *yaml.Node, t reflect.Type, prefix string, extra []string) []*core.ParseError {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	fields := yamlFields(t)
+	known := slices.Concat(extra, slices.Sorted(maps.Keys(fields)))
+
+	var warnings []*core.ParseError
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		ft, ok := fields[key.Value]
+		if !ok {
+			if slices.Contains(extra, key.Value) {
+				continue
+			}
+			w := core.NewParseError(path, "unknown field "+prefix+key.Value, nil).
+				WithPosition(key.Line+frontmatterLine-1, key.Column)
+			s := nestedField(fields, key.Value)
+			if s == "" {
+				s = suggestField(key.Value, known)
+			}
+			if s != "" {
+				w = w.WithSuggestions([]string{"did you mean " + prefix + s + "?"})
+			}
+			warnings = append(warnings, w)
+			continue
+		}
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct {
+			warnings = append(warnings, unknownFields(path, value, ft, prefix+key.Value+".", nil)...)
+		}
+	}
+	return warnings
+}
+
+// yamlFields returns the keys yaml.v3 decodes into the struct type t,
+// with the type of the field each decodes into.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("yaml")
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") || (f.Anonymous && tag == "") {
+			maps.Copy(fields, yamlFields(f.Type))
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// nestedField returns the dotted path of the field named key in one of
+// the struct-typed fields, e.g. behavior.mode for a top-level mode, or
+// "" when none has it. An exact nested match is suggested ahead of
+// suggestField's guesses.
+func nestedField(fields map[string]reflect.Type, key string) string {
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		ft := fields[name]
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if ft.Kind() != reflect.Struct {
+			continue
+		}
+		if _, ok := yamlFields(ft)[key]; ok {
+			return name + "." + key
+		}
+	}
+	return ""
+}
+
+// suggestField returns the key of known that key most likely meant: its
+// alias (fieldAliases), a key differing only in case, or the closest
+// key within an edit distance of a third of key's length. It returns ""
+// when none is close.
+func suggestField(key string, known []string) string {
+	if alias, ok := fieldAliases[key]; ok && slices.Contains(known, alias) {
+		return alias
+	}
+	best, bestDist := "", max(1, len(key)/3)+1
+	for _, k := range known {
+		if strings.EqualFold(k, key) {
+			return k
+		}
+		if d := editDistance(strings.ToLower(key), strings.ToLower(k)); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,175 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestParseDocumentWith_ErrorPosition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		column  int
+		cause   string
+	}{
+		{
+			name:    "type error at the value",
+			content: "---\nname: reviewer\ndescription: Reviews\nbehavior:\n  steps: many\n---\nBody\n",
+			line:    5,
+			column:  10,
+			cause:   "cannot unmarshal !!str `many` into int",
+		},
+		{
+			name:    "syntax error has a line only",
+			content: "---\nname: reviewer\ndescription: Reviews\n mode: x\n---\nBody\n",
+			line:    4,
+			column:  0,
+			cause:   "mapping values are not allowed in this context",
+		},
+		{
+			name:    "unclosed flow sequence",
+			content: "---\nname: reviewer\ndescription: Reviews\ntools:\n  - read\n  - grep\nfoo: [unclosed\nmodel: sonnet\n---\nBody\n",
+			line:    7,
+			column:  0,
+			cause:   "did not find expected ',' or ']'",
+		},
+		{
+			name:    "syntax error on the first line",
+			content: "---\ntools: [read\nname: reviewer\n---\nBody\n",
+			line:    2,
+			column:  0,
+			cause:   "did not find expected ',' or ']'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "agent-reviewer.md")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			_, err := LoadDocument(context.Background(), path, "opencode")
+			var perr *core.ParseError
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, path, perr.Path())
+			assert.Equal(t, tt.line, perr.Line())
+			assert.Equal(t, tt.column, perr.Column())
+			assert.Equal(t, "failed to parse agent", perr.Message())
+			require.Error(t, perr.Cause())
+			assert.Equal(t, tt.cause, perr.Cause().Error())
+		})
+	}
+}
+
+func TestParseDocumentWith_UnknownFields(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "agent-reviewer.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\ntype: agent\nname: reviewer\ndescription: Reviews\n"+
+		"permissionMode: plan\nbehavior:\n  tempature: 0.2\nvars:\n  x: y\nfavouriteColour: blue\nmode: subagent\n---\nBody\n"), 0o600))
+
+	var warnings []*core.ParseError
+	doc, err := ParseDocumentWith(context.Background(), path, "agent", Options{
+		Warn: func(w *core.ParseError) { warnings = append(warnings, w) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "reviewer", doc.(*CanonicalAgent).Name)
+
+	type warning struct {
+		Message     string
+		Line        int
+		Column      int
+		Suggestions []string
+	}
+	got := make([]warning, 0, len(warnings))
+	for _, w := range warnings {
+		got = append(got, warning{w.Message(), w.Line(), w.Column(), w.Suggestions()})
+	}
+	assert.Equal(t, []warning{
+		{"unknown field permissionMode", 5, 1, []string{"did you mean permissionPolicy?"}},
+		{"unknown field behavior.tempature", 7, 3, []string{"did you mean behavior.temperature?"}},
+		{"unknown field favouriteColour", 10, 1, nil},
+		{"unknown field mode", 11, 1, []string{"did you mean behavior.mode?"}},
+	}, got)
+
+	_, err = ParseDocument(context.Background(), path, "agent")
+	require.NoError(t, err, "unknown fields are ignored without Options.Warn")
+}
+
+func TestParseDocumentWith_Memory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown fields are warned about", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "memory-go.md")
+		require.NoError(t, os.WriteFile(path, []byte("---\ntype: memory\npaths:\n  - \"**/*.go\"\nglobs: \"*.go\"\n---\nUse gofmt.\n"), 0o600))
+
+		var warnings []*core.ParseError
+		doc, err := ParseDocumentWith(context.Background(), path, "memory", Options{
+			Warn: func(w *core.ParseError) { warnings = append(warnings, w) },
+		})
+		require.NoError(t, err)
+		memory := doc.(*CanonicalMemory)
+		assert.Equal(t, []string{"**/*.go"}, memory.Paths)
+		assert.Equal(t, "Use gofmt.\n", memory.Memory.Content)
+		require.Len(t, warnings, 1)
+		assert.Equal(t, "unknown field globs", warnings[0].Message())
+		assert.Equal(t, 5, warnings[0].Line())
+	})
+
+	t.Run("invalid YAML is a parse error", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "memory-go.md")
+		require.NoError(t, os.WriteFile(path, []byte("---\npaths: \"**/*.go\"\n---\nUse gofmt.\n"), 0o600))
+
+		_, err := ParseDocument(context.Background(), path, "memory")
+		var perr *core.ParseError
+		require.ErrorAs(t, err, &perr)
+		assert.Equal(t, "failed to parse memory", perr.Message())
+		assert.Equal(t, 2, perr.Line())
+	})
+
+	t.Run("unclosed frontmatter is content", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "memory-go.md")
+		require.NoError(t, os.WriteFile(path, []byte("---\nUse gofmt: always.\n"), 0o600))
+
+		doc, err := ParseDocument(context.Background(), path, "memory")
+		require.NoError(t, err)
+		assert.Equal(t, "---\nUse gofmt: always.\n", doc.(*CanonicalMemory).Memory.Content)
+	})
+}
+
+func TestSuggestField(t *testing.T) {
+	t.Parallel()
+
+	known := []string{"name", "description", "tools", "disallowedTools", "permissionPolicy"}
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "permissionMode", want: "permissionPolicy"},
+		{key: "allowed-tools", want: "tools"},
+		{key: "descripton", want: "description"},
+		{key: "Name", want: "name"},
+		{key: "disallowedtool", want: "disallowedTools"},
+		{key: "color", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, suggestField(tt.key, known))
+		})
+	}
+}
@@ -5,9 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"regexp"
 
 	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 )
 
 // validatePlatform checks if platform parameter is valid.
@@ -15,12 +15,12 @@ func validatePlatform(platform string) []error {
 	var errs []error
 
 	if platform == "" {
-		errs = append(errs, core.NewConfigError("platform", "", "platform is required").WithSuggestions([]string{core.PlatformClaudeCode, core.PlatformOpenCode}))
+		errs = append(errs, core.NewConfigError("platform", "", "platform is required").WithSuggestions(platforms.IDs()))
 		return errs
 	}
 
-	if platform != core.PlatformClaudeCode && platform != core.PlatformOpenCode {
-		errs = append(errs, core.NewConfigError("platform", platform, "unknown platform").WithSuggestions([]string{core.PlatformClaudeCode, core.PlatformOpenCode}))
+	if _, ok := platforms.Lookup(platform); !ok {
+		errs = append(errs, core.NewConfigError("platform", platform, "unknown platform").WithSuggestions(platforms.IDs()))
 		return errs
 	}
 
@@ -31,6 +31,14 @@ func validatePlatform(platform string) []error {
 // The ctx parameter is checked at entry and forwarded to DetectType and
 // ParseDocument so caller cancellation propagates through the load.
 func LoadDocument(ctx context.Context, filepath, platform string) (interface{}, error) {
+	return LoadDocumentWith(ctx, filepath, platform, Options{})
+}
+
+// LoadDocumentWith is LoadDocument with opts. The document type is
+// opts.Type or, when empty, detected (Detect). A *core.ValidationError
+// from variable expansion is returned unwrapped, like a *core.FileError
+// and a *core.ParseError positioned in the file.
+func LoadDocumentWith(ctx context.Context, filepath, platform string, opts Options) (interface{}, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, fmt.Errorf("parser: load cancelled: %w", err)
 	}
@@ -39,15 +47,17 @@ func LoadDocument(ctx context.Context, filepath, platform string) (interface{},
 		return nil, errs[0]
 	}
 
-	docType := DetectType(ctx, filepath)
-	if docType == "" {
-		return nil, core.NewParseError(filepath, "unrecognizable filename (expected: agent-*.md, *-agent.md, etc.)", nil)
+	detection, err := ResolveType(ctx, filepath, opts.Type)
+	if err != nil {
+		return nil, err
 	}
 
-	doc, err := ParseDocument(ctx, filepath, docType)
+	doc, err := ParseDocumentWith(ctx, filepath, detection.Type, opts)
 	if err != nil {
 		var fileErr *core.FileError
-		if errors.As(err, &fileErr) {
+		var validationErr *core.ValidationError
+		var parseErr *core.ParseError
+		if errors.As(err, &fileErr) || errors.As(err, &validationErr) || (errors.As(err, &parseErr) && parseErr.Line() > 0) {
 			return nil, err
 		}
 		return nil, core.NewParseError(filepath, "failed to parse document", err)
@@ -75,20 +85,20 @@ func (p *Parser) LoadDocument(ctx context.Context, path string, platform string)
 	return LoadDocument(ctx, path, platform)
 }
 
-// DetectType detects the document type from the filename. The ctx parameter
-// is checked between regex iterations so a cancelled caller terminates the
-// scan promptly. Detection is regex-only (no I/O); ctx is accept-and-may-ignore
-// for spec symmetry with the cli-framework I/O-adapter ctx-propagation contract.
-func DetectType(ctx context.Context, filepath string) string {
-	for _, p := range detectTypePatterns() {
-		if err := ctx.Err(); err != nil {
-			return ""
-		}
-		if matched, _ := regexp.MatchString(p.pattern, filepath); matched {
-			return p.docType
-		}
+// LoadDocumentWith loads and parses a document from the given path
+// with opts, delegating to the package-level LoadDocumentWith.
+func (p *Parser) LoadDocumentWith(ctx context.Context, path string, platform string, opts Options) (interface{}, error) {
+	return LoadDocumentWith(ctx, path, platform, opts)
+}
+
+// DetectType returns the type of the document at path (see Detect), or
+// "" when it cannot be determined.
+func DetectType(ctx context.Context, path string) string {
+	detection, err := Detect(ctx, path)
+	if err != nil {
+		return ""
 	}
-	return ""
+	return detection.Type
 }
 
 // detectTypePattern pairs a filename regex with the document type it signals.
@@ -117,5 +127,17 @@ func detectTypePatterns() []detectTypePattern {
 		{`.*-skill\.md$`, "skill"},
 		{`skill-.*\.yaml$`, "skill"},
 		{`.*-skill\.yaml$`, "skill"},
+		{`mcp-.*\.md$`, "mcp"},
+		{`.*-mcp\.md$`, "mcp"},
+		{`mcp-.*\.yaml$`, "mcp"},
+		{`.*-mcp\.yaml$`, "mcp"},
+		{`hook-.*\.md$`, "hook"},
+		{`.*-hook\.md$`, "hook"},
+		{`hook-.*\.yaml$`, "hook"},
+		{`.*-hook\.yaml$`, "hook"},
+		{`settings-.*\.md$`, "settings"},
+		{`.*-settings\.md$`, "settings"},
+		{`settings-.*\.yaml$`, "settings"},
+		{`.*-settings\.yaml$`, "settings"},
 	}
 }
@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"gitlab.com/amoconst/germinator/internal/core"
-	yaml "gopkg.in/yaml.v3"
 )
 
 // CanonicalAgent extends the Agent domain model with FilePath and Content fields.
@@ -39,34 +38,125 @@ type CanonicalMemory struct {
 	Content  string
 }
 
+// CanonicalMCPServer extends the MCPServer domain model with FilePath and
+// Content fields. Content holds the Markdown body, which no platform
+// renders; it is kept for notes about the server.
+type CanonicalMCPServer struct {
+	core.MCPServer
+	FilePath string
+	Content  string
+}
+
+// CanonicalHook extends the Hook domain model with FilePath and Content
+// fields. Content holds the Markdown body, which no platform renders.
+type CanonicalHook struct {
+	core.Hook
+	FilePath string
+	Content  string
+}
+
+// CanonicalSettings extends the Settings domain model with FilePath and
+// Content fields. Content holds the Markdown body, which no platform
+// renders.
+type CanonicalSettings struct {
+	core.Settings
+	FilePath string
+	Content  string
+}
+
+// Options tune how documents are parsed.
+type Options struct {
+	// Vars are values for the variables documents declare under vars:,
+	// overriding their defaults (see expandVars).
+	Vars map[string]string
+	// Models are the model aliases a platform document's model is read
+	// back through (core.ModelAliases.Canonical), so a platform model id
+	// becomes its logical name. Canonical documents ignore them.
+	Models core.ModelAliases
+	// Type is the document type LoadDocumentWith parses the file as,
+	// skipping detection. Empty means detect it.
+	Type string
+	// Content, when set, is the document to parse in place of the file
+	// at the path, e.g. one read from stdin; the path then only names
+	// the document in errors and locates its library. Documents it
+	// extends are read from their files.
+	Content []byte
+	// Warn, when set, is called with a warning for every frontmatter key
+	// of a canonical document, or a document it extends, that no field
+	// decodes: a *core.ParseError positioned at the key, suggesting the
+	// key likely meant. Unknown keys are ignored otherwise.
+	Warn func(*core.ParseError)
+}
+
 // ParseDocument parses a document file and returns the appropriate struct.
-// The ctx parameter is checked before the file read so caller cancellation
-// propagates before blocking I/O is attempted.
+// An agent, command, or skill declaring `extends` is returned composed
+// on the documents it extends (see resolveExtends). The ctx parameter is
+// checked before the file read so caller cancellation propagates before
+// blocking I/O is attempted.
 func ParseDocument(ctx context.Context, filePath string, docType string) (interface{}, error) {
+	return ParseDocumentWith(ctx, filePath, docType, Options{})
+}
+
+// ParseDocumentWith is ParseDocument with opts.
+func ParseDocumentWith(ctx context.Context, filePath string, docType string, opts Options) (interface{}, error) {
+	doc, err := parseFile(ctx, filePath, docType, opts)
+	if err != nil {
+		return nil, err
+	}
+	return resolveExtends(ctx, filePath, doc, opts, nil)
+}
+
+// parseFile parses a document file as written, with its variables
+// expanded, without resolving `extends`.
+func parseFile(ctx context.Context, filePath string, docType string, opts Options) (interface{}, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, fmt.Errorf("parser: parse cancelled: %w", err)
 	}
 
-	content, err := os.ReadFile(filePath) //nolint:gosec // G304: User provides file path, tool must read user documents
+	content, err := readContent(filePath, opts)
 	if err != nil {
-		return nil, core.NewFileError(filePath, "read", "failed to read file", err)
+		return nil, err
 	}
 
-	fileContent := string(content)
+	fileContent, positions, err := expandVars(filePath, string(content), opts.Vars)
+	if err != nil {
+		return nil, err
+	}
 
+	var doc interface{}
 	switch docType {
 	case "memory":
-		return parseMemory(ctx, filePath, fileContent)
+		doc, err = parseMemory(ctx, filePath, fileContent, positions.warnings(opts.Warn))
 
-	case "agent", "command", "skill":
-		return parseDocumentWithFrontmatter(ctx, filePath, fileContent, docType)
+	case "agent", "command", "skill", "mcp", "hook", "settings":
+		doc, err = parseDocumentWithFrontmatter(ctx, filePath, fileContent, docType, positions.warnings(opts.Warn))
 
 	default:
 		return nil, core.NewParseError(filePath, "unsupported document type: "+docType, nil)
 	}
+	if err != nil {
+		return nil, positions.sourceError(err)
+	}
+	return doc, nil
+}
+
+// readContent returns opts.Content or, when it is nil, the contents of
+// the file at path.
+func readContent(path string, opts Options) ([]byte, error) {
+	if opts.Content != nil {
+		return opts.Content, nil
+	}
+	content, err := os.ReadFile(path) //nolint:gosec // G304: User provides file path, tool must read user documents
+	if err != nil {
+		return nil, core.NewFileError(path, "read", "failed to read file", err)
+	}
+	return content, nil
 }
 
-func parseMemory(ctx context.Context, filePath string, content string) (interface{}, error) {
+// parseMemory parses a memory document: its frontmatter, decoded as the
+// other types' is (decodeFrontmatter, passing unknown keys to warn), and
+// its body as the content.
+func parseMemory(ctx context.Context, filePath string, content string, warn func(*core.ParseError)) (interface{}, error) {
 	memory := &CanonicalMemory{
 		Memory: core.Memory{
 			Content: content,
@@ -86,7 +176,9 @@ func parseMemory(ctx context.Context, filePath string, content string) (interfac
 	if err != nil {
 		return nil, err
 	}
-	applyMemoryFrontmatter(memory, yamlLines, bodyLines, foundEnd)
+	if err := applyMemoryFrontmatter(filePath, memory, yamlLines, bodyLines, foundEnd, warn); err != nil {
+		return nil, err
+	}
 	return memory, nil
 }
 
@@ -108,65 +200,34 @@ func scanMemoryFrontmatter(ctx context.Context, lines []string) (yamlLines []str
 	return yamlLines, bodyLines, foundEnd, nil
 }
 
-// applyMemoryFrontmatter decodes the frontmatter YAML and applies paths /
-// content to the memory struct. Body content is used when the YAML has no
-// `content:` field but a closing delimiter was found.
-func applyMemoryFrontmatter(memory *CanonicalMemory, yamlLines, bodyLines []string, foundEnd bool) {
-	yamlContent := strings.Join(yamlLines, "\n")
-	var frontmatter map[string]interface{}
-	if err := yaml.Unmarshal([]byte(yamlContent), &frontmatter); err != nil {
-		if foundEnd {
-			memory.Content = strings.Join(bodyLines, "\n")
-			memory.Memory.Content = strings.Join(bodyLines, "\n")
-		}
-		return
+// applyMemoryFrontmatter decodes the frontmatter YAML (decodeFrontmatter)
+// and applies paths / content to the memory struct. Body content is used
+// when the YAML has no `content:` field. Without a closing delimiter the
+// file has no frontmatter and is all content, as for the other types
+// (extractFrontmatter).
+func applyMemoryFrontmatter(path string, memory *CanonicalMemory, yamlLines, bodyLines []string, foundEnd bool, warn func(*core.ParseError)) error {
+	if !foundEnd {
+		memory.Content = memory.Memory.Content
+		return nil
 	}
-	if paths, ok := frontmatter["paths"].([]interface{}); ok {
-		for _, p := range paths {
-			if pathStr, ok := p.(string); ok {
-				memory.Paths = append(memory.Paths, pathStr)
-			}
-		}
+	var frontmatter core.Memory
+	if err := decodeFrontmatter(path, strings.Join(yamlLines, "\n"), "memory", &frontmatter, warn); err != nil {
+		return err
 	}
-	if _, ok := frontmatter["content"].(string); ok {
-		memory.Content = extractContentFromYamlLines(yamlLines)
-		memory.Memory.Content = extractContentFromYamlLines(yamlLines)
-		return
-	}
-	if foundEnd {
-		memory.Content = strings.Join(bodyLines, "\n")
-		memory.Memory.Content = strings.Join(bodyLines, "\n")
-		return
-	}
-	memory.Content = ""
-	memory.Memory.Content = ""
-}
-
-func extractContentFromYamlLines(yamlLines []string) string {
-	for i, line := range yamlLines {
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "content:") {
-			parts := strings.SplitN(trimmed, ":", 2)
-			if len(parts) == 2 && strings.TrimSpace(parts[1]) == "|" {
-				contentLines := []string{}
-				j := i + 1
-				for j < len(yamlLines) {
-					nextLine := yamlLines[j]
-					if nextLine == "" || strings.HasPrefix(nextLine, " ") || strings.HasPrefix(nextLine, "\t") {
-						contentLines = append(contentLines, nextLine)
-						j++
-					} else {
-						break
-					}
-				}
-				return strings.Join(contentLines, "\n")
-			}
-		}
+	memory.Paths = frontmatter.Paths
+	content := frontmatter.Content
+	if content == "" {
+		content = strings.Join(bodyLines, "\n")
 	}
-	return ""
+	memory.Content = content
+	memory.Memory.Content = content
+	return nil
 }
 
-func parseDocumentWithFrontmatter(ctx context.Context, filePath string, fileContent string, docType string) (interface{}, error) {
+// parseDocumentWithFrontmatter parses a document whose fields are its
+// frontmatter (decodeFrontmatter), passing the warnings about keys no
+// field decodes to warn.
+func parseDocumentWithFrontmatter(ctx context.Context, filePath string, fileContent string, docType string, warn func(*core.ParseError)) (interface{}, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, fmt.Errorf("parser: parse cancelled: %w", err)
 	}
@@ -180,8 +241,8 @@ func parseDocumentWithFrontmatter(ctx context.Context, filePath string, fileCont
 	switch docType {
 	case "agent":
 		var agent CanonicalAgent
-		if err := yaml.Unmarshal([]byte(yamlContent), &agent.Agent); err != nil {
-			return nil, core.NewParseError(filePath, "failed to parse agent", err)
+		if err := decodeFrontmatter(filePath, yamlContent, "agent", &agent.Agent, warn); err != nil {
+			return nil, err
 		}
 		agent.FilePath = filePath
 		agent.Content = markdownBody
@@ -189,8 +250,8 @@ func parseDocumentWithFrontmatter(ctx context.Context, filePath string, fileCont
 
 	case "command":
 		var command CanonicalCommand
-		if err := yaml.Unmarshal([]byte(yamlContent), &command.Command); err != nil {
-			return nil, core.NewParseError(filePath, "failed to parse command", err)
+		if err := decodeFrontmatter(filePath, yamlContent, "command", &command.Command, warn); err != nil {
+			return nil, err
 		}
 		command.FilePath = filePath
 		command.Content = markdownBody
@@ -198,12 +259,39 @@ func parseDocumentWithFrontmatter(ctx context.Context, filePath string, fileCont
 
 	case "skill":
 		var skill CanonicalSkill
-		if err := yaml.Unmarshal([]byte(yamlContent), &skill.Skill); err != nil {
-			return nil, core.NewParseError(filePath, "failed to parse skill", err)
+		if err := decodeFrontmatter(filePath, yamlContent, "skill", &skill.Skill, warn); err != nil {
+			return nil, err
 		}
 		skill.FilePath = filePath
 		skill.Content = markdownBody
 		doc = &skill
+
+	case "mcp":
+		var server CanonicalMCPServer
+		if err := decodeFrontmatter(filePath, yamlContent, "mcp server", &server.MCPServer, warn); err != nil {
+			return nil, err
+		}
+		server.FilePath = filePath
+		server.Content = markdownBody
+		doc = &server
+
+	case "hook":
+		var hook CanonicalHook
+		if err := decodeFrontmatter(filePath, yamlContent, "hook", &hook.Hook, warn); err != nil {
+			return nil, err
+		}
+		hook.FilePath = filePath
+		hook.Content = markdownBody
+		doc = &hook
+
+	case "settings":
+		var settings CanonicalSettings
+		if err := decodeFrontmatter(filePath, yamlContent, "settings", &settings.Settings, warn); err != nil {
+			return nil, err
+		}
+		settings.FilePath = filePath
+		settings.Content = markdownBody
+		doc = &settings
 	}
 
 	return doc, nil
@@ -37,7 +37,7 @@ func TestParseMemoryWithPaths(t *testing.T) {
 				t.Fatalf("failed to read file: %v", err)
 			}
 
-			doc, err := parseMemory(context.Background(), tt.filepath, string(content))
+			doc, err := parseMemory(context.Background(), tt.filepath, string(content), nil)
 
 			if tt.expectError {
 				if err == nil {
@@ -105,7 +105,7 @@ func TestParseAgentWithFrontmatter(t *testing.T) {
 				t.Fatalf("failed to read file: %v", err)
 			}
 
-			doc, err := parseDocumentWithFrontmatter(context.Background(), tt.filepath, string(content), "agent")
+			doc, err := parseDocumentWithFrontmatter(context.Background(), tt.filepath, string(content), "agent", nil)
 
 			if tt.expectError {
 				if err == nil {
@@ -167,7 +167,7 @@ func TestParseSkillWithFrontmatter(t *testing.T) {
 				t.Fatalf("failed to read file: %v", err)
 			}
 
-			doc, err := parseDocumentWithFrontmatter(context.Background(), tt.filepath, string(content), "skill")
+			doc, err := parseDocumentWithFrontmatter(context.Background(), tt.filepath, string(content), "skill", nil)
 
 			if tt.expectError {
 				if err == nil {
@@ -221,7 +221,7 @@ func TestParseCommandWithFrontmatter(t *testing.T) {
 				t.Fatalf("failed to read file: %v", err)
 			}
 
-			doc, err := parseDocumentWithFrontmatter(context.Background(), tt.filepath, string(content), "command")
+			doc, err := parseDocumentWithFrontmatter(context.Background(), tt.filepath, string(content), "command", nil)
 
 			if tt.expectError {
 				if err == nil {
@@ -5,58 +5,207 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 
-	claudecode "gitlab.com/amoconst/germinator/internal/claude-code"
 	"gitlab.com/amoconst/germinator/internal/core"
-	opencode "gitlab.com/amoconst/germinator/internal/opencode"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 	yaml "gopkg.in/yaml.v3"
 )
 
-// platformAdapter is the narrow contract parser needs from a platform
-// adapter. Defined here (the consumer) per the "interfaces where
-// consumed" rule (references/01-architecture.md); both
-// internal/claude-code and internal/opencode satisfy it via structural
-// typing, so no shim or compile-time tag is required.
-type platformAdapter interface {
-	ToCanonical(input map[string]interface{}) (*core.Agent, *core.Command, *core.Skill, *core.Memory, error)
+// frontmatterNormalizer is implemented by adapters whose platform writes
+// frontmatter that is not strictly valid YAML (e.g. Cursor's unquoted
+// globs). The hook runs before YAML decoding.
+type frontmatterNormalizer interface {
+	NormalizeFrontmatter(frontmatter string) string
+}
+
+// documentDecoder is implemented by adapters whose platform stores some
+// documents in a format other than Markdown with YAML frontmatter (e.g.
+// Gemini CLI's TOML commands). When handled is true, fields and body
+// replace the frontmatter and Markdown body.
+type documentDecoder interface {
+	DecodeDocument(docType string, content []byte) (fields map[string]interface{}, body string, handled bool, err error)
+}
+
+// mcpDecoder is implemented by adapters whose platform keeps MCP server
+// definitions in a shared config file (.mcp.json, opencode.json). It
+// returns every server the file defines, in a stable order.
+type mcpDecoder interface {
+	DecodeMCPServers(content []byte) ([]core.MCPServer, error)
+}
+
+// fileNamer is implemented by adapters whose platform names documents by
+// file rather than by a frontmatter field (Cursor rules, Copilot prompt
+// and chat mode files). For those documents a missing name is derived
+// from the path.
+type fileNamer interface {
+	NamesFromFile(docType string) bool
+}
+
+// fieldLister is implemented by adapters that list the frontmatter keys
+// ToCanonical reads for a document type (Claude Code, OpenCode). The
+// other keys of a file are kept in the document's Targets under the
+// platform (keepUnknownFields), so a round trip does not lose them.
+type fieldLister interface {
+	KnownFields(docType string) []string
+}
+
+// nameFromPath derives a resource name for platforms that name documents
+// by file rather than frontmatter. It inverts the platform's install
+// layout: the layout's file suffix (".prompt.md", "/SKILL.md") is
+// stripped, falling back to the plain file stem.
+func nameFromPath(path string, target platforms.Platform, docType string) string {
+	base := filepath.Base(path)
+	if layout, ok := target.OutputPath(docType); ok && layout.File == "" {
+		suffix := strings.TrimPrefix(layout.FileSuffix, "/")
+		switch {
+		case layout.UseSubdirectory && base == suffix:
+			return filepath.Base(filepath.Dir(path))
+		case !layout.UseSubdirectory && suffix != "" && strings.HasSuffix(base, suffix) && base != suffix:
+			return strings.TrimSuffix(base, suffix)
+		}
+	}
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// namesFromFile reports whether the adapter derives document names from
+// file names for docType.
+func namesFromFile(adapter platforms.Adapter, docType string) bool {
+	n, ok := adapter.(fileNamer)
+	return ok && n.NamesFromFile(docType)
+}
+
+// decodePlatformDocument splits a platform file into its metadata fields
+// and body. Adapters implementing documentDecoder get the first chance;
+// otherwise the file is read as YAML frontmatter plus a Markdown body.
+func decodePlatformDocument(path string, content []byte, adapter platforms.Adapter, docType string) (map[string]interface{}, string, error) {
+	if d, ok := adapter.(documentDecoder); ok {
+		fields, body, handled, err := d.DecodeDocument(docType, content)
+		if err != nil {
+			return nil, "", core.NewParseError(path, "failed to decode document", err)
+		}
+		if handled {
+			return fields, body, nil
+		}
+	}
+
+	yamlContent, markdownBody, err := extractFrontmatter(string(content))
+	if err != nil {
+		return nil, "", core.NewParseError(path, "failed to extract frontmatter", err)
+	}
+
+	if n, ok := adapter.(frontmatterNormalizer); ok {
+		yamlContent = n.NormalizeFrontmatter(yamlContent)
+	}
+
+	var input map[string]interface{}
+	if err := yaml.Unmarshal([]byte(yamlContent), &input); err != nil {
+		return nil, "", core.NewParseError(path, "failed to parse YAML", err)
+	}
+	return input, markdownBody, nil
+}
+
+// CheckFrontmatter reports whether the YAML frontmatter of content, if
+// it has any, decodes the way decodePlatformDocument reads it: after the
+// adapter's NormalizeFrontmatter hook, when adapter has one. A nil
+// adapter checks canonical frontmatter. Content without frontmatter is
+// valid.
+func CheckFrontmatter(content string, adapter platforms.Adapter) error {
+	yamlContent, _, _ := extractFrontmatter(content)
+	if yamlContent == "" {
+		return nil
+	}
+	if n, ok := adapter.(frontmatterNormalizer); ok {
+		yamlContent = n.NormalizeFrontmatter(yamlContent)
+	}
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal([]byte(yamlContent), &fields); err != nil {
+		return fmt.Errorf("parsing frontmatter: %w", err)
+	}
+	return nil
 }
 
 // ParsePlatformDocument parses a platform YAML file and converts it to a canonical model.
 // The ctx parameter is checked before the file read so caller cancellation
 // propagates before blocking I/O is attempted.
 func ParsePlatformDocument(ctx context.Context, path string, platform string, docType string) (interface{}, error) {
+	return ParsePlatformDocumentWith(ctx, path, platform, docType, Options{})
+}
+
+// ParsePlatformDocumentWith parses a platform document like
+// ParsePlatformDocument, then replaces its model with the logical name
+// opts.Models gives the platform's model id, if any. opts.Content, when
+// set, is parsed in place of the file at path. A document that declares
+// vars: has them expanded with opts.Vars first, as a canonical one does.
+func ParsePlatformDocumentWith(ctx context.Context, path string, platform string, docType string, opts Options) (interface{}, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, fmt.Errorf("parser: platform parse cancelled: %w", err)
 	}
 
-	content, err := os.ReadFile(path) //nolint:gosec // G304: User provides file path, tool must read user documents
+	content, err := readContent(path, opts)
 	if err != nil {
-		return nil, core.NewFileError(path, "read", "failed to read file", err)
+		return nil, err
+	}
+	expanded, _, err := expandVars(path, string(content), opts.Vars)
+	if err != nil {
+		return nil, err
 	}
 
-	fileContent := string(content)
-	yamlContent, markdownBody, err := extractFrontmatter(fileContent)
+	doc, err := ParsePlatformContent(path, []byte(expanded), platform, docType)
 	if err != nil {
-		return nil, core.NewParseError(path, "failed to extract frontmatter", err)
+		return nil, err
+	}
+	switch d := doc.(type) {
+	case *CanonicalAgent:
+		d.Model = opts.Models.Canonical(d.Model, platform)
+	case *CanonicalCommand:
+		d.Model = opts.Models.Canonical(d.Model, platform)
+	case *CanonicalSkill:
+		d.Model = opts.Models.Canonical(d.Model, platform)
 	}
+	return doc, nil
+}
 
-	var input map[string]interface{}
-	if err := yaml.Unmarshal([]byte(yamlContent), &input); err != nil {
-		return nil, core.NewParseError(path, "failed to parse YAML", err)
+// ParsePlatformContent converts platform document content that was not
+// read from path itself, such as a freshly rendered document, to a
+// canonical model. path is where the content would live in the
+// platform's layout: it names the document on platforms that take names
+// from file names, and is recorded as the model's FilePath.
+func ParsePlatformContent(path string, content []byte, platform string, docType string) (interface{}, error) {
+	target, ok := platforms.Lookup(platform)
+	if !ok {
+		return nil, core.NewConfigError("platform", platform, "unsupported platform").WithSuggestions(platforms.IDs())
 	}
+	adapter := target.Adapter()
 
-	if input == nil {
-		input = make(map[string]interface{})
+	if docType == "mcp" {
+		return decodeMCPServer(path, content, platform, adapter, "")
+	}
+	if docType == "hook" {
+		return nil, core.NewParseError(path, "hooks cannot be read back from platform files", nil).
+			WithSuggestions([]string{"write the hook as a canonical hook-<name>.md resource"})
+	}
+	if docType == "settings" {
+		return nil, core.NewParseError(path, "settings cannot be read back from platform files", nil).
+			WithSuggestions([]string{"write the settings as a canonical settings-<name>.md resource"})
 	}
 
-	var adapter platformAdapter
-	switch platform {
-	case "claude-code":
-		adapter = claudecode.ClaudeCode
-	case "opencode":
-		adapter = opencode.OpenCode
-	default:
-		return nil, core.NewConfigError("platform", platform, "unsupported platform")
+	input, markdownBody, err := decodePlatformDocument(path, content, adapter, docType)
+	if err != nil {
+		return nil, err
+	}
+	if docType == "memory" {
+		// Memory files several resources merge into (AGENTS.md,
+		// copilot-instructions.md, GEMINI.md) carry germinator section
+		// markers; they are not content.
+		markdownBody = core.StripSectionMarkers(markdownBody)
+	}
+
+	if input == nil {
+		input = make(map[string]interface{})
 	}
 
 	input["__type"] = docType
@@ -72,6 +221,10 @@ func ParsePlatformDocument(ctx context.Context, path string, platform string, do
 		if agent == nil {
 			return nil, core.NewParseError(path, "expected agent but got nil", nil)
 		}
+		if agent.Name == "" && namesFromFile(adapter, docType) {
+			agent.Name = nameFromPath(path, target, docType)
+		}
+		agent.Targets = keepUnknownFields(adapter, platform, docType, input, agent, agent.Targets)
 		return &CanonicalAgent{
 			Agent:    *agent,
 			FilePath: path,
@@ -81,6 +234,10 @@ func ParsePlatformDocument(ctx context.Context, path string, platform string, do
 		if command == nil {
 			return nil, core.NewParseError(path, "expected command but got nil", nil)
 		}
+		if command.Name == "" && namesFromFile(adapter, docType) {
+			command.Name = nameFromPath(path, target, docType)
+		}
+		command.Targets = keepUnknownFields(adapter, platform, docType, input, command, command.Targets)
 		return &CanonicalCommand{
 			Command:  *command,
 			FilePath: path,
@@ -90,6 +247,10 @@ func ParsePlatformDocument(ctx context.Context, path string, platform string, do
 		if skill == nil {
 			return nil, core.NewParseError(path, "expected skill but got nil", nil)
 		}
+		if skill.Name == "" && namesFromFile(adapter, docType) {
+			skill.Name = nameFromPath(path, target, docType)
+		}
+		skill.Targets = keepUnknownFields(adapter, platform, docType, input, skill, skill.Targets)
 		return &CanonicalSkill{
 			Skill:    *skill,
 			FilePath: path,
@@ -111,3 +272,133 @@ func ParsePlatformDocument(ctx context.Context, path string, platform string, do
 		return nil, core.NewParseError(path, "unsupported document type: "+docType, nil)
 	}
 }
+
+// keepUnknownFields stores every key of input that adapter does not
+// read for docType in targets[platform], from where the platform's
+// templates write it back. Keys that name a field of doc, the canonical
+// model ToCanonical returned, are canonical rather than platform
+// configuration and are not kept, nor is the vars: block expandVars
+// has already applied. Adapters that do not list their keys
+// (fieldLister) keep nothing.
+func keepUnknownFields(adapter platforms.Adapter, platform, docType string, input map[string]interface{}, doc any, targets core.PlatformConfig) core.PlatformConfig {
+	l, ok := adapter.(fieldLister)
+	if !ok {
+		return targets
+	}
+	known := l.KnownFields(docType)
+	if known == nil {
+		return targets
+	}
+	canonical := yamlFields(reflect.TypeOf(doc).Elem())
+	for key, value := range input {
+		if _, ok := canonical[key]; ok || key == "__type" || key == "vars" || slices.Contains(known, key) {
+			continue
+		}
+		if targets == nil {
+			targets = make(core.PlatformConfig)
+		}
+		if targets[platform] == nil {
+			targets[platform] = make(map[string]interface{})
+		}
+		targets[platform][key] = value
+	}
+	return targets
+}
+
+// ParsePlatformMCPServer parses the MCP server called name from a
+// platform config file such as .mcp.json or opencode.json. An empty name
+// selects the only server the file defines; a file with several servers
+// then fails with a *core.ParseError listing their names.
+func ParsePlatformMCPServer(ctx context.Context, path, platform, name string) (*CanonicalMCPServer, error) {
+	content, adapter, err := readPlatformMCPConfig(ctx, path, platform)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMCPServer(path, content, platform, adapter, name)
+}
+
+// ParsePlatformMCPServerContent is ParsePlatformMCPServer for config
+// content that was not read from path, such as one piped to stdin.
+func ParsePlatformMCPServerContent(path string, content []byte, platform, name string) (*CanonicalMCPServer, error) {
+	target, ok := platforms.Lookup(platform)
+	if !ok {
+		return nil, core.NewConfigError("platform", platform, "unsupported platform").WithSuggestions(platforms.IDs())
+	}
+	return decodeMCPServer(path, content, platform, target.Adapter(), name)
+}
+
+// ParsePlatformMCPServers parses every MCP server defined in a platform
+// config file, sorted by name.
+func ParsePlatformMCPServers(ctx context.Context, path, platform string) ([]*CanonicalMCPServer, error) {
+	content, adapter, err := readPlatformMCPConfig(ctx, path, platform)
+	if err != nil {
+		return nil, err
+	}
+	servers, err := decodeMCPServers(path, content, platform, adapter)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]*CanonicalMCPServer, 0, len(servers))
+	for _, s := range servers {
+		s.FilePath = path
+		docs = append(docs, &CanonicalMCPServer{MCPServer: s, FilePath: path})
+	}
+	return docs, nil
+}
+
+func readPlatformMCPConfig(ctx context.Context, path, platform string) ([]byte, platforms.Adapter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, fmt.Errorf("parser: platform parse cancelled: %w", err)
+	}
+
+	content, err := os.ReadFile(path) //nolint:gosec // G304: User provides file path, tool must read user documents
+	if err != nil {
+		return nil, nil, core.NewFileError(path, "read", "failed to read file", err)
+	}
+
+	target, ok := platforms.Lookup(platform)
+	if !ok {
+		return nil, nil, core.NewConfigError("platform", platform, "unsupported platform").WithSuggestions(platforms.IDs())
+	}
+	return content, target.Adapter(), nil
+}
+
+func decodeMCPServers(path string, content []byte, platform string, adapter platforms.Adapter) ([]core.MCPServer, error) {
+	d, ok := adapter.(mcpDecoder)
+	if !ok {
+		return nil, core.NewParseError(path, platform+" does not support mcp documents", nil)
+	}
+	servers, err := d.DecodeMCPServers(content)
+	if err != nil {
+		return nil, core.NewParseError(path, "failed to decode MCP servers", err)
+	}
+	return servers, nil
+}
+
+// decodeMCPServer selects one server from a platform MCP config file.
+func decodeMCPServer(path string, content []byte, platform string, adapter platforms.Adapter, name string) (*CanonicalMCPServer, error) {
+	servers, err := decodeMCPServers(path, content, platform, adapter)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(servers))
+	for _, s := range servers {
+		if (name == "" && len(servers) == 1) || s.Name == name {
+			s.FilePath = path
+			return &CanonicalMCPServer{MCPServer: s, FilePath: path}, nil
+		}
+		names = append(names, s.Name)
+	}
+
+	switch {
+	case len(servers) == 0:
+		return nil, core.NewParseError(path, "no MCP servers defined", nil)
+	case name == "":
+		return nil, core.NewParseError(path, fmt.Sprintf("file defines %d MCP servers (%s)", len(servers), strings.Join(names, ", ")), nil).
+			WithSuggestions([]string{"pass --name to choose one"})
+	default:
+		return nil, core.NewParseError(path, "no MCP server named "+name, nil).
+			WithSuggestions([]string{"use one of: " + strings.Join(names, ", ")})
+	}
+}
@@ -2,9 +2,14 @@ package parser
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 )
 
 func TestParsePlatformDocumentClaudeCodeAgent(t *testing.T) {
@@ -223,6 +228,148 @@ func TestParsePlatformDocumentOpenCodeAgent(t *testing.T) {
 	}
 }
 
+func TestParsePlatformDocumentCursorRule(t *testing.T) {
+	tmpDir := t.TempDir()
+	ruleFile := filepath.Join(tmpDir, "go-style.mdc")
+	ruleContent := `---
+description:
+globs: *.go, cmd/**/*.go
+alwaysApply: false
+---
+Wrap errors with %w.`
+
+	if err := os.WriteFile(ruleFile, []byte(ruleContent), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	doc, err := ParsePlatformDocument(context.Background(), ruleFile, "cursor", "memory")
+	if err != nil {
+		t.Fatalf("ParsePlatformDocument() error = %v (unquoted globs must be normalized)", err)
+	}
+	memory, ok := doc.(*CanonicalMemory)
+	if !ok {
+		t.Fatalf("expected *CanonicalMemory, got %T", doc)
+	}
+	if len(memory.Paths) != 2 || memory.Paths[0] != "*.go" || memory.Paths[1] != "cmd/**/*.go" {
+		t.Errorf("memory.Paths = %v, want [*.go cmd/**/*.go]", memory.Paths)
+	}
+	if memory.Content != "Wrap errors with %w." {
+		t.Errorf("memory.Content = %q", memory.Content)
+	}
+
+	doc, err = ParsePlatformDocument(context.Background(), ruleFile, "cursor", "skill")
+	if err != nil {
+		t.Fatalf("ParsePlatformDocument() error = %v", err)
+	}
+	skill, ok := doc.(*CanonicalSkill)
+	if !ok {
+		t.Fatalf("expected *CanonicalSkill, got %T", doc)
+	}
+	if skill.Name != "go-style" {
+		t.Errorf("skill.Name = %q, want name derived from filename", skill.Name)
+	}
+}
+
+func TestParsePlatformDocumentGeminiCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	cmdFile := filepath.Join(tmpDir, "review.toml")
+	cmdContent := `description = "Review the diff"
+prompt = """
+Review {{args}}."""
+`
+
+	if err := os.WriteFile(cmdFile, []byte(cmdContent), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	doc, err := ParsePlatformDocument(context.Background(), cmdFile, "gemini", "command")
+	if err != nil {
+		t.Fatalf("ParsePlatformDocument() error = %v (TOML commands must be decoded)", err)
+	}
+	cmd, ok := doc.(*CanonicalCommand)
+	if !ok {
+		t.Fatalf("expected *CanonicalCommand, got %T", doc)
+	}
+	if cmd.Name != "review" {
+		t.Errorf("cmd.Name = %q, want name derived from filename", cmd.Name)
+	}
+	if cmd.Description != "Review the diff" {
+		t.Errorf("cmd.Description = %q", cmd.Description)
+	}
+	if cmd.Content != "Review $ARGUMENTS." {
+		t.Errorf("cmd.Content = %q", cmd.Content)
+	}
+
+	if err := os.WriteFile(cmdFile, []byte("prompt = \"unterminated\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	_, err = ParsePlatformDocument(context.Background(), cmdFile, "gemini", "command")
+	var parseErr *core.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("expected *core.ParseError for invalid TOML, got %v", err)
+	}
+}
+
+func TestParsePlatformDocumentWithModels(t *testing.T) {
+	tmpDir := t.TempDir()
+	agentFile := filepath.Join(tmpDir, "reviewer.md")
+	content := "---\ndescription: Reviews code\nmode: subagent\nmodel: anthropic/claude-opus-4-1\n---\nReview.\n"
+	if err := os.WriteFile(agentFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		models core.ModelAliases
+		want   string
+	}{
+		{name: "built-in alias", models: core.DefaultModelAliases(), want: "opus"},
+		{name: "configured alias", models: core.ModelAliases{"smart": {"opencode": "anthropic/claude-opus-4-1"}}, want: "smart"},
+		{name: "no aliases", want: "anthropic/claude-opus-4-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParsePlatformDocumentWith(context.Background(), agentFile, "opencode", "agent", Options{Models: tt.models})
+			if err != nil {
+				t.Fatalf("ParsePlatformDocumentWith() error = %v", err)
+			}
+			agent, ok := doc.(*CanonicalAgent)
+			if !ok {
+				t.Fatalf("expected *CanonicalAgent, got %T", doc)
+			}
+			if agent.Model != tt.want {
+				t.Errorf("Model = %q, want %q", agent.Model, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameFromPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		platform string
+		docType  string
+		want     string
+	}{
+		{".cursor/rules/go-style.mdc", "cursor", "memory", "go-style"},
+		{".claude/commands/review.md", "claude-code", "command", "review"},
+		{".claude/skills/commit/SKILL.md", "claude-code", "skill", "commit"},
+		{".github/prompts/review.prompt.md", "copilot", "command", "review"},
+		{".github/chatmodes/planner.chatmode.md", "copilot", "agent", "planner"},
+		{"elsewhere/review.md", "copilot", "command", "review"},
+	}
+	for _, tt := range tests {
+		target, ok := platforms.Lookup(tt.platform)
+		if !ok {
+			t.Fatalf("platform %q not registered", tt.platform)
+		}
+		if got := nameFromPath(tt.path, target, tt.docType); got != tt.want {
+			t.Errorf("nameFromPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
 func TestParsePlatformDocumentInvalidYAML(t *testing.T) {
 	tmpDir := t.TempDir()
 	invalidFile := filepath.Join(tmpDir, "invalid.md")
@@ -290,6 +437,76 @@ and formatting
 	}
 }
 
+func TestParsePlatformContentKeepsUnknownFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		docType  string
+		content  string
+		want     core.PlatformConfig
+	}{
+		{
+			name:     "claude-code agent",
+			platform: "claude-code",
+			docType:  "agent",
+			content:  "---\nname: reviewer\ndescription: Reviews\ncolor: blue\nskills:\n  - lint\n---\n",
+			want:     core.PlatformConfig{"claude-code": {"color": "blue", "skills": []string{"lint"}}},
+		},
+		{
+			name:     "opencode agent",
+			platform: "opencode",
+			docType:  "agent",
+			content:  "---\ndescription: Reviews\ntop_p: 0.9\noptions:\n  reasoningEffort: high\n---\n",
+			want: core.PlatformConfig{"opencode": {
+				"top_p":   0.9,
+				"options": map[string]interface{}{"reasoningEffort": "high"},
+			}},
+		},
+		{
+			name:     "claude-code command",
+			platform: "claude-code",
+			docType:  "command",
+			content:  "---\nname: review\ndescription: Review\nallowed-tools: Bash(git:*)\n---\n",
+			want:     core.PlatformConfig{"claude-code": {"allowed-tools": "Bash(git:*)"}},
+		},
+		{
+			name:     "canonical keys are not kept",
+			platform: "claude-code",
+			docType:  "agent",
+			content:  "---\nname: reviewer\ndescription: Reviews\npermissionPolicy: balanced\n---\n",
+			want:     core.PlatformConfig{},
+		},
+		{
+			name:     "adapters without a field list keep nothing",
+			platform: "cursor",
+			docType:  "skill",
+			content:  "---\nname: review\ndescription: Review\ncolor: blue\n---\n",
+			want:     core.PlatformConfig{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParsePlatformContent("doc.md", []byte(tt.content), tt.platform, tt.docType)
+			if err != nil {
+				t.Fatalf("ParsePlatformContent() unexpected error: %v", err)
+			}
+			var got core.PlatformConfig
+			switch d := doc.(type) {
+			case *CanonicalAgent:
+				got = d.Targets
+			case *CanonicalCommand:
+				got = d.Targets
+			case *CanonicalSkill:
+				got = d.Targets
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Targets = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParsePlatformDocumentUnsupportedPlatform(t *testing.T) {
 	tmpDir := t.TempDir()
 	testFile := filepath.Join(tmpDir, "test.md")
@@ -346,3 +563,55 @@ func containsInString(s, substr string) bool {
 	}
 	return false
 }
+
+func TestCheckFrontmatter(t *testing.T) {
+	cursor, _ := platforms.Lookup(core.PlatformCursor)
+
+	tests := []struct {
+		name    string
+		content string
+		adapter platforms.Adapter
+		wantErr bool
+	}{
+		{"valid", "---\ndescription: 'Reviews: code'\n---\nBody", nil, false},
+		{"no frontmatter", `{"mcpServers": {}}`, nil, false},
+		{"unquoted colon", "---\ndescription: Reviews: code\n---\nBody", nil, true},
+		{"unquoted globs without normalizer", "---\nglobs: *.go\n---\nBody", nil, true},
+		{"unquoted globs with cursor normalizer", "---\nglobs: *.go\n---\nBody", cursor.Adapter(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckFrontmatter(tt.content, tt.adapter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckFrontmatter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParsePlatformContent(t *testing.T) {
+	content := []byte("---\ndescription: Reviews code\nmaxSteps: 5\n---\nReview carefully.")
+
+	doc, err := ParsePlatformContent(filepath.Join(".opencode", "agents", "reviewer.md"), content, "opencode", "agent")
+	if err != nil {
+		t.Fatalf("ParsePlatformContent() error = %v", err)
+	}
+	agent, ok := doc.(*CanonicalAgent)
+	if !ok {
+		t.Fatalf("expected *CanonicalAgent, got %T", doc)
+	}
+	if agent.Name != "reviewer" {
+		t.Errorf("agent.Name = %q, want name derived from the OpenCode file name", agent.Name)
+	}
+	if agent.Behavior.Steps != 5 {
+		t.Errorf("agent.Behavior.Steps = %d, want 5", agent.Behavior.Steps)
+	}
+	if agent.Content != "Review carefully." {
+		t.Errorf("agent.Content = %q", agent.Content)
+	}
+
+	if _, err := ParsePlatformContent("x.md", content, "nope", "agent"); err == nil {
+		t.Error("expected an error for an unknown platform")
+	}
+}
@@ -0,0 +1,227 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// templateAction matches one {{ ... }} template action.
+var templateAction = regexp.MustCompile(`\{\{.*?\}\}`)
+
+// expandVars expands the {{ .Vars.<name> }} references of a document
+// that declares variables in its frontmatter:
+//
+//	vars:
+//	  project_name:                # no default: required
+//	  test_command: go test ./...
+//
+// with values, falling back to the declared defaults (core.ExpandVars).
+// A document without a vars: block is returned unchanged, so documents
+// that merely mention {{ }} are not treated as templates. The body is
+// expanded as text and the frontmatter value by value
+// (expandFrontmatter), so a value such as "a: b" cannot change its
+// structure. The positions returned map the frontmatter of the result
+// back to content. A *core.ValidationError naming a missing variable is
+// returned as is.
+func expandVars(path, content string, values map[string]string) (string, sourcePositions, error) {
+	declared := declaredVars(content)
+	if declared == nil {
+		return content, nil, nil
+	}
+	frontmatter, body, _ := extractFrontmatter(content) //nolint:errcheck // extractFrontmatter never fails
+	body, err := core.ExpandVars(body, declared, values)
+	var positions sourcePositions
+	if err == nil {
+		frontmatter, positions, err = expandFrontmatter(frontmatter, declared, values)
+	}
+	if err != nil {
+		var verr *core.ValidationError
+		if errors.As(err, &verr) {
+			return "", nil, verr.WithContext(path)
+		}
+		return "", nil, core.NewParseError(path, "failed to expand variables", err)
+	}
+	return "---\n" + frontmatter + "\n---\n" + body, positions, nil
+}
+
+// position is a 1-based line and column of a file.
+type position struct {
+	line, column int
+}
+
+// sourcePositions maps the file position of every node of a frontmatter
+// expandFrontmatter re-encoded to the position the node was written at,
+// so that the errors and warnings decoding it point into the source.
+// A nil sourcePositions leaves every position as it is.
+type sourcePositions map[position]position
+
+// add records the positions of the nodes under expanded, the re-encoded
+// form of source.
+func (p sourcePositions) add(expanded, source *yaml.Node) {
+	p[position{expanded.Line + frontmatterLine - 1, expanded.Column}] = position{source.Line + frontmatterLine - 1, source.Column}
+	if len(expanded.Content) != len(source.Content) {
+		return
+	}
+	for i := range expanded.Content {
+		p.add(expanded.Content[i], source.Content[i])
+	}
+}
+
+// original returns e located at its source position. A position with no
+// node, such as one known by line only, takes the line of the first node
+// on its line.
+func (p sourcePositions) original(e *core.ParseError) *core.ParseError {
+	if len(p) == 0 || e.Line() == 0 {
+		return e
+	}
+	if to, ok := p[position{e.Line(), e.Column()}]; ok {
+		return e.WithPosition(to.line, to.column)
+	}
+	var from, to position
+	for f, t := range p {
+		if f.line == e.Line() && (from.line == 0 || f.column < from.column) {
+			from, to = f, t
+		}
+	}
+	if from.line == 0 {
+		return e
+	}
+	return e.WithPosition(to.line, 0)
+}
+
+// warnings returns warn with its warnings located at their source
+// positions.
+func (p sourcePositions) warnings(warn func(*core.ParseError)) func(*core.ParseError) {
+	if len(p) == 0 || warn == nil {
+		return warn
+	}
+	return func(w *core.ParseError) { warn(p.original(w)) }
+}
+
+// sourceError returns err located at its source position when it is a
+// *core.ParseError, and err otherwise.
+func (p sourcePositions) sourceError(err error) error {
+	var perr *core.ParseError
+	if len(p) == 0 || !errors.As(err, &perr) {
+		return err
+	}
+	return p.original(perr)
+}
+
+// varPlaceholder matches the stand-ins expandFrontmatter puts in place
+// of template actions, capturing the action's index.
+var varPlaceholder = regexp.MustCompile(`germinator-var-(\d+)-`)
+
+// expandFrontmatter expands the template actions of frontmatter inside
+// the YAML scalars that hold them, after decoding, and re-encodes the
+// result. A plain scalar is re-typed from its expanded value (steps:
+// {{ .Vars.steps }} is an int); a quoted or block scalar stays a string.
+// Either way YAML syntax in a value is quoted rather than parsed. The
+// positions returned map the re-encoded nodes back to frontmatter.
+// Frontmatter that is not YAML until expanded, such as an {{ if }}
+// spanning several keys, is expanded as text, with no positions.
+func expandFrontmatter(frontmatter string, declared map[string]*string, values map[string]string) (string, sourcePositions, error) {
+	actions := templateAction.FindAllString(frontmatter, -1)
+	if len(actions) == 0 {
+		return frontmatter, nil, nil
+	}
+	i := 0
+	marked := templateAction.ReplaceAllStringFunc(frontmatter, func(string) string {
+		i++
+		return fmt.Sprintf("germinator-var-%d-", i-1)
+	})
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(marked), &root); err != nil {
+		expanded, err := core.ExpandVars(frontmatter, declared, values)
+		return expanded, nil, err //nolint:wrapcheck // typed core errors; expandVars adds the path
+	}
+	if err := expandScalars(&root, actions, declared, values); err != nil {
+		return "", nil, err
+	}
+
+	var sb strings.Builder
+	enc := yaml.NewEncoder(&sb)
+	enc.SetIndent(2)
+	if err := enc.Encode(&root); err != nil {
+		return "", nil, fmt.Errorf("encoding expanded frontmatter: %w", err)
+	}
+	var encoded yaml.Node
+	if err := yaml.Unmarshal([]byte(sb.String()), &encoded); err != nil {
+		return "", nil, fmt.Errorf("decoding expanded frontmatter: %w", err)
+	}
+	positions := sourcePositions{}
+	positions.add(&encoded, &root)
+	return strings.TrimSuffix(sb.String(), "\n"), positions, nil
+}
+
+// expandScalars expands, in place, every scalar under n holding a
+// placeholder for one of actions.
+func expandScalars(n *yaml.Node, actions []string, declared map[string]*string, values map[string]string) error {
+	for _, c := range n.Content {
+		if err := expandScalars(c, actions, declared, values); err != nil {
+			return err
+		}
+	}
+	if n.Kind != yaml.ScalarNode || !varPlaceholder.MatchString(n.Value) {
+		return nil
+	}
+	text := varPlaceholder.ReplaceAllStringFunc(n.Value, func(m string) string {
+		i, _ := strconv.Atoi(varPlaceholder.FindStringSubmatch(m)[1]) //nolint:errcheck // the pattern only matches digits
+		return actions[i]
+	})
+	expanded, err := core.ExpandVars(text, declared, values)
+	if err != nil {
+		return err //nolint:wrapcheck // typed core errors; expandVars adds the path
+	}
+	n.Value = expanded
+	if n.Style == 0 {
+		n.Tag = ""
+	}
+	return nil
+}
+
+// declaredVars returns the variables declared in the frontmatter of
+// content, or nil when it declares none. Template actions are blanked
+// before decoding: a frontmatter value that starts with {{ is not valid
+// YAML until expanded.
+func declaredVars(content string) map[string]*string {
+	frontmatter, _, _ := extractFrontmatter(content) //nolint:errcheck // extractFrontmatter never fails
+	if frontmatter == "" {
+		return nil
+	}
+	var decl struct {
+		Vars map[string]*string `yaml:"vars"`
+	}
+	if err := yaml.Unmarshal([]byte(templateAction.ReplaceAllString(frontmatter, "_")), &decl); err != nil {
+		return nil
+	}
+	return decl.Vars
+}
+
+// LoadValues reads a values file: a YAML mapping of variable names to
+// scalar values, such as
+//
+//	project_name: germinator
+//	test_command: make test
+func LoadValues(ctx context.Context, path string) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("parser: load values cancelled: %w", err)
+	}
+	content, err := os.ReadFile(path) //nolint:gosec // G304: User provides the values file path
+	if err != nil {
+		return nil, core.NewFileError(path, "read", "failed to read values file", err)
+	}
+	var values map[string]string
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, core.NewParseError(path, "values file must map variable names to scalar values", err)
+	}
+	return values, nil
+}
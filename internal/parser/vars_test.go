@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestParseDocumentWith_Vars(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	templated := write("command-test.md", "---\nname: test\ndescription: Run the {{ .Vars.project }} tests\n"+
+		"vars:\n  project:\n  test_command: go test ./...\n---\nRun `{{ .Vars.test_command }}`.\n")
+	plain := write("command-plain.md", "---\nname: plain\ndescription: Plain\n---\nLiteral {{ .Values.x }} text.\n")
+
+	doc, err := ParseDocumentWith(context.Background(), templated, "command", Options{Vars: map[string]string{"project": "germinator"}})
+	require.NoError(t, err)
+	cmd := doc.(*CanonicalCommand)
+	assert.Equal(t, "Run the germinator tests", cmd.Description)
+	assert.Equal(t, "Run `go test ./...`.\n", cmd.Content)
+
+	_, err = LoadDocument(context.Background(), templated, "opencode")
+	var verr *core.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "project", verr.Value())
+
+	doc, err = ParseDocument(context.Background(), plain, "command")
+	require.NoError(t, err)
+	assert.Equal(t, "Literal {{ .Values.x }} text.\n", doc.(*CanonicalCommand).Content,
+		"documents without vars: are not templates")
+}
+
+func TestParseDocumentWith_VarsInFrontmatter(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "agent-reviewer.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\nname: reviewer\ndescription: {{ .Vars.summary }}\n"+
+		"model: \"{{ .Vars.model }}\"\nbehavior:\n  steps: {{ .Vars.steps }}\n"+
+		"vars:\n  summary:\n  model: sonnet\n  steps: \"5\"\n---\nSummary: {{ .Vars.summary }}\n"), 0o600))
+
+	doc, err := ParseDocumentWith(context.Background(), path, "agent", Options{
+		Vars: map[string]string{"summary": "a: b", "model": "x\" y"},
+	})
+	require.NoError(t, err)
+	agent := doc.(*CanonicalAgent)
+	assert.Equal(t, "reviewer", agent.Name)
+	assert.Equal(t, "a: b", agent.Description, "a value is not parsed as YAML")
+	assert.Equal(t, "x\" y", agent.Model, "quotes in a quoted value are escaped")
+	assert.Equal(t, 5, agent.Behavior.Steps, "a plain scalar takes the type of its value")
+	assert.Equal(t, "Summary: a: b\n", agent.Content)
+}
+
+func TestLoadValues(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "values.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("project: germinator\nsteps: 5\n"), 0o600))
+
+	values, err := LoadValues(context.Background(), path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"project": "germinator", "steps": "5"}, values)
+
+	require.NoError(t, os.WriteFile(path, []byte("project: [a, b]\n"), 0o600))
+	_, err = LoadValues(context.Background(), path)
+	var perr *core.ParseError
+	require.ErrorAs(t, err, &perr)
+}
+
+func TestParseDocumentWith_VarsPositions(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "agent-reviewer.md")
+	content := "---\nname: reviewer\ndescription: {{ .Vars.summary }}\n\n\ntools: [read,\n  grep]\n" +
+		"favouriteColour: blue\nbehavior:\n    steps: {{ .Vars.steps }}\nvars:\n  summary: Reviews\n  steps: many\n---\nBody\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	var warnings []*core.ParseError
+	_, err := ParseDocumentWith(context.Background(), path, "agent", Options{
+		Warn: func(w *core.ParseError) { warnings = append(warnings, w) },
+	})
+	var perr *core.ParseError
+	require.ErrorAs(t, err, &perr)
+	assert.Equal(t, "failed to parse agent", perr.Message())
+	assert.Equal(t, 10, perr.Line(), "the type error is at the value as written")
+	assert.Equal(t, 12, perr.Column())
+
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(content, "steps: many", "steps: \"5\"", 1)), 0o600))
+	warnings = nil
+	_, err = ParseDocumentWith(context.Background(), path, "agent", Options{
+		Warn: func(w *core.ParseError) { warnings = append(warnings, w) },
+	})
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "unknown field favouriteColour", warnings[0].Message())
+	assert.Equal(t, 8, warnings[0].Line())
+	assert.Equal(t, 1, warnings[0].Column())
+}
@@ -31,6 +31,46 @@ func ToPascalCase(s string) string {
 	return result.String()
 }
 
+// claudeCodeTools lists the built-in Claude Code tool names. Canonical
+// documents spell tools in kebab-case (web-fetch) or, as canonicalize
+// writes them, in lowercase (webfetch); both map to these names.
+var claudeCodeTools = []string{
+	"Agent",
+	"AskUserQuestion",
+	"Bash",
+	"BashOutput",
+	"Edit",
+	"ExitPlanMode",
+	"Glob",
+	"Grep",
+	"KillShell",
+	"LSP",
+	"MultiEdit",
+	"NotebookEdit",
+	"Read",
+	"SlashCommand",
+	"Skill",
+	"Task",
+	"TodoWrite",
+	"WebFetch",
+	"WebSearch",
+	"Write",
+}
+
+// ClaudeCodeToolName returns the name Claude Code knows a canonical tool
+// by: a built-in tool matched case-insensitively, as written or in
+// PascalCase (webfetch and web-fetch both give WebFetch), else name in
+// PascalCase. ok reports whether the tool is built in.
+func ClaudeCodeToolName(name string) (string, bool) {
+	pascal := ToPascalCase(name)
+	for _, t := range claudeCodeTools {
+		if strings.EqualFold(t, name) || strings.EqualFold(t, pascal) {
+			return t, true
+		}
+	}
+	return pascal, false
+}
+
 // ToLowerCase converts a string to lowercase.
 func ToLowerCase(s string) string {
 	return strings.ToLower(s)
@@ -0,0 +1,281 @@
+package permission
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+// presetTools lists the tools a PermissionPolicy preset covers, in the
+// order platforms render them.
+var presetTools = []string{"edit", "bash", "read", "grep", "glob", "list", "webfetch", "websearch"}
+
+// presetOrder is the order InferPolicy tries presets in. Permissive
+// precedes unrestricted because both expand to the same rules and
+// permissive is the conservative reading.
+var presetOrder = []core.PermissionPolicy{
+	core.PermissionPolicyRestrictive,
+	core.PermissionPolicyBalanced,
+	core.PermissionPolicyPermissive,
+	core.PermissionPolicyAnalysis,
+	core.PermissionPolicyUnrestricted,
+}
+
+// actions returns the per-tool actions of m keyed by canonical tool name.
+func (m Map) actions() map[string]Action {
+	return map[string]Action{
+		"edit":      m.Edit,
+		"bash":      m.Bash,
+		"read":      m.Read,
+		"grep":      m.Grep,
+		"glob":      m.Glob,
+		"list":      m.List,
+		"webfetch":  m.WebFetch,
+		"websearch": m.WebSearch,
+	}
+}
+
+// ExpandPolicy returns the rules a preset stands for: one "*" rule per
+// preset tool, taken from the OpenCode column of PermissionPolicyMappings.
+func ExpandPolicy(policy core.PermissionPolicy) (core.PermissionRules, error) {
+	mapping, ok := PermissionPolicyMappings[string(policy)]
+	if !ok {
+		return nil, core.NewConfigError("permission-policy", string(policy), "unknown permission policy")
+	}
+	rules := make(core.PermissionRules, len(presetTools))
+	for tool, action := range mapping.OpenCode.actions() {
+		if action != "" {
+			rules[tool] = map[string]core.PermissionAction{"*": core.PermissionAction(action)}
+		}
+	}
+	return rules, nil
+}
+
+// EffectiveRules expands policy (when set) and layers rules on top, so
+// an explicit rule overrides the preset for the same tool and specifier.
+func EffectiveRules(policy core.PermissionPolicy, rules core.PermissionRules) (core.PermissionRules, error) {
+	if policy == "" {
+		return core.PermissionRules{}.Merge(rules), nil
+	}
+	expanded, err := ExpandPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	return expanded.Merge(rules), nil
+}
+
+// InferPolicy is the inverse of EffectiveRules: it finds the first preset
+// whose expansion is contained in rules and returns it with the rules
+// the preset does not account for. When no preset matches, the policy is
+// empty and every rule is returned.
+func InferPolicy(rules core.PermissionRules) (core.PermissionPolicy, core.PermissionRules) {
+	for _, policy := range presetOrder {
+		expanded, _ := ExpandPolicy(policy)
+		if !covers(rules, expanded) {
+			continue
+		}
+		rest := make(core.PermissionRules)
+		for tool, specs := range rules {
+			for spec, action := range specs {
+				if expanded[tool][spec] == action {
+					continue
+				}
+				if rest[tool] == nil {
+					rest[tool] = make(map[string]core.PermissionAction)
+				}
+				rest[tool][spec] = action
+			}
+		}
+		return policy, rest
+	}
+	return "", rules
+}
+
+func covers(rules, expanded core.PermissionRules) bool {
+	for tool, specs := range expanded {
+		for spec, action := range specs {
+			if rules[tool][spec] != action {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IsPresetShaped reports whether rules hold only "*" rules for preset
+// tools, i.e. whether a preset alone could have produced their shape.
+func IsPresetShaped(rules core.PermissionRules) bool {
+	for tool, specs := range rules {
+		if !slices.Contains(presetTools, tool) {
+			return false
+		}
+		for spec := range specs {
+			if spec != "*" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Tools returns the tool names of rules in render order: preset tools
+// first in their fixed order, then any other tools alphabetically.
+func Tools(rules core.PermissionRules) []string {
+	tools := make([]string, 0, len(rules))
+	for _, tool := range presetTools {
+		if _, ok := rules[tool]; ok {
+			tools = append(tools, tool)
+		}
+	}
+	var extra []string
+	for tool := range rules {
+		if !slices.Contains(presetTools, tool) {
+			extra = append(extra, tool)
+		}
+	}
+	sort.Strings(extra)
+	return append(tools, extra...)
+}
+
+// Specifiers returns the specifiers of one tool's rules with "*" first
+// and the rest alphabetically.
+func Specifiers(specs map[string]core.PermissionAction) []string {
+	out := make([]string, 0, len(specs))
+	for spec := range specs {
+		out = append(out, spec)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i] == "*" || out[j] == "*" {
+			return out[i] == "*" && out[j] != "*"
+		}
+		return out[i] < out[j]
+	})
+	return out
+}
+
+// ClaudeCodeRule formats one rule as a Claude Code permission rule
+// string. The "*" specifier yields the bare tool name; for bash a
+// trailing "*" becomes Claude Code's ":*" prefix syntax:
+//
+//	("bash", "git push*")              → Bash(git push:*)
+//	("edit", "docs/**")                → Edit(docs/**)
+//	("webfetch", "domain:example.com") → WebFetch(domain:example.com)
+func ClaudeCodeRule(tool, specifier string) string {
+	name, _ := ClaudeCodeToolName(tool)
+	if specifier == "*" || specifier == "" {
+		return name
+	}
+	if tool == "bash" {
+		if prefix, ok := strings.CutSuffix(specifier, "*"); ok && !strings.HasSuffix(prefix, ":") {
+			specifier = prefix + ":*"
+		}
+	}
+	return name + "(" + specifier + ")"
+}
+
+// ParseClaudeCodeRule reverses ClaudeCodeRule, returning the canonical
+// tool name and specifier of a Claude Code permission rule string.
+func ParseClaudeCodeRule(rule string) (tool, specifier string, err error) {
+	rule = strings.TrimSpace(rule)
+	name, rest, hasSpec := strings.Cut(rule, "(")
+	if name == "" || (hasSpec && !strings.HasSuffix(rest, ")")) {
+		return "", "", core.NewConfigError("permission-rule", rule, "malformed permission rule: "+rule).
+			WithSuggestions([]string{"use Tool or Tool(specifier), e.g. Bash(git push:*)"})
+	}
+	tool = ToLowerCase(name)
+	if !hasSpec {
+		return tool, "*", nil
+	}
+	specifier = strings.TrimSuffix(rest, ")")
+	if prefix, ok := strings.CutSuffix(specifier, ":*"); ok {
+		specifier = prefix + "*"
+	}
+	if specifier == "" {
+		specifier = "*"
+	}
+	return tool, specifier, nil
+}
+
+// ClaudeCodePermissions groups rules into Claude Code's allow, ask, and
+// deny lists of rule strings, each in render order. Empty lists are
+// omitted.
+func ClaudeCodePermissions(rules core.PermissionRules) map[core.PermissionAction][]string {
+	lists := make(map[core.PermissionAction][]string)
+	for _, tool := range Tools(rules) {
+		for _, spec := range Specifiers(rules[tool]) {
+			action := rules[tool][spec]
+			lists[action] = append(lists[action], ClaudeCodeRule(tool, spec))
+		}
+	}
+	return lists
+}
+
+// ParseClaudeCodePermissions reads a Claude Code permissions object
+// ({allow: [...], ask: [...], deny: [...]}) into canonical rules. Keys
+// other than the three action lists (e.g. defaultMode) are ignored.
+func ParseClaudeCodePermissions(perms map[string]interface{}) (core.PermissionRules, error) {
+	rules := make(core.PermissionRules)
+	for _, action := range []core.PermissionAction{core.PermissionAllow, core.PermissionAsk, core.PermissionDeny} {
+		list, ok := perms[string(action)].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, raw := range list {
+			ruleStr, ok := raw.(string)
+			if !ok {
+				return nil, core.NewConfigError("permission-rule", fmt.Sprint(raw), "permission rules must be strings")
+			}
+			tool, spec, err := ParseClaudeCodeRule(ruleStr)
+			if err != nil {
+				return nil, err
+			}
+			if rules[tool] == nil {
+				rules[tool] = make(map[string]core.PermissionAction)
+			}
+			rules[tool][spec] = action
+		}
+	}
+	return rules, nil
+}
+
+// OpenCodePermission renders rules as the body of an OpenCode
+// `permission:` block: one nested object per tool, each specifier
+// quoted, in render order. The result carries no trailing newline.
+func OpenCodePermission(rules core.PermissionRules) string {
+	var lines []string
+	for _, tool := range Tools(rules) {
+		lines = append(lines, "  "+tool+":")
+		for _, spec := range Specifiers(rules[tool]) {
+			lines = append(lines, fmt.Sprintf("    %q: %s", spec, rules[tool][spec]))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseOpenCodePermission reads an OpenCode permission object into
+// canonical rules. A bare action string for a tool (edit: deny) is read
+// as its "*" rule. Action strings are not validated here; callers run
+// ValidateActionStrings first.
+func ParseOpenCodePermission(perm map[string]interface{}) core.PermissionRules {
+	rules := make(core.PermissionRules, len(perm))
+	for tool, raw := range perm {
+		specs := make(map[string]core.PermissionAction)
+		switch v := raw.(type) {
+		case string:
+			specs["*"] = core.PermissionAction(v)
+		case map[string]interface{}:
+			for spec, action := range v {
+				if s, ok := action.(string); ok {
+					specs[spec] = core.PermissionAction(s)
+				}
+			}
+		}
+		if len(specs) > 0 {
+			rules[ToLowerCase(tool)] = specs
+		}
+	}
+	return rules
+}
@@ -0,0 +1,181 @@
+package permission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestExpandPolicy(t *testing.T) {
+	rules, err := ExpandPolicy(core.PermissionPolicyAnalysis)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"edit", "bash", "read", "grep", "glob", "list", "webfetch", "websearch"}, Tools(rules))
+	assert.Equal(t, core.PermissionDeny, rules["edit"]["*"])
+	assert.Equal(t, core.PermissionAllow, rules["read"]["*"])
+
+	_, err = ExpandPolicy("invalid")
+	var cfgErr *core.ConfigError
+	require.ErrorAs(t, err, &cfgErr)
+}
+
+func TestEffectiveRules(t *testing.T) {
+	rules := core.PermissionRules{
+		"bash": {"*": core.PermissionDeny, "git push*": core.PermissionDeny},
+		"task": {"*": core.PermissionAsk},
+	}
+
+	effective, err := EffectiveRules(core.PermissionPolicyBalanced, rules)
+	require.NoError(t, err)
+	assert.Equal(t, core.PermissionDeny, effective["bash"]["*"], "explicit rule overrides the preset")
+	assert.Equal(t, core.PermissionDeny, effective["bash"]["git push*"])
+	assert.Equal(t, core.PermissionAllow, effective["edit"]["*"], "preset rule is kept")
+	assert.Equal(t, "task", Tools(effective)[len(effective)-1], "extra tools render after preset tools")
+	assert.NotContains(t, rules, "edit", "input must not be mutated")
+}
+
+func TestInferPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     core.PermissionPolicy
+		rules      core.PermissionRules
+		wantPolicy core.PermissionPolicy
+		wantRest   core.PermissionRules
+	}{
+		{
+			name:       "preset with extra rules",
+			policy:     core.PermissionPolicyBalanced,
+			rules:      core.PermissionRules{"bash": {"git push*": core.PermissionDeny}},
+			wantPolicy: core.PermissionPolicyBalanced,
+			wantRest:   core.PermissionRules{"bash": {"git push*": core.PermissionDeny}},
+		},
+		{
+			name:       "identical presets resolve to permissive",
+			policy:     core.PermissionPolicyUnrestricted,
+			wantPolicy: core.PermissionPolicyPermissive,
+			wantRest:   core.PermissionRules{},
+		},
+		{
+			name:       "no preset",
+			rules:      core.PermissionRules{"edit": {"docs/**": core.PermissionAllow}},
+			wantPolicy: "",
+			wantRest:   core.PermissionRules{"edit": {"docs/**": core.PermissionAllow}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			effective, err := EffectiveRules(tt.policy, tt.rules)
+			require.NoError(t, err)
+
+			policy, rest := InferPolicy(effective)
+			assert.Equal(t, tt.wantPolicy, policy)
+			assert.Equal(t, tt.wantRest, rest)
+		})
+	}
+}
+
+func TestIsPresetShaped(t *testing.T) {
+	preset, err := ExpandPolicy(core.PermissionPolicyRestrictive)
+	require.NoError(t, err)
+	assert.True(t, IsPresetShaped(preset))
+	assert.True(t, IsPresetShaped(core.PermissionRules{"edit": {"*": core.PermissionDeny}}))
+	assert.False(t, IsPresetShaped(core.PermissionRules{"bash": {"git push*": core.PermissionDeny}}))
+	assert.False(t, IsPresetShaped(core.PermissionRules{"task": {"*": core.PermissionDeny}}))
+}
+
+func TestClaudeCodeRule(t *testing.T) {
+	tests := []struct {
+		name      string
+		tool      string
+		specifier string
+		rule      string
+	}{
+		{"whole tool", "bash", "*", "Bash"},
+		{"bash prefix", "bash", "git push*", "Bash(git push:*)"},
+		{"bash exact", "bash", "npm test", "Bash(npm test)"},
+		{"path glob", "edit", "docs/**", "Edit(docs/**)"},
+		{"read file", "read", "./.env", "Read(./.env)"},
+		{"web fetch domain", "webfetch", "domain:example.com", "WebFetch(domain:example.com)"},
+		{"web search", "websearch", "*", "WebSearch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.rule, ClaudeCodeRule(tt.tool, tt.specifier))
+
+			tool, specifier, err := ParseClaudeCodeRule(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.tool, tool, "tool round-trip")
+			assert.Equal(t, tt.specifier, specifier, "specifier round-trip")
+		})
+	}
+}
+
+func TestParseClaudeCodeRule_Malformed(t *testing.T) {
+	for _, rule := range []string{"", "(docs/**)", "Edit(docs/**"} {
+		t.Run(rule, func(t *testing.T) {
+			_, _, err := ParseClaudeCodeRule(rule)
+			var cfgErr *core.ConfigError
+			require.ErrorAs(t, err, &cfgErr)
+		})
+	}
+}
+
+func TestClaudeCodePermissions(t *testing.T) {
+	rules := core.PermissionRules{
+		"bash": {"git push*": core.PermissionDeny, "git status": core.PermissionAllow},
+		"edit": {"docs/**": core.PermissionAllow},
+	}
+
+	lists := ClaudeCodePermissions(rules)
+	assert.Equal(t, map[core.PermissionAction][]string{
+		core.PermissionAllow: {"Edit(docs/**)", "Bash(git status)"},
+		core.PermissionDeny:  {"Bash(git push:*)"},
+	}, lists)
+
+	parsed, err := ParseClaudeCodePermissions(map[string]interface{}{
+		"allow":       []interface{}{"Edit(docs/**)", "Bash(git status)"},
+		"deny":        []interface{}{"Bash(git push:*)"},
+		"defaultMode": "acceptEdits",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, rules, parsed)
+}
+
+func TestClaudeCodePermissions_BuiltinToolNames(t *testing.T) {
+	settings := map[string]interface{}{
+		"allow": []interface{}{"WebFetch(domain:example.com)", "TodoWrite"},
+		"ask":   []interface{}{"WebSearch"},
+	}
+	rules, err := ParseClaudeCodePermissions(settings)
+	require.NoError(t, err)
+	assert.Equal(t, map[core.PermissionAction][]string{
+		core.PermissionAllow: {"WebFetch(domain:example.com)", "TodoWrite"},
+		core.PermissionAsk:   {"WebSearch"},
+	}, ClaudeCodePermissions(rules))
+
+	balanced, err := ExpandPolicy(core.PermissionPolicyBalanced)
+	require.NoError(t, err)
+	assert.Subset(t, ClaudeCodePermissions(balanced)[core.PermissionAllow], []string{"WebFetch", "WebSearch"})
+}
+
+func TestOpenCodePermission(t *testing.T) {
+	rules := core.PermissionRules{
+		"bash": {"git push*": core.PermissionDeny, "*": core.PermissionAsk},
+		"edit": {"*": core.PermissionAllow},
+	}
+
+	assert.Equal(t, `  edit:
+    "*": allow
+  bash:
+    "*": ask
+    "git push*": deny`, OpenCodePermission(rules))
+
+	parsed := ParseOpenCodePermission(map[string]interface{}{
+		"bash": map[string]interface{}{"*": "ask", "git push*": "deny"},
+		"edit": "allow",
+	})
+	assert.Equal(t, rules, parsed)
+}
@@ -0,0 +1,159 @@
+package platforms
+
+import (
+	"maps"
+
+	claudecode "gitlab.com/amoconst/germinator/internal/claude-code"
+	"gitlab.com/amoconst/germinator/internal/codex"
+	"gitlab.com/amoconst/germinator/internal/copilot"
+	"gitlab.com/amoconst/germinator/internal/core"
+	coreclaudecode "gitlab.com/amoconst/germinator/internal/core/claudecode"
+	corecodex "gitlab.com/amoconst/germinator/internal/core/codex"
+	corecopilot "gitlab.com/amoconst/germinator/internal/core/copilot"
+	corecursor "gitlab.com/amoconst/germinator/internal/core/cursor"
+	coregemini "gitlab.com/amoconst/germinator/internal/core/gemini"
+	"gitlab.com/amoconst/germinator/internal/core/opencode"
+	"gitlab.com/amoconst/germinator/internal/cursor"
+	"gitlab.com/amoconst/germinator/internal/gemini"
+	opencodeadapter "gitlab.com/amoconst/germinator/internal/opencode"
+)
+
+// builtins returns the platforms shipped with germinator, in the order
+// they are listed to users.
+func builtins() []Platform {
+	return []Platform{
+		&definition{
+			id:          core.PlatformClaudeCode,
+			description: "Claude Code document format",
+			adapter:     claudecode.ClaudeCode,
+			templateSet: core.PlatformClaudeCode,
+			outputPaths: extendLayout(dotDirLayout(".claude"), map[string]core.OutputPathConfig{
+				"mcp":      {File: ".mcp.json", Merge: true, MergeJSON: core.JSONMergeEntries},
+				"hook":     {Directory: ".claude", File: "settings.json", Merge: true, MergeJSON: core.JSONMergeHooks},
+				"settings": {Directory: ".claude", File: "settings.json", Merge: true, MergeJSON: core.JSONMergeSettings},
+			}),
+			localPaths: map[string]core.OutputPathConfig{
+				"hook":     {Directory: ".claude", File: "settings.local.json", Merge: true, MergeJSON: core.JSONMergeHooks},
+				"settings": {Directory: ".claude", File: "settings.local.json", Merge: true, MergeJSON: core.JSONMergeSettings},
+			},
+			validators: Validators{
+				Agent:    coreclaudecode.ValidateAgentClaudeCode,
+				Command:  coreclaudecode.ValidateCommandClaudeCode,
+				Skill:    coreclaudecode.ValidateSkillClaudeCode,
+				Hook:     coreclaudecode.ValidateHookClaudeCode,
+				Settings: coreclaudecode.ValidateSettingsClaudeCode,
+				ModelID:  coreclaudecode.IsModelID,
+			},
+		},
+		&definition{
+			id:          core.PlatformOpenCode,
+			description: "OpenCode document format",
+			adapter:     opencodeadapter.OpenCode,
+			templateSet: core.PlatformOpenCode,
+			outputPaths: extendLayout(dotDirLayout(".opencode"), map[string]core.OutputPathConfig{
+				"mcp":      {File: "opencode.json", Merge: true, MergeJSON: core.JSONMergeEntries},
+				"hook":     {Directory: ".opencode", Subdirectory: "plugins", FileSuffix: ".js"},
+				"settings": {File: "opencode.json", Merge: true, MergeJSON: core.JSONMergeSettings},
+			}),
+			validators: Validators{
+				Agent:    opencode.ValidateAgentOpenCode,
+				Command:  opencode.ValidateCommandOpenCode,
+				Skill:    opencode.ValidateSkillOpenCode,
+				Hook:     opencode.ValidateHookOpenCode,
+				Settings: opencode.ValidateSettingsOpenCode,
+				ModelID:  opencode.IsModelID,
+			},
+		},
+		&definition{
+			id:          core.PlatformCursor,
+			description: "Cursor project rules (.mdc)",
+			adapter:     cursor.Cursor,
+			templateSet: core.PlatformCursor,
+			outputPaths: cursorRulesLayout(),
+			validators: Validators{
+				Agent:  corecursor.ValidateAgentCursor,
+				Memory: corecursor.ValidateMemoryCursor,
+			},
+		},
+		&definition{
+			id:          core.PlatformCopilot,
+			description: "GitHub Copilot instructions, prompts, and chat modes",
+			adapter:     copilot.Copilot,
+			templateSet: core.PlatformCopilot,
+			outputPaths: map[string]core.OutputPathConfig{
+				"agent":   {Directory: ".github", Subdirectory: "chatmodes", FileSuffix: ".chatmode.md"},
+				"command": {Directory: ".github", Subdirectory: "prompts", FileSuffix: ".prompt.md"},
+				"memory":  {Directory: ".github", Subdirectory: "instructions", FileSuffix: ".instructions.md"},
+			},
+			unscopedPaths: map[string]core.OutputPathConfig{
+				"memory": {Directory: ".github", File: "copilot-instructions.md", Merge: true},
+			},
+			validators: Validators{
+				Skill:   corecopilot.ValidateSkillCopilot,
+				Memory:  corecopilot.ValidateMemoryCopilot,
+				ModelID: corecopilot.IsModelID,
+			},
+		},
+		&definition{
+			id:          core.PlatformGemini,
+			description: "Gemini CLI commands (TOML) and GEMINI.md",
+			adapter:     gemini.Gemini,
+			templateSet: core.PlatformGemini,
+			outputPaths: map[string]core.OutputPathConfig{
+				"command": {Directory: ".gemini", Subdirectory: "commands", FileSuffix: ".toml"},
+				"memory":  {File: "GEMINI.md", Merge: true},
+			},
+			validators: Validators{
+				Agent:  coregemini.ValidateAgentGemini,
+				Skill:  coregemini.ValidateSkillGemini,
+				Memory: coregemini.ValidateMemoryGemini,
+			},
+		},
+		&definition{
+			id:          core.PlatformCodex,
+			description: "AGENTS.md instructions (OpenAI Codex CLI and compatible agents)",
+			adapter:     codex.Codex,
+			templateSet: core.PlatformCodex,
+			outputPaths: map[string]core.OutputPathConfig{
+				"memory": {File: "AGENTS.md", NestByPaths: true, Merge: true},
+			},
+			validators: Validators{
+				Agent:   corecodex.ValidateAgentCodex,
+				Command: corecodex.ValidateCommandCodex,
+				Skill:   corecodex.ValidateSkillCodex,
+			},
+		},
+	}
+}
+
+// dotDirLayout is the layout shared by platforms that keep every
+// resource type under a single dot-directory.
+func dotDirLayout(dir string) map[string]core.OutputPathConfig {
+	return map[string]core.OutputPathConfig{
+		"skill":   {Directory: dir, Subdirectory: "skills", FileSuffix: "/SKILL.md", UseSubdirectory: true},
+		"agent":   {Directory: dir, Subdirectory: "agents", FileSuffix: ".md"},
+		"command": {Directory: dir, Subdirectory: "commands", FileSuffix: ".md"},
+		"memory":  {Directory: dir, Subdirectory: "memory", FileSuffix: ".md"},
+	}
+}
+
+// extendLayout adds the platform-specific entries of extra to layout,
+// e.g. the shared JSON config files MCP servers, hooks, and settings
+// merge into.
+func extendLayout(layout, extra map[string]core.OutputPathConfig) map[string]core.OutputPathConfig {
+	maps.Copy(layout, extra)
+	return layout
+}
+
+// cursorRulesLayout writes every supported resource type as a rule file
+// under .cursor/rules, which Cursor reads recursively. Skills and
+// commands get their own subdirectory so a skill, a command, and a
+// memory of the same name do not overwrite each other and a rule's
+// type can be inferred from its path. Cursor has no agent equivalent.
+func cursorRulesLayout() map[string]core.OutputPathConfig {
+	return map[string]core.OutputPathConfig{
+		"skill":   {Directory: ".cursor", Subdirectory: "rules/skills", FileSuffix: ".mdc"},
+		"command": {Directory: ".cursor", Subdirectory: "rules/commands", FileSuffix: ".mdc"},
+		"memory":  {Directory: ".cursor", Subdirectory: "rules", FileSuffix: ".mdc"},
+	}
+}
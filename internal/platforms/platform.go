@@ -0,0 +1,129 @@
+// Package platforms is the single registry of target platforms.
+//
+// Every platform-dependent decision — which adapter converts documents,
+// which template set renders them, where installed resources are
+// written, which extra validators apply, and how tool names are cased —
+// is answered by a Platform registered here. Packages that used to
+// switch on "claude-code" / "opencode" (parser, renderer, validate,
+// library, config, cmd) consult Lookup or IDs instead, so adding a
+// target means adding an adapter package and one registration in
+// builtin.go.
+package platforms
+
+import (
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+// Adapter is the bidirectional conversion contract every platform
+// adapter (internal/claude-code, internal/opencode, ...) satisfies via
+// structural typing.
+type Adapter interface {
+	ToCanonical(input map[string]interface{}) (*core.Agent, *core.Command, *core.Skill, *core.Memory, error)
+	FromCanonical(docType string, doc interface{}) (map[string]interface{}, error)
+	PermissionPolicyToPlatform(policy core.PermissionPolicy) (interface{}, error)
+	ConvertToolNameCase(name string) string
+	// UnsupportedFields lists the canonical fields the platform drops
+	// when rendering a document of docType.
+	UnsupportedFields(docType string) []core.UnsupportedField
+}
+
+// Validators holds the platform-specific validators that run on top of
+// the shared core validators. A nil entry means the platform adds no
+// rules for that document type.
+type Validators struct {
+	Agent    core.ValidationFunc[*core.Agent]
+	Command  core.ValidationFunc[*core.Command]
+	Skill    core.ValidationFunc[*core.Skill]
+	Memory   core.ValidationFunc[*core.Memory]
+	MCP      core.ValidationFunc[*core.MCPServer]
+	Hook     core.ValidationFunc[*core.Hook]
+	Settings core.ValidationFunc[*core.Settings]
+	// ModelID reports whether a model name is one of the platform's own
+	// model ids, for core.ModelAliases.Check. Nil for platforms whose
+	// documents carry no model.
+	ModelID func(model string) bool
+}
+
+// Platform describes one target platform.
+type Platform interface {
+	// ID is the identifier accepted by --platform (e.g. "claude-code").
+	ID() string
+	// Description is a one-line human-readable summary.
+	Description() string
+	// Adapter returns the platform's document adapter.
+	Adapter() Adapter
+	// TemplateSet names the directory under config/templates holding
+	// the platform's <docType>.tmpl files.
+	TemplateSet() string
+	// Supports reports whether the platform has a representation for the
+	// document type at all.
+	Supports(docType string) bool
+	// OutputPath returns the install layout for a resource type, or
+	// false when the platform cannot install that type.
+	OutputPath(docType string) (core.OutputPathConfig, bool)
+	// UnscopedOutputPath returns the layout for a document with no path
+	// scope (memory without paths) when the platform keeps such
+	// documents somewhere other than OutputPath, e.g. Copilot's
+	// repository-wide .github/copilot-instructions.md.
+	UnscopedOutputPath(docType string) (core.OutputPathConfig, bool)
+	// LocalOutputPath returns the layout used for docType when it is
+	// installed with core.ScopeLocal, e.g. Claude Code's personal
+	// .claude/settings.local.json. Types without one install to
+	// OutputPath in either scope.
+	LocalOutputPath(docType string) (core.OutputPathConfig, bool)
+	// Validators returns the platform-specific validators.
+	Validators() Validators
+	// ConvertToolNameCase converts a canonical tool name to the
+	// platform's casing convention.
+	ConvertToolNameCase(name string) string
+}
+
+// definition is the declarative Platform implementation used for the
+// built-in registrations.
+type definition struct {
+	id          string
+	description string
+	adapter     Adapter
+	templateSet string
+	outputPaths map[string]core.OutputPathConfig
+	// unscopedPaths overrides outputPaths for documents without a path
+	// scope; nil for platforms that do not distinguish them.
+	unscopedPaths map[string]core.OutputPathConfig
+	// localPaths overrides outputPaths for the local install scope; nil
+	// for platforms without personal settings files.
+	localPaths map[string]core.OutputPathConfig
+	validators Validators
+}
+
+var _ Platform = (*definition)(nil)
+
+func (d *definition) ID() string          { return d.id }
+func (d *definition) Description() string { return d.description }
+func (d *definition) Adapter() Adapter    { return d.adapter }
+func (d *definition) TemplateSet() string { return d.templateSet }
+
+func (d *definition) Supports(docType string) bool {
+	_, ok := d.outputPaths[docType]
+	return ok
+}
+
+func (d *definition) OutputPath(docType string) (core.OutputPathConfig, bool) {
+	layout, ok := d.outputPaths[docType]
+	return layout, ok
+}
+
+func (d *definition) UnscopedOutputPath(docType string) (core.OutputPathConfig, bool) {
+	layout, ok := d.unscopedPaths[docType]
+	return layout, ok
+}
+
+func (d *definition) LocalOutputPath(docType string) (core.OutputPathConfig, bool) {
+	layout, ok := d.localPaths[docType]
+	return layout, ok
+}
+
+func (d *definition) Validators() Validators { return d.validators }
+
+func (d *definition) ConvertToolNameCase(name string) string {
+	return d.adapter.ConvertToolNameCase(name)
+}
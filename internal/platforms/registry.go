@@ -0,0 +1,120 @@
+package platforms
+
+import (
+	"fmt"
+	"sync"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+// Registry is an ordered set of platforms keyed by ID. Registration
+// order is preserved so listings, help text, and suggestions are
+// deterministic.
+type Registry struct {
+	mu        sync.RWMutex
+	order     []string
+	platforms map[string]Platform
+}
+
+// NewRegistry returns a registry holding the given platforms in order.
+// It panics on a duplicate or empty ID (a programmer error).
+func NewRegistry(ps ...Platform) *Registry {
+	r := &Registry{platforms: make(map[string]Platform, len(ps))}
+	for _, p := range ps {
+		r.MustRegister(p)
+	}
+	return r
+}
+
+// Register adds p to the registry. Returns a *core.ConfigError when the
+// ID is empty or already registered.
+func (r *Registry) Register(p Platform) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := p.ID()
+	if id == "" {
+		return core.NewConfigError("platform", id, "platform ID must not be empty")
+	}
+	if _, exists := r.platforms[id]; exists {
+		return core.NewConfigError("platform", id, "platform already registered")
+	}
+	r.platforms[id] = p
+	r.order = append(r.order, id)
+	return nil
+}
+
+// MustRegister is Register that panics on error. Used for the built-in
+// registrations whose IDs are known to be unique.
+func (r *Registry) MustRegister(p Platform) {
+	if err := r.Register(p); err != nil {
+		panic(fmt.Sprintf("platforms: %v", err))
+	}
+}
+
+// Lookup returns the platform registered under id.
+func (r *Registry) Lookup(id string) (Platform, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.platforms[id]
+	return p, ok
+}
+
+// IDs returns the registered platform IDs in registration order.
+func (r *Registry) IDs() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, len(r.order))
+	copy(ids, r.order)
+	return ids
+}
+
+// All returns the registered platforms in registration order.
+func (r *Registry) All() []Platform {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	all := make([]Platform, 0, len(r.order))
+	for _, id := range r.order {
+		all = append(all, r.platforms[id])
+	}
+	return all
+}
+
+// Get returns the platform registered under id or a *core.ValidationError
+// listing the registered IDs.
+func (r *Registry) Get(id string) (Platform, error) {
+	if err := core.ValidatePlatform(id, r.IDs()); err != nil {
+		return nil, err //nolint:wrapcheck // typed *core.ValidationError propagates as-is
+	}
+	p, _ := r.Lookup(id)
+	return p, nil
+}
+
+// defaultRegistry holds the built-in platforms. It is the registry the
+// package-level helpers consult.
+var defaultRegistry = NewRegistry(builtins()...)
+
+// Default returns the process-wide registry.
+func Default() *Registry { return defaultRegistry }
+
+// Register adds p to the default registry.
+func Register(p Platform) error { return defaultRegistry.Register(p) }
+
+// Lookup returns the platform registered under id in the default registry.
+func Lookup(id string) (Platform, bool) { return defaultRegistry.Lookup(id) }
+
+// Get returns the platform registered under id in the default registry,
+// or a *core.ValidationError naming the known platforms.
+func Get(id string) (Platform, error) { return defaultRegistry.Get(id) }
+
+// IDs returns the IDs of the default registry in registration order.
+func IDs() []string { return defaultRegistry.IDs() }
+
+// All returns the platforms of the default registry in registration order.
+func All() []Platform { return defaultRegistry.All() }
+
+// Validate returns nil when id names a registered platform, otherwise
+// the *core.ValidationError produced by core.ValidatePlatform.
+func Validate(id string) error {
+	return core.ValidatePlatform(id, IDs()) //nolint:wrapcheck // typed *core.ValidationError propagates as-is
+}
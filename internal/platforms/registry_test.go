@@ -0,0 +1,176 @@
+package platforms
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+)
+
+func TestDefaultRegistry_Builtins(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{core.PlatformClaudeCode, core.PlatformOpenCode, core.PlatformCursor, core.PlatformCopilot, core.PlatformGemini, core.PlatformCodex}, IDs())
+
+	for _, id := range []string{core.PlatformClaudeCode, core.PlatformOpenCode} {
+		p, ok := Lookup(id)
+		require.True(t, ok, id)
+		assert.NotNil(t, p.Adapter(), id)
+		assert.NotEmpty(t, p.TemplateSet(), id)
+		for _, docType := range core.ResourceTypes() {
+			_, ok := p.OutputPath(docType)
+			assert.True(t, ok, "%s must install %s", id, docType)
+		}
+	}
+}
+
+func TestCursorPlatform(t *testing.T) {
+	t.Parallel()
+
+	p, err := Get(core.PlatformCursor)
+	require.NoError(t, err)
+
+	assert.False(t, p.Supports("agent"), "cursor has no agent equivalent")
+	_, ok := p.OutputPath("agent")
+	assert.False(t, ok)
+
+	for docType, want := range map[string]string{
+		"skill":   ".cursor/rules/skills/x.mdc",
+		"command": ".cursor/rules/commands/x.mdc",
+		"memory":  ".cursor/rules/x.mdc",
+	} {
+		assert.True(t, p.Supports(docType), docType)
+		layout, ok := p.OutputPath(docType)
+		require.True(t, ok, docType)
+		assert.Equal(t, want, core.ResolveOutputPath(layout, "x"), "each type has its own path")
+	}
+	assert.NotNil(t, p.Validators().Agent, "agents must be rejected at validation time")
+}
+
+func TestCopilotPlatform(t *testing.T) {
+	t.Parallel()
+
+	p, err := Get(core.PlatformCopilot)
+	require.NoError(t, err)
+
+	assert.False(t, p.Supports("skill"), "copilot has no skill equivalent")
+
+	want := map[string]string{
+		"agent":   ".github/chatmodes/x.chatmode.md",
+		"command": ".github/prompts/x.prompt.md",
+		"memory":  ".github/instructions/x.instructions.md",
+	}
+	for docType, path := range want {
+		layout, ok := p.OutputPath(docType)
+		require.True(t, ok, docType)
+		assert.Equal(t, path, core.ResolveOutputPath(layout, "x"))
+	}
+
+	unscoped, ok := p.UnscopedOutputPath("memory")
+	require.True(t, ok)
+	assert.Equal(t, ".github/copilot-instructions.md", core.ResolveOutputPath(unscoped, "x"))
+
+	_, ok = p.UnscopedOutputPath("command")
+	assert.False(t, ok)
+}
+
+func TestGeminiPlatform(t *testing.T) {
+	t.Parallel()
+
+	p, err := Get(core.PlatformGemini)
+	require.NoError(t, err)
+
+	assert.False(t, p.Supports("agent"), "gemini has no agent files")
+	assert.False(t, p.Supports("skill"), "gemini has no skill files")
+
+	command, ok := p.OutputPath("command")
+	require.True(t, ok)
+	assert.Equal(t, ".gemini/commands/x.toml", core.ResolveOutputPath(command, "x"))
+
+	memory, ok := p.OutputPath("memory")
+	require.True(t, ok)
+	assert.Equal(t, "GEMINI.md", core.ResolveOutputPath(memory, "x"))
+}
+
+func TestUnsupportedFields(t *testing.T) {
+	t.Parallel()
+
+	fields := func(platform, docType string) []string {
+		p, err := Get(platform)
+		require.NoError(t, err)
+		var out []string
+		for _, f := range p.Adapter().UnsupportedFields(docType) {
+			assert.NotEmpty(t, f.Reason, "%s %s %s", platform, docType, f.Field)
+			out = append(out, f.Field)
+		}
+		return out
+	}
+
+	for _, docType := range []string{"agent", "command", "skill", "memory"} {
+		assert.Empty(t, fields(core.PlatformClaudeCode, docType), "claude-code represents every %s field", docType)
+	}
+	for _, docType := range core.ResourceTypes() {
+		if docType != "memory" {
+			assert.Empty(t, fields(core.PlatformCodex, docType))
+		}
+	}
+	assert.Equal(t, []string{"paths"}, fields(core.PlatformCodex, "memory"))
+	assert.Equal(t, []string{"enabled"}, fields(core.PlatformClaudeCode, "mcp"))
+	assert.Equal(t, []string{"extensions.hooks"}, fields(core.PlatformOpenCode, "agent"))
+	assert.Equal(t, []string{"tools", "arguments.hint"}, fields(core.PlatformOpenCode, "command"))
+	assert.Equal(t, []string{"tools", "execution", "arguments.hint", "model"}, fields(core.PlatformCursor, "command"))
+	assert.Equal(t, []string{"disallowedTools", "permissionPolicy", "permissions", "behavior", "extensions.hooks"}, fields(core.PlatformCopilot, "agent"))
+	assert.Equal(t, []string{"tools", "execution", "arguments.hint", "model"}, fields(core.PlatformGemini, "command"))
+	assert.Empty(t, fields(core.PlatformGemini, "memory"))
+}
+
+func TestRegistry_RegisterRejectsDuplicatesAndEmptyIDs(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry(&definition{id: "a"})
+
+	var cfgErr *core.ConfigError
+	require.ErrorAs(t, r.Register(&definition{id: "a"}), &cfgErr)
+	require.ErrorAs(t, r.Register(&definition{id: ""}), &cfgErr)
+	require.NoError(t, r.Register(&definition{id: "b"}))
+
+	assert.Equal(t, []string{"a", "b"}, r.IDs())
+	assert.Len(t, r.All(), 2)
+}
+
+func TestRegistry_GetUnknown(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry(&definition{id: "a"})
+
+	_, err := r.Get("nope")
+	var valErr *core.ValidationError
+	require.True(t, errors.As(err, &valErr))
+	assert.Contains(t, valErr.Suggestions(), `use "a"`)
+
+	p, err := r.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, "a", p.ID())
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Validate(core.PlatformOpenCode))
+	require.Error(t, Validate("unknown"))
+}
+
+func TestResolveOutputPathFromRegistry(t *testing.T) {
+	t.Parallel()
+
+	p, err := Get(core.PlatformClaudeCode)
+	require.NoError(t, err)
+
+	skill, _ := p.OutputPath("skill")
+	assert.Equal(t, ".claude/skills/commit/SKILL.md", core.ResolveOutputPath(skill, "commit"))
+	agent, _ := p.OutputPath("agent")
+	assert.Equal(t, ".claude/agents/reviewer.md", core.ResolveOutputPath(agent, "reviewer"))
+}
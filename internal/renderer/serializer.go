@@ -3,19 +3,23 @@ package renderer
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
+	"maps"
+	"reflect"
+	"slices"
 	"strings"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
+	yaml "gopkg.in/yaml.v3"
 
-	claudecode "gitlab.com/amoconst/germinator/internal/claude-code"
 	gerrors "gitlab.com/amoconst/germinator/internal/core"
-	opencode "gitlab.com/amoconst/germinator/internal/opencode"
+	"gitlab.com/amoconst/germinator/internal/core/gemini"
+	"gitlab.com/amoconst/germinator/internal/core/opencode"
 	"gitlab.com/amoconst/germinator/internal/parser"
 	"gitlab.com/amoconst/germinator/internal/permission"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 )
 
 type templateContext struct {
@@ -29,9 +33,16 @@ type canonicalTemplateContext struct {
 
 // RenderDocument renders a document using platform-specific template.
 // The ctx parameter is checked at entry so a cancelled caller terminates
-// before template lookup and execution. The inner os.ReadFile reads bundled
-// templates whose path is independent of ctx, so the entry check is sufficient.
+// before template lookup and execution. Templates are read from memory (or
+// a small override file, see readTemplate), so the entry check is sufficient.
 func RenderDocument(ctx context.Context, doc any, platform string) (string, error) {
+	return RenderDocumentWith(ctx, doc, platform, TemplateOptions{})
+}
+
+// RenderDocumentWith renders a document like RenderDocument, reading the
+// template from the overrides in opts before the bundled set and
+// resolving its model through opts.Models.
+func RenderDocumentWith(ctx context.Context, doc any, platform string, opts TemplateOptions) (string, error) {
 	if err := ctx.Err(); err != nil {
 		return "", fmt.Errorf("renderer: render cancelled: %w", err)
 	}
@@ -41,27 +52,28 @@ func RenderDocument(ctx context.Context, doc any, platform string) (string, erro
 		return "", gerrors.NewTransformError("render", platform, "failed to determine document type", err)
 	}
 
-	tmplPath, err := getTemplatePath(platform, docType+".tmpl")
-	if err != nil {
-		return "", gerrors.NewTransformError("render", platform, "failed to get template path", err)
+	target, ok := platforms.Lookup(platform)
+	if !ok {
+		return "", gerrors.NewTransformError("render", platform, "unsupported platform", nil).WithSuggestions(platforms.IDs())
+	}
+
+	if !target.Supports(docType) {
+		return "", gerrors.NewTransformError("render", platform, fmt.Sprintf("platform does not support %s documents", docType), nil)
 	}
 
-	tmplContent, err := os.ReadFile(tmplPath) //nolint:gosec // G304: Reads internal bundled templates only
+	tmplContent, err := readTemplate(opts, target.TemplateSet(), docType+".tmpl")
 	if err != nil {
-		return "", gerrors.NewFileError(tmplPath, "read", "failed to read template file", err)
+		return "", gerrors.NewTransformError("render", platform, "failed to load template", err)
 	}
 
-	var adapter any
-	switch platform {
-	case "claude-code":
-		adapter = claudecode.ClaudeCode
-	case "opencode":
-		adapter = opencode.OpenCode
+	doc, err = PlatformDocument(doc, platform, opts.Models)
+	if err != nil {
+		return "", err
 	}
 
 	tmplCtx := templateContext{
 		Doc:     doc,
-		Adapter: adapter,
+		Adapter: target.Adapter(),
 	}
 
 	tmpl, err := template.New(docType).Funcs(createTemplateFuncMap()).Parse(string(tmplContent))
@@ -74,6 +86,10 @@ func RenderDocument(ctx context.Context, doc any, platform string) (string, erro
 		return "", gerrors.NewTransformError("render", platform, "failed to execute template", err)
 	}
 
+	if err := checkFrontmatter(sb.String(), platform, target.Adapter()); err != nil {
+		return "", err
+	}
+
 	return sb.String(), nil
 }
 
@@ -85,6 +101,15 @@ func RenderDocument(ctx context.Context, doc any, platform string) (string, erro
 //   - permissionPolicyToClaudeCode: converts canonical permission policy to Claude Code enum
 //   - permissionPolicyToOpenCode: converts canonical permission policy to OpenCode permission map as YAML string
 //   - convertToolNameCase: converts tool name to platform-specific case
+//   - platformTools: converts a tool list to platform names, dropping duplicates
+//   - tomlString: quotes a string as a TOML basic (or multi-line basic) string
+//   - yamlValue: renders a value as a YAML scalar, quoting it when needed
+//   - targetFields: renders the platform keys kept in targets that the adapter has no field for
+//   - prettyJSON: encodes a value as indented JSON without HTML escaping
+//   - pascalCase: converts kebab-case to PascalCase
+//   - claudeCodeHookEvent, openCodeHookEvent: check or map a hook event for the platform
+//   - openCodePermission, claudeCodePermissions: render permission rule blocks
+//   - openCodePermissionRules, claudeCodePermissionLists: build permission rules for JSON settings
 //
 // Returns:
 //   - map[string]any: Template function map containing Sprig and custom functions
@@ -95,8 +120,11 @@ func createTemplateFuncMap() map[string]any {
 		if policy == "" {
 			return ""
 		}
-		adapter := claudecode.ClaudeCode
-		result, err := adapter.PermissionPolicyToPlatform(policy)
+		target, ok := platforms.Lookup(gerrors.PlatformClaudeCode)
+		if !ok {
+			return ""
+		}
+		result, err := target.Adapter().PermissionPolicyToPlatform(policy)
 		if err != nil {
 			return ""
 		}
@@ -110,8 +138,11 @@ func createTemplateFuncMap() map[string]any {
 		if policy == "" {
 			return ""
 		}
-		adapter := opencode.OpenCode
-		result, err := adapter.PermissionPolicyToPlatform(policy)
+		target, ok := platforms.Lookup(gerrors.PlatformOpenCode)
+		if !ok {
+			return ""
+		}
+		result, err := target.Adapter().PermissionPolicyToPlatform(policy)
 		if err != nil {
 			return ""
 		}
@@ -153,72 +184,256 @@ func createTemplateFuncMap() map[string]any {
 		return ""
 	}
 
+	funcMap["openCodePermission"] = openCodePermission
+	funcMap["claudeCodePermissions"] = claudeCodePermissions
+	funcMap["openCodePermissionRules"] = openCodePermissionRules
+	funcMap["claudeCodePermissionLists"] = claudeCodePermissionLists
+
 	funcMap["convertToolNameCase"] = func(name string, platform string) string {
-		switch platform {
-		case "claude-code":
-			adapter := claudecode.ClaudeCode
-			return adapter.ConvertToolNameCase(name)
-		case "opencode":
-			adapter := opencode.OpenCode
-			return adapter.ConvertToolNameCase(name)
-		default:
+		target, ok := platforms.Lookup(platform)
+		if !ok {
 			return name
 		}
+		return target.ConvertToolNameCase(name)
 	}
 
+	funcMap["platformTools"] = func(tools []string, platform string) []string {
+		target, ok := platforms.Lookup(platform)
+		var out []string
+		seen := make(map[string]bool, len(tools))
+		for _, t := range tools {
+			name := t
+			if ok {
+				name = target.ConvertToolNameCase(t)
+			}
+			if !seen[name] {
+				seen[name] = true
+				out = append(out, name)
+			}
+		}
+		return out
+	}
+
+	funcMap["tomlString"] = tomlString
+	funcMap["yamlValue"] = yamlValue
+	funcMap["targetFields"] = targetFields
+	funcMap["prettyJSON"] = prettyJSON
+	funcMap["pascalCase"] = permission.ToPascalCase
+	funcMap["claudeCodeHookEvent"] = claudeCodeHookEvent
+	funcMap["openCodeHookEvent"] = openCodeHookEvent
+
 	return funcMap
 }
 
-// findProjectRoot walks up the directory tree from cwd looking for go.mod.
-// Returns the project root directory and an error if not found.
-func findProjectRoot(cwd string) (string, error) {
-	dir := cwd
-	for {
-		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
-			return dir, nil
+// openCodePermission renders the body of an OpenCode `permission:` block
+// from a preset and explicit rules; explicit rules override the preset
+// for the same tool and specifier.
+func openCodePermission(policy gerrors.PermissionPolicy, rules gerrors.PermissionRules) (string, error) {
+	effective, err := permission.EffectiveRules(policy, rules)
+	if err != nil {
+		return "", err //nolint:wrapcheck // typed *core.ConfigError surfaces through template execution
+	}
+	return permission.OpenCodePermission(effective), nil
+}
+
+// claudeCodePermissions renders the body of a Claude Code `permissions:`
+// block: the allow, ask, and deny lists of rule strings. Presets are not
+// expanded here because Claude Code expresses them as permissionMode.
+func claudeCodePermissions(rules gerrors.PermissionRules) string {
+	lists := permission.ClaudeCodePermissions(rules)
+	var lines []string
+	for _, action := range []gerrors.PermissionAction{gerrors.PermissionAllow, gerrors.PermissionAsk, gerrors.PermissionDeny} {
+		if len(lists[action]) == 0 {
+			continue
 		}
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			break
+		lines = append(lines, "  "+string(action)+":")
+		for _, rule := range lists[action] {
+			lines = append(lines, fmt.Sprintf("    - %q", rule))
 		}
-		dir = parent
 	}
-	return "", gerrors.NewFileError(cwd, "read", "project root not found (no go.mod)", nil)
+	return strings.Join(lines, "\n")
 }
 
-func getTemplatePath(platform string, filename string) (string, error) {
-	cwd, err := os.Getwd()
+// openCodePermissionRules returns the effective rules of a preset and
+// explicit rules as an opencode.json permission object: a tool with only
+// a "*" rule maps to its action, any other tool to its specifier map.
+func openCodePermissionRules(policy gerrors.PermissionPolicy, rules gerrors.PermissionRules) (map[string]any, error) {
+	effective, err := permission.EffectiveRules(policy, rules)
 	if err != nil {
-		return "", fmt.Errorf("getting current working directory: %w", err)
+		return nil, err //nolint:wrapcheck // typed *core.ConfigError surfaces through template execution
 	}
+	out := make(map[string]any, len(effective))
+	for tool, specs := range effective {
+		if action, ok := specs["*"]; ok && len(specs) == 1 {
+			out[tool] = string(action)
+			continue
+		}
+		out[tool] = specs
+	}
+	return out, nil
+}
 
-	relPath := filepath.Join("config", "templates", platform, filename)
+// claudeCodePermissionLists returns rules as the allow, ask, and deny
+// lists of a settings.json permissions object. Empty lists are omitted,
+// so rules without entries yield an empty map.
+func claudeCodePermissionLists(rules gerrors.PermissionRules) map[string]any {
+	out := make(map[string]any)
+	for action, list := range permission.ClaudeCodePermissions(rules) {
+		out[string(action)] = list
+	}
+	return out
+}
 
-	// First try CWD (works when running from project root)
-	tmplPath := filepath.Join(cwd, relPath)
-	if _, err := os.Stat(tmplPath); err == nil {
-		absPath, err := filepath.Abs(tmplPath)
+// claudeCodeHookEvent returns event unchanged when it is a canonical
+// hook event (canonical events are Claude Code's), failing otherwise so
+// an unknown event is never written to settings.json.
+func claudeCodeHookEvent(event string) (string, error) {
+	if result := gerrors.ValidateHookEvent(&gerrors.Hook{Event: event}); result.IsError() {
+		return "", gerrors.NewTransformError("render", gerrors.PlatformClaudeCode,
+			"unknown hook event "+event, result.Error)
+	}
+	return event, nil
+}
+
+// openCodeHookEvent returns the OpenCode plugin event for a canonical
+// hook event, failing for events OpenCode cannot observe.
+func openCodeHookEvent(event string) (string, error) {
+	e, ok := opencode.HookEvent(event)
+	if !ok {
+		return "", gerrors.NewTransformError("render", gerrors.PlatformOpenCode,
+			"hook event "+event+" has no OpenCode plugin equivalent", nil)
+	}
+	return e, nil
+}
+
+// prettyJSON renders v as JSON indented with two spaces. Unlike Sprig's
+// toPrettyJson it does not escape <, >, and & so shell commands and URLs
+// stay readable in the emitted config file.
+func prettyJSON(v any) (string, error) {
+	var sb strings.Builder
+	enc := json.NewEncoder(&sb)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return "", fmt.Errorf("encoding json: %w", err)
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+// yamlValue renders v as a YAML frontmatter value that reads back as v.
+// Single-line strings stay plain unless YAML would misread them (`: `,
+// a leading `#` or `*`, "true", numbers), in which case they are quoted;
+// strings with line breaks and non-string values are written as JSON,
+// which is valid YAML at any indentation.
+func yamlValue(v any) (string, error) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.String && !strings.ContainsAny(rv.String(), "\r\n") {
+		out, err := yaml.Marshal(rv.String())
 		if err != nil {
-			return "", fmt.Errorf("resolving absolute path: %w", err)
+			return "", fmt.Errorf("encoding yaml: %w", err)
 		}
-		return absPath, nil
+		return strings.TrimSuffix(string(out), "\n"), nil
 	}
+	var sb strings.Builder
+	enc := json.NewEncoder(&sb)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", fmt.Errorf("encoding yaml: %w", err)
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
 
-	// Try finding project root (works when running from package directory in tests)
-	projectRoot, err := findProjectRoot(cwd)
-	if err != nil {
-		return "", gerrors.NewFileError(relPath, "read", "template file not found", nil)
+// fieldLister is implemented by adapters that list the frontmatter keys
+// they read for a document type (parser.ParsePlatformContent keeps the
+// others under the platform's targets).
+type fieldLister interface {
+	KnownFields(docType string) []string
+}
+
+// targetFields renders the keys of targets[platform] the platform's
+// adapter does not read for docType, one `key: value` line each in key
+// order: configuration the canonical model has no field for, such as a
+// Claude Code agent's color, kept by canonicalize or written by hand.
+// Keys the adapter reads are rendered by the template itself and are
+// skipped, so none is written twice. It returns "" when there are none
+// or the adapter does not list its keys.
+func targetFields(targets gerrors.PlatformConfig, platform, docType string) (string, error) {
+	target, ok := platforms.Lookup(platform)
+	if !ok {
+		return "", nil
+	}
+	l, ok := target.Adapter().(fieldLister)
+	if !ok {
+		return "", nil
 	}
-	tmplPath = filepath.Join(projectRoot, relPath)
-	if _, err := os.Stat(tmplPath); err == nil {
-		absPath, err := filepath.Abs(tmplPath)
+	known := l.KnownFields(docType)
+	config := targets[platform]
+	lines := make([]string, 0, len(config))
+	for _, key := range slices.Sorted(maps.Keys(config)) {
+		if slices.Contains(known, key) {
+			continue
+		}
+		k, err := yamlValue(key)
+		if err != nil {
+			return "", err
+		}
+		v, err := yamlValue(config[key])
 		if err != nil {
-			return "", fmt.Errorf("resolving absolute path: %w", err)
+			return "", err
 		}
-		return absPath, nil
+		lines = append(lines, k+": "+v)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// checkFrontmatter re-parses the YAML frontmatter of a rendered document
+// the way the platform's parser reads it, so a template that emits an
+// unquoted value YAML cannot read fails the render instead of producing
+// a broken file. Output without frontmatter (JSON, TOML, plain Markdown)
+// is not checked.
+func checkFrontmatter(output, platform string, adapter platforms.Adapter) error {
+	if err := parser.CheckFrontmatter(output, adapter); err != nil {
+		return gerrors.NewTransformError("render", platform, "rendered frontmatter is not valid YAML", err).
+			WithSuggestions([]string{"quote template values with yamlValue, e.g. description: {{yamlValue .Doc.Description}}"})
+	}
+	return nil
+}
+
+// tomlString renders s as a TOML string value. Single-line values use a
+// basic string; values containing newlines use a multi-line basic string
+// so prompts stay readable in the emitted file.
+func tomlString(s string) string {
+	if !strings.Contains(s, "\n") {
+		return `"` + escapeTOML(s, false) + `"`
 	}
+	// A newline directly after the opening delimiter is trimmed by TOML
+	// parsers, so the value starts on its own line.
+	return "\"\"\"\n" + escapeTOML(s, true) + "\"\"\""
+}
 
-	return "", gerrors.NewFileError(relPath, "read", "template file not found", nil)
+// escapeTOML escapes s for a TOML basic string. In multi-line mode
+// newlines are kept literal; every quote is escaped in both modes, so a
+// run of quotes can never close a multi-line string early.
+func escapeTOML(s string, multiline bool) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r == '"':
+			sb.WriteString(`\"`)
+		case r == '\n' && multiline, r == '\t':
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&sb, `\u%04X`, r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
 }
 
 func getDocType(doc any) (string, error) {
@@ -234,15 +449,189 @@ func getDocType(doc any) (string, error) {
 		return "memory", nil
 	case *parser.CanonicalSkill:
 		return "skill", nil
+	case *parser.CanonicalMCPServer:
+		return "mcp", nil
+	case *parser.CanonicalHook:
+		return "hook", nil
+	case *parser.CanonicalSettings:
+		return "settings", nil
 	default:
 		return "", gerrors.NewTransformError("marshal", "canonical", fmt.Sprintf("unknown document type: %T", d), nil)
 	}
 }
 
+// PlatformDocument returns doc as rendering for platform sees it: with
+// the conditional blocks of its body resolved (core.SelectPlatformBlocks)
+// and its model resolved through models (core.ModelAliases.Resolve). doc
+// itself is not modified; settings have only their model resolved, and
+// documents without a rendered body or model are returned as is. Malformed blocks are reported as a *core.TransformError.
+func PlatformDocument(doc any, platform string, models gerrors.ModelAliases) (any, error) {
+	var body *string
+	switch d := doc.(type) {
+	case *parser.CanonicalAgent:
+		c := *d
+		c.Model = models.Resolve(c.Model, platform)
+		if platform != gerrors.PlatformClaudeCode {
+			c.Tools = withoutToolSpecifiers(c.Tools)
+			c.DisallowedTools = withoutToolSpecifiers(c.DisallowedTools)
+		}
+		doc, body = &c, &c.Content
+	case *parser.CanonicalCommand:
+		c := *d
+		c.Model = models.Resolve(c.Model, platform)
+		if platform != gerrors.PlatformClaudeCode {
+			c.Tools = withoutToolSpecifiers(c.Tools)
+		}
+		doc, body = &c, &c.Content
+	case *parser.CanonicalSkill:
+		c := *d
+		c.Model = models.Resolve(c.Model, platform)
+		if platform != gerrors.PlatformClaudeCode {
+			c.Tools = withoutToolSpecifiers(c.Tools)
+		}
+		doc, body = &c, &c.Content
+	case *parser.CanonicalMemory:
+		c := *d
+		if platform == gerrors.PlatformGemini {
+			c.Paths = slices.DeleteFunc(slices.Clone(c.Paths), gemini.IsGlob)
+		}
+		doc, body = &c, &c.Content
+	case *parser.CanonicalSettings:
+		c := *d
+		c.Model = models.Resolve(c.Model, platform)
+		return &c, nil
+	default:
+		return doc, nil
+	}
+	selected, err := gerrors.SelectPlatformBlocks(*body, platform, platforms.IDs())
+	if err != nil {
+		return nil, gerrors.NewTransformError("render", platform, "invalid conditional block", err)
+	}
+	*body = selected
+	if m, ok := doc.(*parser.CanonicalMemory); ok {
+		// Memory carries its body twice; both copies are the one body.
+		m.Memory.Content = selected
+	}
+	return doc, nil
+}
+
+// DroppedFields reports the fields of doc that rendering it for platform
+// loses: every set canonical field the platform adapter declares
+// unsupported for the document type, targets settings for other
+// platforms, and the entries PlatformDocument leaves out (Claude Code
+// tool specifiers on any other platform, memory path globs on Gemini
+// CLI). An empty result means the rendering is lossless.
+func DroppedFields(doc any, platform string) ([]gerrors.FieldLoss, error) {
+	docType, err := getDocType(doc)
+	if err != nil {
+		return nil, gerrors.NewTransformError("render", platform, "failed to determine document type", err)
+	}
+
+	target, ok := platforms.Lookup(platform)
+	if !ok {
+		return nil, gerrors.NewTransformError("render", platform, "unsupported platform", nil).WithSuggestions(platforms.IDs())
+	}
+
+	losses := gerrors.DetectLosses(canonicalModel(doc), platform, target.Adapter().UnsupportedFields(docType))
+	if m, ok := doc.(*parser.CanonicalMemory); ok && platform == gerrors.PlatformGemini {
+		for _, p := range m.Paths {
+			if gemini.IsGlob(p) {
+				losses = append(losses, gerrors.FieldLoss{
+					Field:  "paths",
+					Value:  p,
+					Reason: "gemini imports context files with @path; a glob is left out",
+				})
+			}
+		}
+	}
+	if platform == gerrors.PlatformClaudeCode {
+		return losses, nil
+	}
+	for _, list := range toolLists(doc) {
+		if slices.ContainsFunc(losses, func(l gerrors.FieldLoss) bool { return l.Field == list.field }) {
+			continue
+		}
+		for _, tool := range list.tools {
+			if isToolSpecifier(tool) {
+				losses = append(losses, gerrors.FieldLoss{
+					Field:  list.field,
+					Value:  tool,
+					Reason: platform + " has no tool specifiers; the entry is left out",
+				})
+			}
+		}
+	}
+	return losses, nil
+}
+
+// isToolSpecifier reports whether tool carries a Claude Code "(spec)"
+// suffix, such as bash(git diff:*). Only Claude Code takes specifiers;
+// every other platform renders tools by name, so PlatformDocument leaves
+// specifier entries out and DroppedFields reports them.
+func isToolSpecifier(tool string) bool {
+	return strings.IndexByte(tool, '(') > 0
+}
+
+// withoutToolSpecifiers returns tools without its specifier entries.
+func withoutToolSpecifiers(tools []string) []string {
+	if !slices.ContainsFunc(tools, isToolSpecifier) {
+		return tools
+	}
+	var kept []string
+	for _, tool := range tools {
+		if !isToolSpecifier(tool) {
+			kept = append(kept, tool)
+		}
+	}
+	return kept
+}
+
+type toolList struct {
+	field string
+	tools []string
+}
+
+// toolLists returns the canonical tool lists of doc by field name.
+func toolLists(doc any) []toolList {
+	switch d := doc.(type) {
+	case *parser.CanonicalAgent:
+		return []toolList{{"tools", d.Tools}, {"disallowedTools", d.DisallowedTools}}
+	case *parser.CanonicalCommand:
+		return []toolList{{"tools", d.Tools}}
+	case *parser.CanonicalSkill:
+		return []toolList{{"tools", d.Tools}}
+	default:
+		return nil
+	}
+}
+
+// canonicalModel unwraps a parser.Canonical* document to the core model
+// whose fields describe it.
+func canonicalModel(doc any) any {
+	switch d := doc.(type) {
+	case *parser.CanonicalAgent:
+		return &d.Agent
+	case *parser.CanonicalCommand:
+		return &d.Command
+	case *parser.CanonicalSkill:
+		return &d.Skill
+	case *parser.CanonicalMemory:
+		return &d.Memory
+	case *parser.CanonicalMCPServer:
+		return &d.MCPServer
+	case *parser.CanonicalHook:
+		return &d.Hook
+	case *parser.CanonicalSettings:
+		return &d.Settings
+	default:
+		return nil
+	}
+}
+
 // MarshalCanonical serializes a canonical model to YAML string using canonical templates.
 // The ctx parameter is checked at entry so a cancelled caller terminates
-// before template lookup and execution. The inner os.ReadFile reads bundled
-// templates whose path is independent of ctx, so the entry check is sufficient.
+// before template lookup and execution. Templates are read from memory (or
+// a small override file, see readTemplate), so the entry check is sufficient.
 func MarshalCanonical(ctx context.Context, doc any) (string, error) {
 	if err := ctx.Err(); err != nil {
 		return "", fmt.Errorf("renderer: marshal cancelled: %w", err)
@@ -253,14 +642,9 @@ func MarshalCanonical(ctx context.Context, doc any) (string, error) {
 		return "", gerrors.NewTransformError("marshal", "canonical", "failed to determine document type", err)
 	}
 
-	tmplPath, err := getCanonicalTemplatePath(docType + ".tmpl")
-	if err != nil {
-		return "", gerrors.NewTransformError("marshal", "canonical", "failed to get template path", err)
-	}
-
-	tmplContent, err := os.ReadFile(tmplPath) //nolint:gosec // G304: Reads internal bundled templates only
+	tmplContent, err := readTemplate(TemplateOptions{}, "canonical", docType+".tmpl")
 	if err != nil {
-		return "", gerrors.NewFileError(tmplPath, "read", "failed to read template file", err)
+		return "", gerrors.NewTransformError("marshal", "canonical", "failed to load template", err)
 	}
 
 	tmplCtx := canonicalTemplateContext{
@@ -277,6 +661,10 @@ func MarshalCanonical(ctx context.Context, doc any) (string, error) {
 		return "", gerrors.NewTransformError("marshal", "canonical", "failed to execute template", err)
 	}
 
+	if err := parser.CheckFrontmatter(sb.String(), nil); err != nil {
+		return "", gerrors.NewTransformError("marshal", "canonical", "rendered frontmatter is not valid YAML", err)
+	}
+
 	return sb.String(), nil
 }
 
@@ -284,57 +672,54 @@ func MarshalCanonical(ctx context.Context, doc any) (string, error) {
 // *Serializer; the (*Serializer).RenderDocument method delegates to the
 // package-level RenderDocument function so callers can choose between
 // functional and method-style usage.
-type Serializer struct{}
+type Serializer struct {
+	templateDirs []string
+	models       gerrors.ModelAliases
+}
+
+// NewSerializer creates a new Serializer instance. templateDirs are
+// template override directories (the user's `templates` setting) searched
+// by every render after any overrides passed per call.
+func NewSerializer(templateDirs ...string) *Serializer {
+	return &Serializer{templateDirs: templateDirs}
+}
 
-// NewSerializer creates a new Serializer instance.
-func NewSerializer() *Serializer {
-	return &Serializer{}
+// WithModels sets the model aliases every render resolves (the built-in
+// aliases merged with the user's config.toml), under any passed per
+// call. It returns s for chaining.
+func (s *Serializer) WithModels(models gerrors.ModelAliases) *Serializer {
+	s.models = models
+	return s
 }
 
 // RenderDocument renders a document to the target platform format.
 // Forwards ctx to the package-level RenderDocument so caller cancellation
 // propagates through template lookup and execution.
 func (s *Serializer) RenderDocument(ctx context.Context, doc any, platform string) (string, error) {
-	return RenderDocument(ctx, doc, platform)
+	return s.RenderDocumentWith(ctx, doc, platform, TemplateOptions{})
 }
 
-// getCanonicalTemplatePath returns the absolute path to a canonical template file.
-func getCanonicalTemplatePath(filename string) (string, error) {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return "", fmt.Errorf("getting current working directory: %w", err)
-	}
-
-	relPath := filepath.Join("config", "templates", "canonical", filename)
-
-	// First try CWD (works when running from project root)
-	tmplPath := filepath.Join(cwd, relPath)
-	if _, err := os.Stat(tmplPath); err == nil {
-		absPath, err := filepath.Abs(tmplPath)
-		if err != nil {
-			return "", fmt.Errorf("resolving absolute path: %w", err)
-		}
-		return absPath, nil
-	}
-
-	// Try finding project root (works when running from package directory in tests)
-	projectRoot, err := findProjectRoot(cwd)
-	if err != nil {
-		return "", gerrors.NewFileError(relPath, "read", "canonical template file not found", nil)
-	}
-	tmplPath = filepath.Join(projectRoot, relPath)
-	if _, err := os.Stat(tmplPath); err == nil {
-		absPath, err := filepath.Abs(tmplPath)
-		if err != nil {
-			return "", fmt.Errorf("resolving absolute path: %w", err)
-		}
-		return absPath, nil
-	}
+// RenderDocumentWith renders a document with the per-call overrides in
+// opts, which take precedence over the Serializer's own directories and
+// model aliases.
+func (s *Serializer) RenderDocumentWith(ctx context.Context, doc any, platform string, opts TemplateOptions) (string, error) {
+	opts.Dirs = append(slices.Clone(opts.Dirs), s.templateDirs...)
+	opts.Models = s.models.Merge(opts.Models)
+	return RenderDocumentWith(ctx, doc, platform, opts)
+}
 
-	return "", gerrors.NewFileError(relPath, "read", "canonical template file not found", nil)
+// DroppedFields reports the fields rendering doc for platform loses.
+// Delegates to the package-level DroppedFields.
+func (s *Serializer) DroppedFields(doc any, platform string) ([]gerrors.FieldLoss, error) {
+	return DroppedFields(doc, platform)
 }
 
 // createCanonicalTemplateFuncMap creates and returns a FuncMap with minimal Sprig functions for canonical templates.
+// permissionRules renders canonical permission rules, which share OpenCode's nested object shape.
 func createCanonicalTemplateFuncMap() map[string]any {
-	return sprig.FuncMap()
+	funcMap := sprig.FuncMap()
+	funcMap["permissionRules"] = permission.OpenCodePermission
+	funcMap["yamlValue"] = yamlValue
+	funcMap["targetFields"] = targetFields
+	return funcMap
 }
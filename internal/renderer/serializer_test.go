@@ -12,7 +12,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/gemini"
 	"gitlab.com/amoconst/germinator/internal/parser"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 )
 
 func float64Ptr(f float64) *float64 {
@@ -146,6 +148,267 @@ func TestRenderDocumentMemory(t *testing.T) {
 	}
 }
 
+func TestRenderDocumentCursor(t *testing.T) {
+	t.Run("memory with paths becomes auto-attached rule", func(t *testing.T) {
+		memory := &parser.CanonicalMemory{
+			Memory:  core.Memory{Paths: []string{"src/**/*.go", "README.md"}},
+			Content: "Memory content",
+		}
+
+		result, err := RenderDocument(context.Background(), memory, core.PlatformCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "---\ndescription:\nglobs: src/**/*.go,README.md\nalwaysApply: false\n---\nMemory content\n", result)
+	})
+
+	t.Run("memory without paths is always applied", func(t *testing.T) {
+		memory := &parser.CanonicalMemory{Content: "Always"}
+
+		result, err := RenderDocument(context.Background(), memory, core.PlatformCursor)
+		require.NoError(t, err)
+		assert.Contains(t, result, "alwaysApply: true")
+	})
+
+	t.Run("skill becomes agent-requested rule", func(t *testing.T) {
+		skill := &parser.CanonicalSkill{
+			Skill:   core.Skill{Name: "git-release", Description: "Create releases"},
+			Content: "Body",
+		}
+
+		result, err := RenderDocument(context.Background(), skill, core.PlatformCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "---\ndescription: Create releases\nglobs:\nalwaysApply: false\n---\nBody\n", result)
+	})
+
+	t.Run("agent is unsupported", func(t *testing.T) {
+		agent := &parser.CanonicalAgent{Agent: core.Agent{Name: "a", Description: "a"}}
+
+		_, err := RenderDocument(context.Background(), agent, core.PlatformCursor)
+		var transformErr *core.TransformError
+		require.ErrorAs(t, err, &transformErr)
+		assert.Contains(t, err.Error(), "does not support agent documents")
+	})
+}
+
+func TestRenderDocumentCopilot(t *testing.T) {
+	t.Run("memory with paths is scoped by applyTo", func(t *testing.T) {
+		memory := &parser.CanonicalMemory{
+			Memory:  core.Memory{Paths: []string{"**/*.go", "go.mod"}},
+			Content: "Memory content",
+		}
+
+		result, err := RenderDocument(context.Background(), memory, core.PlatformCopilot)
+		require.NoError(t, err)
+		assert.Equal(t, "---\napplyTo: \"**/*.go,go.mod\"\n---\nMemory content\n", result)
+	})
+
+	t.Run("memory without paths has no frontmatter", func(t *testing.T) {
+		memory := &parser.CanonicalMemory{Content: "Always"}
+
+		result, err := RenderDocument(context.Background(), memory, core.PlatformCopilot)
+		require.NoError(t, err)
+		assert.Equal(t, "Always\n", result)
+	})
+
+	t.Run("command becomes prompt file", func(t *testing.T) {
+		command := &parser.CanonicalCommand{
+			Command: core.Command{
+				Name:        "review",
+				Description: "Review the diff",
+				Tools:       []string{"bash", "edit", "write"},
+				Execution:   core.CommandExecution{Agent: "agent"},
+			},
+			Content: "Body",
+		}
+
+		result, err := RenderDocument(context.Background(), command, core.PlatformCopilot)
+		require.NoError(t, err)
+		assert.Contains(t, result, "mode: agent")
+		assert.Contains(t, result, "tools: ['runCommands', 'editFiles']")
+		assert.NotContains(t, result, "name:")
+	})
+
+	t.Run("skill is unsupported", func(t *testing.T) {
+		skill := &parser.CanonicalSkill{Skill: core.Skill{Name: "s", Description: "s"}}
+
+		_, err := RenderDocument(context.Background(), skill, core.PlatformCopilot)
+		var transformErr *core.TransformError
+		require.ErrorAs(t, err, &transformErr)
+		assert.Contains(t, err.Error(), "does not support skill documents")
+	})
+}
+
+func TestRenderDocumentGemini(t *testing.T) {
+	t.Run("command renders as TOML", func(t *testing.T) {
+		command := &parser.CanonicalCommand{
+			Command: core.Command{Name: "review", Description: `Review "staged" changes`},
+			Content: "Review the diff.\nFocus on $ARGUMENTS.",
+		}
+
+		result, err := RenderDocument(context.Background(), command, core.PlatformGemini)
+		require.NoError(t, err)
+		assert.Equal(t, "description = \"Review \\\"staged\\\" changes\"\nprompt = \"\"\"\nReview the diff.\nFocus on {{args}}.\"\"\"\n", result)
+
+		fields, body, _, err := gemini.Gemini.DecodeDocument("command", []byte(result))
+		require.NoError(t, err, "rendered command must be valid TOML")
+		assert.Equal(t, command.Description, fields["description"])
+		assert.Equal(t, command.Content, body)
+	})
+
+	t.Run("memory paths become imports", func(t *testing.T) {
+		memory := &parser.CanonicalMemory{
+			Memory:  core.Memory{Paths: []string{"docs/style.md", "README.md"}},
+			Content: "Be concise.",
+		}
+
+		result, err := RenderDocument(context.Background(), memory, core.PlatformGemini)
+		require.NoError(t, err)
+		assert.Equal(t, "@docs/style.md\n@README.md\n\nBe concise.\n", result)
+	})
+
+	t.Run("agent is unsupported", func(t *testing.T) {
+		agent := &parser.CanonicalAgent{Agent: core.Agent{Name: "a", Description: "a"}}
+
+		_, err := RenderDocument(context.Background(), agent, core.PlatformGemini)
+		var transformErr *core.TransformError
+		require.ErrorAs(t, err, &transformErr)
+	})
+}
+
+func TestTomlString(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "hello", `"hello"`},
+		{"quotes and backslashes", `say "hi" \ bye`, `"say \"hi\" \\ bye"`},
+		{"control characters", "a\tb\x01", "\"a\tb\\u0001\""},
+		{"multi-line", "line 1\nline \"\"\"2\"\"\"", "\"\"\"\nline 1\nline \\\"\\\"\\\"2\\\"\\\"\\\"\"\"\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tomlString(tt.input))
+		})
+	}
+}
+
+func TestYamlValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{"plain", "hello world", "hello world"},
+		{"colon space", "Reviews code: carefully", `'Reviews code: carefully'`},
+		{"comment marker", "# not a comment", `'# not a comment'`},
+		{"glob", "*.go", `'*.go'`},
+		{"flow sequence", "[issue-number]", `'[issue-number]'`},
+		{"boolean-looking", "true", `"true"`},
+		{"multi-line", "line 1\nline 2", `"line 1\nline 2"`},
+		{"typed string", core.PermissionPolicy("balanced"), "balanced"},
+		{"bool", true, "true"},
+		{"list", []string{"a: b", "c"}, `["a: b","c"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := yamlValue(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRenderDocumentTargetFields(t *testing.T) {
+	agent := &parser.CanonicalAgent{
+		Agent: core.Agent{
+			Name:        "reviewer",
+			Description: "Reviews code",
+			Targets: core.PlatformConfig{
+				"claude-code": {"color": "blue", "skills": []string{"lint"}, "model": "opus"},
+				"opencode":    {"top_p": 0.9, "options": map[string]interface{}{"reasoningEffort": "high"}},
+			},
+		},
+		Content: "Review.",
+	}
+
+	tests := []struct {
+		platform string
+		want     []string
+		notWant  []string
+	}{
+		{
+			platform: "claude-code",
+			want:     []string{"color: blue\n", "skills:\n  - lint\n"},
+			notWant:  []string{"model:", "top_p", "skills: ["},
+		},
+		{
+			platform: "opencode",
+			want:     []string{`options: {"reasoningEffort":"high"}` + "\n", "top_p: 0.9\n"},
+			notWant:  []string{"color"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			result, err := RenderDocument(context.Background(), agent, tt.platform)
+			require.NoError(t, err)
+			for _, w := range tt.want {
+				assert.Contains(t, result, w)
+			}
+			for _, w := range tt.notWant {
+				assert.NotContains(t, result, w)
+			}
+
+			doc, err := parser.ParsePlatformContent("reviewer.md", []byte(result), tt.platform, "agent")
+			require.NoError(t, err)
+			for key, value := range agent.Targets[tt.platform] {
+				if key == "model" || key == "skills" {
+					continue
+				}
+				assert.Equal(t, value, doc.(*parser.CanonicalAgent).Targets[tt.platform][key], "%s reads back", key)
+			}
+		})
+	}
+}
+
+func TestRenderDocumentQuotesFrontmatterValues(t *testing.T) {
+	agent := &parser.CanonicalAgent{
+		Agent: core.Agent{
+			Name:        "reviewer",
+			Description: "Reviews code: carefully # thoroughly",
+			Tools:       []string{"*"},
+			Behavior:    core.AgentBehavior{Mode: "subagent", Prompt: "First line.\nSecond: line."},
+		},
+		Content: "Body",
+	}
+
+	for _, p := range platforms.All() {
+		if !p.Supports("agent") {
+			continue
+		}
+		t.Run(p.ID(), func(t *testing.T) {
+			output, err := RenderDocument(context.Background(), agent, p.ID())
+			require.NoError(t, err)
+			require.NoError(t, parser.CheckFrontmatter(output, p.Adapter()))
+		})
+	}
+}
+
+func TestRenderDocumentRejectsInvalidFrontmatter(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "agent.tmpl")
+	require.NoError(t, os.WriteFile(file, []byte("---\ndescription: {{.Doc.Description}}\n---\n"), 0o600))
+
+	agent := &parser.CanonicalAgent{Agent: core.Agent{Name: "reviewer", Description: "Reviews code: carefully"}}
+	_, err := RenderDocumentWith(context.Background(), agent, core.PlatformClaudeCode, TemplateOptions{File: file})
+
+	var transformErr *core.TransformError
+	require.ErrorAs(t, err, &transformErr)
+	assert.Equal(t, "render", transformErr.Operation())
+	assert.NotEmpty(t, transformErr.Suggestions())
+}
+
 func TestRenderDocumentUnknownType(t *testing.T) {
 	type UnknownType struct{}
 
@@ -409,6 +672,148 @@ func TestRenderCanonicalAgentOpenCode(t *testing.T) {
 	}
 }
 
+func TestRenderPermissionRulesRoundTrip(t *testing.T) {
+	agent := &parser.CanonicalAgent{
+		Agent: core.Agent{
+			Name:             "reviewer",
+			Description:      "Reviews code",
+			PermissionPolicy: core.PermissionPolicyBalanced,
+			Permissions: core.PermissionRules{
+				"bash": {"git push*": core.PermissionDeny},
+				"edit": {"docs/**": core.PermissionAllow},
+			},
+		},
+		Content: "Review.",
+	}
+
+	tests := []struct {
+		platform string
+		path     string
+		contains []string
+	}{
+		{
+			platform: core.PlatformClaudeCode,
+			path:     ".claude/agents/reviewer.md",
+			contains: []string{
+				"permissionMode: acceptEdits",
+				"permissions:\n  allow:\n    - \"Edit(docs/**)\"\n  deny:\n    - \"Bash(git push:*)\"",
+			},
+		},
+		{
+			platform: core.PlatformOpenCode,
+			path:     ".opencode/agents/reviewer.md",
+			contains: []string{
+				"  edit:\n    \"*\": allow\n    \"docs/**\": allow",
+				"  bash:\n    \"*\": ask\n    \"git push*\": deny",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			output, err := RenderDocument(t.Context(), agent, tt.platform)
+			require.NoError(t, err)
+			for _, want := range tt.contains {
+				assert.Contains(t, output, want)
+			}
+
+			path := filepath.Join(t.TempDir(), filepath.FromSlash(tt.path))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+			require.NoError(t, os.WriteFile(path, []byte(output), 0o600))
+
+			parsed, err := parser.ParsePlatformDocument(t.Context(), path, tt.platform, "agent")
+			require.NoError(t, err)
+			back, ok := parsed.(*parser.CanonicalAgent)
+			require.True(t, ok, "expected *parser.CanonicalAgent, got %T", parsed)
+			assert.Equal(t, agent.PermissionPolicy, back.PermissionPolicy, "preset round-trip")
+			assert.Equal(t, agent.Permissions, back.Permissions, "rules round-trip")
+		})
+	}
+
+	t.Run("canonical", func(t *testing.T) {
+		output, err := MarshalCanonical(t.Context(), agent)
+		require.NoError(t, err)
+		assert.Contains(t, output, "permissionPolicy: balanced\npermissions:\n  edit:\n    \"docs/**\": allow\n  bash:\n    \"git push*\": deny\n")
+	})
+}
+
+func TestRenderHook(t *testing.T) {
+	hook := &parser.CanonicalHook{
+		Hook: core.Hook{Name: "lint-go", Event: "PostToolUse", Matcher: "Edit|Write", Command: "golangci-lint run && echo ok", Timeout: 60},
+	}
+
+	t.Run("claude-code", func(t *testing.T) {
+		output, err := RenderDocument(t.Context(), hook, core.PlatformClaudeCode)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"hooks": {"PostToolUse": [{"matcher": "Edit|Write", "hooks": [
+			{"type": "command", "command": "golangci-lint run && echo ok", "timeout": 60}]}]}}`, output)
+		assert.Contains(t, output, "&& echo ok", "commands are not HTML-escaped")
+	})
+
+	t.Run("opencode", func(t *testing.T) {
+		output, err := RenderDocument(t.Context(), hook, core.PlatformOpenCode)
+		require.NoError(t, err)
+		assert.Contains(t, output, "export const HookLintGo = async ({ $ }) => {")
+		assert.Contains(t, output, `const matcher = new RegExp("^(?:Edit|Write)$", "i")`)
+		assert.Contains(t, output, `"tool.execute.after": async (input) => {`)
+	})
+
+	t.Run("unknown events fail to render", func(t *testing.T) {
+		bad := &parser.CanonicalHook{Hook: core.Hook{Name: "x", Event: "OnSave", Command: "x"}}
+		for _, platform := range []string{core.PlatformClaudeCode, core.PlatformOpenCode} {
+			_, err := RenderDocument(t.Context(), bad, platform)
+			require.Error(t, err, platform)
+		}
+
+		notify := &parser.CanonicalHook{Hook: core.Hook{Name: "x", Event: "Notification", Command: "x"}}
+		_, err := RenderDocument(t.Context(), notify, core.PlatformClaudeCode)
+		require.NoError(t, err)
+		_, err = RenderDocument(t.Context(), notify, core.PlatformOpenCode)
+		require.Error(t, err, "opencode has no Notification event")
+	})
+}
+
+func TestRenderSettings(t *testing.T) {
+	settings := &parser.CanonicalSettings{
+		Settings: core.Settings{
+			Name:             "team",
+			Model:            "anthropic/claude-sonnet-4-5",
+			PermissionPolicy: core.PermissionPolicyRestrictive,
+			Permissions:      core.PermissionRules{"bash": {"npm test && npm run lint": core.PermissionAllow}},
+			Env:              map[string]string{"CI": "1"},
+		},
+	}
+
+	t.Run("claude-code", func(t *testing.T) {
+		output, err := RenderDocument(t.Context(), settings, core.PlatformClaudeCode)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"model": "anthropic/claude-sonnet-4-5",
+			"permissions": {"allow": ["Bash(npm test && npm run lint)"], "defaultMode": "default"},
+			"env": {"CI": "1"}
+		}`, output)
+	})
+
+	t.Run("opencode", func(t *testing.T) {
+		output, err := RenderDocument(t.Context(), settings, core.PlatformOpenCode)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"model": "anthropic/claude-sonnet-4-5",
+			"permission": {
+				"edit": "ask",
+				"bash": {"*": "ask", "npm test && npm run lint": "allow"},
+				"read": "ask", "grep": "ask", "glob": "ask", "list": "ask",
+				"webfetch": "ask", "websearch": "ask"
+			}
+		}`, output)
+
+		dropped, err := DroppedFields(settings, core.PlatformOpenCode)
+		require.NoError(t, err)
+		require.Len(t, dropped, 1)
+		assert.Equal(t, "env", dropped[0].Field)
+	})
+}
+
 func TestRenderCanonicalCommand(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -812,7 +1217,7 @@ func TestMarshalCanonicalCommand(t *testing.T) {
 				if !containsString(output, "arguments:") {
 					t.Error("Expected arguments section")
 				}
-				if !containsString(output, "hint: [issue-number]") {
+				if !containsString(output, "hint: '[issue-number]'") {
 					t.Error("Expected hint field")
 				}
 				if !containsString(output, "model: claude-haiku-4-20250514") {
@@ -962,10 +1367,10 @@ func TestMarshalCanonicalMemory(t *testing.T) {
 				if containsString(output, "paths:") {
 					t.Error("Should not contain paths section when empty")
 				}
-				if !containsString(output, "content: |") {
-					t.Error("Expected content with pipe syntax")
+				if containsString(output, "content:") {
+					t.Error("Content belongs in the body, not the frontmatter")
 				}
-				if !containsString(output, "This is the memory content") {
+				if !containsString(output, "---\nThis is the memory content") {
 					t.Error("Expected content text")
 				}
 			},
@@ -982,8 +1387,8 @@ func TestMarshalCanonicalMemory(t *testing.T) {
 				if !containsString(output, "paths:") {
 					t.Error("Expected paths section")
 				}
-				if !containsString(output, "content: |") {
-					t.Error("Expected content with pipe syntax")
+				if !containsString(output, "---\nAdditional context") {
+					t.Error("Expected content in the body")
 				}
 			},
 		},
@@ -1403,3 +1808,205 @@ func TestCanonicalMarshalRoundTripPreservesSemanticFields(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderDocumentConditionalBlocks(t *testing.T) {
+	t.Parallel()
+
+	body := "Explore first.\n<!-- germinator:if platform=claude-code -->\nUse the Task tool.\n<!-- germinator:else -->\nMention @explore.\n<!-- germinator:endif -->\n"
+	cmd := &parser.CanonicalCommand{
+		Command: core.Command{Name: "explore", Description: "Explore the code"},
+		Content: body,
+	}
+
+	claude, err := RenderDocument(context.Background(), cmd, "claude-code")
+	require.NoError(t, err)
+	assert.Contains(t, claude, "Explore first.\nUse the Task tool.\n")
+	assert.NotContains(t, claude, "@explore")
+	assert.NotContains(t, claude, "germinator:")
+
+	opencode, err := RenderDocument(context.Background(), cmd, "opencode")
+	require.NoError(t, err)
+	assert.Contains(t, opencode, "Explore first.\nMention @explore.\n")
+	assert.NotContains(t, opencode, "Task tool")
+
+	assert.Equal(t, body, cmd.Content, "rendering must not modify the document")
+
+	cmd.Content = "<!-- germinator:if platform=claude-code -->\nunclosed\n"
+	_, err = RenderDocument(context.Background(), cmd, "claude-code")
+	var terr *core.TransformError
+	require.ErrorAs(t, err, &terr)
+}
+
+func TestPlatformDocumentMemory(t *testing.T) {
+	t.Parallel()
+
+	body := "Be concise.\n<!-- germinator:if platform=codex -->\nRun make check.\n<!-- germinator:endif -->\n" +
+		"<!-- germinator:end memory/style -->\n"
+	mem := &parser.CanonicalMemory{Memory: core.Memory{Content: body}, Content: body}
+
+	got, err := PlatformDocument(mem, "opencode", nil)
+	require.NoError(t, err)
+	want := "Be concise.\n<!-- germinator:end memory/style -->\n"
+	assert.Equal(t, want, got.(*parser.CanonicalMemory).Content)
+	assert.Equal(t, want, got.(*parser.CanonicalMemory).Memory.Content, "both copies of the body are resolved")
+	assert.Equal(t, body, mem.Content, "the document is not modified")
+	assert.Equal(t, body, mem.Memory.Content)
+}
+
+func TestRenderDocumentModelAliases(t *testing.T) {
+	t.Parallel()
+
+	agent := &parser.CanonicalAgent{
+		Agent:   core.Agent{Name: "reviewer", Description: "Reviews code", Model: "smart"},
+		Content: "Review.\n",
+	}
+	models := core.ModelAliases{"smart": {"claude-code": "opus", "opencode": "anthropic/claude-opus-4-1"}}
+
+	claude, err := RenderDocumentWith(context.Background(), agent, "claude-code", TemplateOptions{Models: models})
+	require.NoError(t, err)
+	assert.Contains(t, claude, "model: opus\n")
+
+	serializer := NewSerializer().WithModels(core.DefaultModelAliases())
+	opencode, err := serializer.RenderDocumentWith(context.Background(), agent, "opencode", TemplateOptions{Models: models})
+	require.NoError(t, err)
+	assert.Contains(t, opencode, "model: anthropic/claude-opus-4-1\n")
+
+	agent.Model = "haiku"
+	opencode, err = serializer.RenderDocument(context.Background(), agent, "opencode")
+	require.NoError(t, err)
+	assert.Contains(t, opencode, "model: anthropic/claude-haiku-4-5\n")
+
+	verbatim, err := RenderDocument(context.Background(), agent, "opencode")
+	require.NoError(t, err)
+	assert.Contains(t, verbatim, "model: haiku\n", "without aliases the model renders verbatim")
+	assert.Equal(t, "haiku", agent.Model, "rendering must not modify the document")
+}
+
+func TestRenderDocumentSettingsModelAliases(t *testing.T) {
+	t.Parallel()
+
+	settings := &parser.CanonicalSettings{Settings: core.Settings{Model: "sonnet"}}
+	serializer := NewSerializer().WithModels(core.DefaultModelAliases())
+
+	opencode, err := serializer.RenderDocument(context.Background(), settings, "opencode")
+	require.NoError(t, err)
+	assert.Contains(t, opencode, `"model": "anthropic/claude-sonnet-4-5"`)
+
+	claude, err := serializer.RenderDocument(context.Background(), settings, "claude-code")
+	require.NoError(t, err)
+	assert.Contains(t, claude, `"model": "sonnet"`)
+	assert.Equal(t, "sonnet", settings.Model, "rendering must not modify the document")
+}
+
+func TestRenderDocumentClaudeCodePermissionModeTarget(t *testing.T) {
+	t.Parallel()
+
+	agent := &parser.CanonicalAgent{
+		Agent: core.Agent{
+			Name:        "planner",
+			Description: "Plans changes",
+			Targets:     core.PlatformConfig{"claude-code": {"permissionMode": "plan"}},
+		},
+		Content: "Plan.\n",
+	}
+
+	out, err := RenderDocument(context.Background(), agent, "claude-code")
+	require.NoError(t, err)
+	assert.Contains(t, out, "permissionMode: plan\n")
+
+	agent.PermissionPolicy = core.PermissionPolicyBalanced
+	out, err = RenderDocument(context.Background(), agent, "claude-code")
+	require.NoError(t, err)
+	assert.Contains(t, out, "permissionMode: acceptEdits\n", "permissionPolicy takes precedence")
+	assert.NotContains(t, out, "permissionMode: plan")
+}
+
+func TestRenderDocumentToolSpecifiers(t *testing.T) {
+	t.Parallel()
+
+	agent := &parser.CanonicalAgent{
+		Agent: core.Agent{
+			Name:            "reviewer",
+			Description:     "Reviews diffs",
+			Tools:           []string{"read", "bash(git diff:*)"},
+			DisallowedTools: []string{"bash(rm:*)"},
+		},
+		Content: "Review.\n",
+	}
+
+	claude, err := RenderDocument(t.Context(), agent, core.PlatformClaudeCode)
+	require.NoError(t, err)
+	assert.Contains(t, claude, "Bash(git diff:*)")
+	dropped, err := DroppedFields(agent, core.PlatformClaudeCode)
+	require.NoError(t, err)
+	assert.Empty(t, dropped)
+
+	out, err := RenderDocument(t.Context(), agent, core.PlatformOpenCode)
+	require.NoError(t, err)
+	assert.Contains(t, out, "read: true")
+	dropped, err = DroppedFields(agent, core.PlatformOpenCode)
+	require.NoError(t, err)
+	assert.Contains(t, dropped, core.FieldLoss{
+		Field: "disallowedTools", Value: "bash(rm:*)", Reason: "opencode has no tool specifiers; the entry is left out",
+	})
+
+	for _, platform := range []string{core.PlatformOpenCode, core.PlatformCopilot} {
+		t.Run(platform, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := RenderDocument(t.Context(), agent, platform)
+			require.NoError(t, err)
+			assert.NotContains(t, out, "git diff")
+			assert.NotContains(t, out, "rm:*")
+
+			dropped, err := DroppedFields(agent, platform)
+			require.NoError(t, err)
+			assert.Contains(t, dropped, core.FieldLoss{
+				Field: "tools", Value: "bash(git diff:*)", Reason: platform + " has no tool specifiers; the entry is left out",
+			})
+		})
+	}
+	assert.Equal(t, []string{"read", "bash(git diff:*)"}, agent.Tools, "rendering must not modify the document")
+}
+
+func TestRenderDocumentGeminiMemoryGlobs(t *testing.T) {
+	t.Parallel()
+
+	memory := &parser.CanonicalMemory{
+		Memory:  core.Memory{Paths: []string{"docs/style.md", "src/**/*.go"}, Content: "Use gofmt.\n"},
+		Content: "Use gofmt.\n",
+	}
+
+	out, err := RenderDocument(t.Context(), memory, core.PlatformGemini)
+	require.NoError(t, err)
+	assert.Contains(t, out, "@docs/style.md\n")
+	assert.NotContains(t, out, "src/**/*.go")
+
+	dropped, err := DroppedFields(memory, core.PlatformGemini)
+	require.NoError(t, err)
+	assert.Equal(t, []core.FieldLoss{{
+		Field: "paths", Value: "src/**/*.go", Reason: "gemini imports context files with @path; a glob is left out",
+	}}, dropped)
+	assert.Equal(t, []string{"docs/style.md", "src/**/*.go"}, memory.Paths, "rendering must not modify the document")
+}
+
+func TestMarshalCanonicalMCPServer(t *testing.T) {
+	t.Parallel()
+
+	server := core.MCPServer{
+		Name:    "github",
+		Command: "npx",
+		Args:    []string{"-y", "@modelcontextprotocol/server-github"},
+		Env:     map[string]string{"GITHUB_TOKEN": "${GITHUB_TOKEN}", "NOTE": "a: b # not a comment", "yes": "no"},
+		Headers: map[string]string{"Authorization": "Bearer 'x'", "X-Trace: id": "\"quoted\"\nline"},
+	}
+
+	output, err := MarshalCanonical(t.Context(), &parser.CanonicalMCPServer{MCPServer: server})
+	require.NoError(t, err)
+
+	doc, err := parser.ParseDocumentWith(t.Context(), "mcp-github.md", "mcp", parser.Options{Content: []byte(output)})
+	require.NoError(t, err, output)
+	got := doc.(*parser.CanonicalMCPServer).MCPServer
+	got.FilePath = ""
+	assert.Equal(t, server, got)
+}
@@ -0,0 +1,188 @@
+package renderer
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"gitlab.com/amoconst/germinator/config/templates"
+	gerrors "gitlab.com/amoconst/germinator/internal/core"
+)
+
+// TemplatesEnv names the environment variable holding an explicit
+// template override directory. It is laid out like the bundled set
+// (<set>/<type>.tmpl, e.g. claude-code/agent.tmpl); templates it does not
+// hold fall back to the bundled ones, so overriding one template needs
+// one file.
+const TemplatesEnv = "GERMINATOR_TEMPLATES"
+
+// TemplateOptions selects where a render reads its template from, most
+// specific first: File, then each of Dirs in order, then the directory
+// named by TemplatesEnv, then the bundled set. The zero value reads the
+// TemplatesEnv directory and the bundled set. It also carries the model
+// aliases the render resolves.
+type TemplateOptions struct {
+	// File is the template for this one document (a per-resource
+	// override from library.yaml). It replaces the directory lookup.
+	File string
+	// Dirs are override directories laid out like the bundled set
+	// (<set>/<type>.tmpl); a directory that does not exist is an error.
+	Dirs []string
+	// Models are the model aliases the render resolves the document's
+	// model through. Nil renders the model verbatim.
+	Models gerrors.ModelAliases
+}
+
+// dirs returns the override directories in lookup order, with the
+// TemplatesEnv directory appended when it is set and not already listed.
+func (o TemplateOptions) dirs() []string {
+	dirs := slices.Clone(o.Dirs)
+	if dir := os.Getenv(TemplatesEnv); dir != "" && !slices.Contains(dirs, dir) {
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// Template describes one bundled template and the file that shadows it.
+type Template struct {
+	// Name is the template's path in the bundled set, e.g.
+	// claude-code/agent.tmpl.
+	Name string
+	// Override is the override file a render reads instead, or empty
+	// when the bundled template is used.
+	Override string
+}
+
+// readTemplate returns the template <templateSet>/<filename> from the
+// first source in opts that holds it (see TemplateOptions), falling back
+// to the templates compiled into the binary.
+func readTemplate(opts TemplateOptions, templateSet, filename string) ([]byte, error) {
+	if opts.File != "" {
+		content, err := os.ReadFile(opts.File) //nolint:gosec // G304: per-resource template named in library.yaml
+		if err != nil {
+			return nil, gerrors.NewFileError(opts.File, "read", "failed to read template file", err)
+		}
+		return content, nil
+	}
+
+	name := path.Join(templateSet, filename)
+	override, err := findOverride(opts.dirs(), name)
+	if err != nil {
+		return nil, err
+	}
+	if override != "" {
+		content, err := os.ReadFile(override) //nolint:gosec // G304: user-selected template override directory
+		if err != nil {
+			return nil, gerrors.NewFileError(override, "read", "failed to read template file", err)
+		}
+		return content, nil
+	}
+
+	return BundledTemplate(name)
+}
+
+// findOverride returns the first file named name (a slash-separated
+// <set>/<type>.tmpl path) under dirs, or "" when none holds it. An
+// override directory that does not exist is an error rather than
+// silently ignored.
+func findOverride(dirs []string, name string) (string, error) {
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return "", gerrors.NewFileError(dir, "read", "template override directory not found", err).
+				WithSuggestions([]string{"create the directory, or fix the templates setting or " + TemplatesEnv})
+		}
+		candidate := filepath.Join(dir, filepath.FromSlash(name))
+		_, err := os.Stat(candidate)
+		if err == nil {
+			return candidate, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", gerrors.NewFileError(candidate, "read", "failed to read template file", err)
+		}
+	}
+	return "", nil
+}
+
+// BundledTemplate returns the bundled template name (<set>/<type>.tmpl).
+func BundledTemplate(name string) ([]byte, error) {
+	content, err := fs.ReadFile(templates.FS, name)
+	if err != nil {
+		return nil, gerrors.NewFileError(name, "read", "template file not found", err)
+	}
+	return content, nil
+}
+
+// ListTemplates returns every bundled template in name order, each with
+// the file from opts.Dirs (or TemplatesEnv) that overrides it. opts.File
+// is ignored: it applies to a single document, not a template set.
+func ListTemplates(opts TemplateOptions) ([]Template, error) {
+	names, err := fs.Glob(templates.FS, "*/*.tmpl")
+	if err != nil {
+		return nil, gerrors.NewFileError("", "read", "failed to list bundled templates", err)
+	}
+	dirs := opts.dirs()
+	list := make([]Template, 0, len(names))
+	for _, name := range names {
+		override, err := findOverride(dirs, name)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, Template{Name: name, Override: override})
+	}
+	return list, nil
+}
+
+// ExportTemplate writes the bundled template name to dir/<name>, creating
+// its set directory, and returns the path written. An existing file is
+// an error unless force is set.
+func ExportTemplate(dir, name string, force bool) (string, error) {
+	content, err := BundledTemplate(name)
+	if err != nil {
+		return "", err
+	}
+	target := filepath.Join(dir, filepath.FromSlash(name))
+	if !force {
+		if _, err := os.Stat(target); err == nil {
+			return target, gerrors.NewFileError(target, "write", "file exists (use --force to overwrite)", nil)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil { //nolint:gosec // G301: user-owned template directory; 0755 is standard permission
+		return target, gerrors.NewFileError(target, "mkdir", "failed to create template directory", err)
+	}
+	if err := os.WriteFile(target, content, 0o644); err != nil { //nolint:gosec // G306: user-owned template file; 0644 is standard readable permission
+		return target, gerrors.NewFileError(target, "write", "failed to write template file", err)
+	}
+	return target, nil
+}
+
+// DiffTemplate returns a unified diff from the bundled template to its
+// override, or "" when t has no override or the two are identical.
+func DiffTemplate(t Template) (string, error) {
+	if t.Override == "" {
+		return "", nil
+	}
+	bundled, err := BundledTemplate(t.Name)
+	if err != nil {
+		return "", err
+	}
+	override, err := os.ReadFile(t.Override) //nolint:gosec // G304: override found in a user-selected template directory
+	if err != nil {
+		return "", gerrors.NewFileError(t.Override, "read", "failed to read template file", err)
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(bundled)),
+		B:        difflib.SplitLines(string(override)),
+		FromFile: "bundled/" + t.Name,
+		ToFile:   t.Override,
+		Context:  3,
+	})
+	if err != nil {
+		return "", gerrors.NewFileError(t.Override, "read", "failed to diff template", err)
+	}
+	return diff, nil
+}
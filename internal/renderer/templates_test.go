@@ -0,0 +1,94 @@
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/parser"
+)
+
+func TestReadTemplate_Bundled(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	content, err := readTemplate(TemplateOptions{}, "claude-code", "agent.tmpl")
+	require.NoError(t, err, "bundled templates do not depend on the working directory")
+	assert.Contains(t, string(content), ".Doc.Name")
+
+	_, err = readTemplate(TemplateOptions{}, "claude-code", "missing.tmpl")
+	var fileErr *core.FileError
+	require.ErrorAs(t, err, &fileErr)
+}
+
+func TestReadTemplate_Override(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "claude-code"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "claude-code", "command.tmpl"), []byte("custom {{.Doc.Name}}"), 0o600))
+	t.Setenv(TemplatesEnv, dir)
+
+	doc := &parser.CanonicalCommand{Command: core.Command{Name: "review", Description: "Review"}}
+	output, err := RenderDocument(t.Context(), doc, core.PlatformClaudeCode)
+	require.NoError(t, err)
+	assert.Equal(t, "custom review", output)
+
+	output, err = RenderDocument(t.Context(), doc, core.PlatformOpenCode)
+	require.NoError(t, err)
+	assert.Contains(t, output, "description: Review", "templates missing from the override fall back to the bundled set")
+}
+
+func TestReadTemplate_MissingOverrideDirectory(t *testing.T) {
+	t.Setenv(TemplatesEnv, filepath.Join(t.TempDir(), "nope"))
+
+	_, err := readTemplate(TemplateOptions{}, "claude-code", "agent.tmpl")
+	var fileErr *core.FileError
+	require.ErrorAs(t, err, &fileErr)
+}
+
+func TestReadTemplate_Precedence(t *testing.T) {
+	write := func(dir, content string) string {
+		path := filepath.Join(dir, "claude-code", "agent.tmpl")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	first, second, env := t.TempDir(), t.TempDir(), t.TempDir()
+	file := write(first, "first")
+	write(second, "second")
+	write(env, "env")
+	t.Setenv(TemplatesEnv, env)
+
+	tests := []struct {
+		name string
+		opts TemplateOptions
+		want string
+	}{
+		{"file wins", TemplateOptions{File: file, Dirs: []string{second}}, "first"},
+		{"directories in order", TemplateOptions{Dirs: []string{second, first}}, "second"},
+		{"environment after directories", TemplateOptions{}, "env"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := readTemplate(tt.opts, "claude-code", "agent.tmpl")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(content))
+		})
+	}
+}
+
+func TestListTemplates(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "opencode"), 0o750))
+	override := filepath.Join(dir, "opencode", "agent.tmpl")
+	require.NoError(t, os.WriteFile(override, []byte("custom"), 0o600))
+
+	list, err := ListTemplates(TemplateOptions{Dirs: []string{dir}})
+	require.NoError(t, err)
+	assert.Contains(t, list, Template{Name: "claude-code/agent.tmpl"})
+	assert.Contains(t, list, Template{Name: "opencode/agent.tmpl", Override: override})
+	assert.Contains(t, list, Template{Name: "canonical/agent.tmpl"})
+}
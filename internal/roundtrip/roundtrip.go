@@ -0,0 +1,356 @@
+// Package roundtrip checks that canonical documents survive a trip
+// through a platform format as an I/O shell-package service. Each
+// document is rendered for a platform (renderer.RenderDocumentWith),
+// parsed back (parser.ParsePlatformContent) without touching the
+// filesystem, and the two canonical models are compared field by field
+// (core.DetectDrift).
+//
+// A resource whose documents come back unchanged can be maintained in
+// platform format and re-canonicalized without loss; drift in a field
+// the platform claims to support is an adapter bug.
+//
+// The Service interface, Request type, and NewService constructor are
+// the canonical contract. cmd/roundtrip.go declares a local
+// RoundTripper interface that is structurally identical to
+// roundtrip.Service. Check is the same comparison for a document that
+// is already loaded.
+package roundtrip
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/library"
+	"gitlab.com/amoconst/germinator/internal/parser"
+	"gitlab.com/amoconst/germinator/internal/platforms"
+	"gitlab.com/amoconst/germinator/internal/renderer"
+)
+
+// Request carries the inputs for a round-trip check. Paths are
+// canonical document files; when Paths is empty every resource of
+// Library is checked instead, or only Refs when set. Platforms limits
+// the check to those platforms; empty means every platform that
+// supports the document's type.
+type Request struct {
+	Paths     []string
+	Library   *library.Library
+	Refs      []string
+	Platforms []string
+	// TemplateDirs are template override directories consulted after a
+	// library resource's own template and the library's directory, as
+	// for init.
+	TemplateDirs []string
+	// Models are the model aliases documents render with; in library
+	// mode the library's own models take precedence over them.
+	Models core.ModelAliases
+	// Vars are values for the variables documents declare, shared by
+	// every document; each uses the ones it declares.
+	Vars map[string]string
+}
+
+// Service is the per-call contract for round-trip checks. Per-document
+// failures and drift are recorded on the result; the error return is
+// reserved for failures that stop the whole run.
+type Service interface {
+	Check(ctx context.Context, req *Request) (*core.RoundtripResult, error)
+}
+
+// roundtripService is the production implementation. Zero-size because
+// loading, rendering, and parsing are delegated to the parser and
+// renderer packages.
+type roundtripService struct{}
+
+// Compile-time confirmation that *roundtripService satisfies the
+// Service interface declared in this package.
+var _ Service = (*roundtripService)(nil)
+
+// NewService returns the production wiring for round-trip checks.
+func NewService() Service {
+	return &roundtripService{}
+}
+
+// source is one canonical document to check.
+type source struct {
+	ref  string
+	path string
+	typ  string
+	name string
+}
+
+// Check implements Service. Documents are checked in ref order, each
+// against its platforms in registry order.
+func (roundtripService) Check(ctx context.Context, req *Request) (*core.RoundtripResult, error) {
+	if req == nil {
+		return nil, core.NewValidationError("roundtrip", "request", "", "roundtrip request must not be nil")
+	}
+
+	sources, err := collectSources(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &core.RoundtripResult{}
+	for _, src := range sources {
+		for _, platform := range targetPlatforms(src.typ, req.Platforms) {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("roundtrip: cancelled: %w", err)
+			}
+			result.Documents = append(result.Documents, checkSource(ctx, req, src, platform))
+		}
+	}
+	return result, nil
+}
+
+// collectSources lists the documents a request covers. Hooks and
+// settings are skipped in library mode: their platform files do not
+// record which resource they came from, so they cannot be read back.
+func collectSources(ctx context.Context, req *Request) ([]source, error) {
+	if len(req.Paths) > 0 {
+		sources := make([]source, 0, len(req.Paths))
+		for _, p := range req.Paths {
+			detection, err := parser.ResolveType(ctx, p, "")
+			if err != nil {
+				return nil, err //nolint:wrapcheck // typed parser errors propagate as-is
+			}
+			typ := detection.Type
+			name := fileName(p, typ)
+			sources = append(sources, source{ref: library.FormatRef(typ, name), path: p, typ: typ, name: name})
+		}
+		return sources, nil
+	}
+
+	if req.Library == nil {
+		return nil, core.NewValidationError("roundtrip", "library", "", "no documents given and no library loaded")
+	}
+	refs := req.Refs
+	if len(refs) == 0 {
+		for typ, resources := range req.Library.Resources {
+			if typ == string(library.ResourceTypeHook) || typ == string(library.ResourceTypeSettings) {
+				continue
+			}
+			for name := range resources {
+				refs = append(refs, library.FormatRef(typ, name))
+			}
+		}
+		sort.Strings(refs)
+	}
+
+	sources := make([]source, 0, len(refs))
+	for _, ref := range refs {
+		typ, name, err := library.ParseRef(ref)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // typed core error from the library package
+		}
+		path, err := library.ResolveResource(req.Library, ref)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // typed core error from the library package
+		}
+		sources = append(sources, source{ref: ref, path: path, typ: typ, name: name})
+	}
+	return sources, nil
+}
+
+// fileName derives a document name from a canonical file name such as
+// agent-reviewer.md or reviewer-agent.md.
+func fileName(path, typ string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if name, ok := strings.CutPrefix(base, typ+"-"); ok {
+		return name
+	}
+	return strings.TrimSuffix(base, "-"+typ)
+}
+
+// targetPlatforms returns the platforms to check a document of typ
+// against: requested, or every registered platform, that support typ.
+func targetPlatforms(typ string, requested []string) []string {
+	var ids []string
+	for _, p := range platforms.All() {
+		if !p.Supports(typ) {
+			continue
+		}
+		if len(requested) > 0 && !slices.Contains(requested, p.ID()) {
+			continue
+		}
+		ids = append(ids, p.ID())
+	}
+	return ids
+}
+
+// checkSource loads one canonical document and round-trips it through
+// platform. Errors are recorded on the returned document.
+func checkSource(ctx context.Context, req *Request, src source, platform string) core.RoundtripDocument {
+	result := core.RoundtripDocument{Ref: src.ref, InputPath: src.path, Platform: platform}
+
+	doc, err := parser.LoadDocumentWith(ctx, src.path, platform, parser.Options{Type: src.typ, Vars: req.Vars})
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	opts := renderer.TemplateOptions{Dirs: req.TemplateDirs, Models: req.Models}
+	if req.Library != nil && len(req.Paths) == 0 {
+		opts.File = req.Library.ResourceTemplate(src.typ, src.name, platform)
+		opts.Models = opts.Models.Merge(req.Library.Models)
+		if dir := req.Library.TemplatesDir(); dir != "" {
+			opts.Dirs = append([]string{dir}, opts.Dirs...)
+		}
+	}
+
+	name := src.name
+	if len(req.Paths) > 0 {
+		// A lone file is installed under its document name.
+		name = cmp.Or(documentName(doc), name)
+	}
+	result.Dropped, result.Drift, result.Error = check(ctx, doc, src.typ, name, platform, opts)
+	return result
+}
+
+// Check renders doc, a canonical document as returned by
+// parser.LoadDocument, for platform with the bundled (or
+// GERMINATOR_TEMPLATES) templates, parses the output back, and returns
+// the fields the platform drops and the fields that drifted. name is
+// the resource name, used where the platform takes names from file
+// names.
+func Check(ctx context.Context, doc any, name, platform string) ([]core.FieldLoss, []core.FieldDrift, error) {
+	return check(ctx, doc, docType(doc), name, platform, renderer.TemplateOptions{})
+}
+
+func check(ctx context.Context, doc any, typ, name, platform string, opts renderer.TemplateOptions) ([]core.FieldLoss, []core.FieldDrift, error) {
+	dropped, err := renderer.DroppedFields(doc, platform)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck // typed *core.TransformError for ExitCodeFor dispatch
+	}
+
+	rendered, err := renderer.RenderDocumentWith(ctx, doc, platform, opts)
+	if err != nil {
+		return dropped, nil, err //nolint:wrapcheck // typed *core.TransformError for ExitCodeFor dispatch
+	}
+
+	path, err := layoutPath(doc, typ, name, platform)
+	if err != nil {
+		return dropped, nil, err
+	}
+	parsed, err := parser.ParsePlatformContent(path, []byte(rendered), platform, typ)
+	if err != nil {
+		return dropped, nil, core.NewParseError(path, "failed to parse rendered "+platform+" document", err)
+	}
+
+	// The platform only ever sees the conditional blocks meant for it,
+	// and its own id for an aliased model.
+	want, err := renderer.PlatformDocument(doc, platform, opts.Models)
+	if err != nil {
+		return dropped, nil, err //nolint:wrapcheck // typed *core.TransformError for ExitCodeFor dispatch
+	}
+	return dropped, detectDrift(want, parsed, dropped), nil
+}
+
+// layoutPath returns where the rendered document would be installed,
+// relative to the project root, so the parser derives the same name
+// from it as from an installed file.
+func layoutPath(doc any, typ, name, platform string) (string, error) {
+	if m, ok := doc.(*parser.CanonicalMemory); ok {
+		path, err := library.GetMemoryOutputPath(name, platform, "", m.Paths)
+		if err != nil {
+			return "", err //nolint:wrapcheck // typed core error from the library package
+		}
+		return path, nil
+	}
+	path, err := library.GetOutputPath(typ, name, platform, "")
+	if err != nil {
+		return "", err //nolint:wrapcheck // typed core error from the library package
+	}
+	return path, nil
+}
+
+// detectDrift compares the canonical models of want and got, and their
+// Markdown bodies as the "content" field. Surrounding whitespace is not
+// drift: templates and parsers disagree on trailing newlines.
+func detectDrift(want, got any, dropped []core.FieldLoss) []core.FieldDrift {
+	wantModel, wantBody := model(want)
+	gotModel, gotBody := model(got)
+	drift := core.DetectDrift(wantModel, gotModel, dropped)
+	if _, isMemory := want.(*parser.CanonicalMemory); !isMemory && wantBody != gotBody {
+		drift = append(drift, core.FieldDrift{Field: "content", Want: wantBody, Got: gotBody})
+	}
+	return drift
+}
+
+// docType returns the document type of a canonical document, or "" for
+// types that cannot be read back from a platform.
+func docType(doc any) string {
+	switch doc.(type) {
+	case *parser.CanonicalAgent:
+		return "agent"
+	case *parser.CanonicalCommand:
+		return "command"
+	case *parser.CanonicalSkill:
+		return "skill"
+	case *parser.CanonicalMemory:
+		return "memory"
+	case *parser.CanonicalMCPServer:
+		return "mcp"
+	default:
+		return ""
+	}
+}
+
+// documentName returns the name a canonical document declares, or ""
+// for types without one.
+func documentName(doc any) string {
+	switch d := doc.(type) {
+	case *parser.CanonicalAgent:
+		return d.Name
+	case *parser.CanonicalCommand:
+		return d.Name
+	case *parser.CanonicalSkill:
+		return d.Name
+	case *parser.CanonicalMCPServer:
+		return d.Name
+	default:
+		return ""
+	}
+}
+
+// model returns a copy of the core model inside a canonical document
+// and its trimmed body. Tool lists are sorted in the copy: platforms
+// that key tools by name do not keep their order, and the order carries
+// no meaning. Memory keeps its body in the model's content field, which
+// is trimmed in the copy.
+func model(doc any) (any, string) {
+	switch d := doc.(type) {
+	case *parser.CanonicalAgent:
+		m := d.Agent
+		m.Tools, m.DisallowedTools = sorted(m.Tools), sorted(m.DisallowedTools)
+		return &m, strings.TrimSpace(d.Content)
+	case *parser.CanonicalCommand:
+		m := d.Command
+		m.Tools = sorted(m.Tools)
+		return &m, strings.TrimSpace(d.Content)
+	case *parser.CanonicalSkill:
+		m := d.Skill
+		m.Tools = sorted(m.Tools)
+		return &m, strings.TrimSpace(d.Content)
+	case *parser.CanonicalMemory:
+		m := d.Memory
+		m.Content = strings.TrimSpace(m.Content)
+		return &m, m.Content
+	case *parser.CanonicalMCPServer:
+		m := d.MCPServer
+		return &m, strings.TrimSpace(d.Content)
+	default:
+		return doc, ""
+	}
+}
+
+func sorted(list []string) []string {
+	if list == nil {
+		return nil
+	}
+	return slices.Sorted(slices.Values(list))
+}
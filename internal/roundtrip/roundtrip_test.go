@@ -0,0 +1,167 @@
+package roundtrip
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/library"
+	"gitlab.com/amoconst/germinator/internal/parser"
+)
+
+const canonicalAgent = `---
+name: reviewer
+description: "Reviews code: carefully"
+tools:
+  - read
+  - grep
+  - bash
+permissionPolicy: restrictive
+behavior:
+  mode: subagent
+  steps: 12
+  prompt: |-
+    First line.
+    Second line.
+---
+Review the diff.
+`
+
+const canonicalCommand = `---
+name: deploy
+description: Deploys the service
+tools:
+  - bash
+execution:
+  context: fork
+  agent: reviewer
+arguments:
+  hint: "[environment]"
+---
+Deploy to $ARGUMENTS.
+`
+
+func writeFile(t *testing.T, path, content string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestService_Check_Files(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	agent := writeFile(t, filepath.Join(dir, "agent-reviewer.md"), canonicalAgent)
+	command := writeFile(t, filepath.Join(dir, "command-deploy.md"), canonicalCommand)
+
+	result, err := NewService().Check(context.Background(), &Request{
+		Paths:     []string{agent, command},
+		Platforms: []string{core.PlatformClaudeCode, core.PlatformOpenCode},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Documents, 4)
+
+	byKey := make(map[string]core.RoundtripDocument)
+	for _, d := range result.Documents {
+		require.NoError(t, d.Error, "%s → %s", d.Ref, d.Platform)
+		byKey[d.Ref+" "+d.Platform] = d
+	}
+
+	assert.Empty(t, byKey["command/deploy claude-code"].Drift, "flat command fields parse back")
+	assert.Empty(t, byKey["command/deploy opencode"].Drift)
+	assert.Empty(t, byKey["agent/reviewer claude-code"].Drift)
+
+	opencode := byKey["agent/reviewer opencode"]
+	assert.Equal(t, []core.FieldDrift{{Field: "permissionPolicy", Want: "restrictive", Got: "balanced"}}, opencode.Drift,
+		"OpenCode renders restrictive and balanced to the same permissions")
+}
+
+func TestService_Check_Vars(t *testing.T) {
+	t.Parallel()
+
+	path := writeFile(t, filepath.Join(t.TempDir(), "command-test.md"),
+		"---\nname: test\ndescription: Test {{ .Vars.project }}\nvars:\n  project:\n---\nRun the tests.\n")
+
+	result, err := NewService().Check(context.Background(), &Request{
+		Paths:     []string{path},
+		Platforms: []string{core.PlatformOpenCode},
+		Vars:      map[string]string{"project": "germinator"},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Documents, 1)
+	require.NoError(t, result.Documents[0].Error, "the required variable is set")
+	assert.Empty(t, result.Documents[0].Drift)
+
+	result, err = NewService().Check(context.Background(), &Request{
+		Paths:     []string{path},
+		Platforms: []string{core.PlatformOpenCode},
+	})
+	require.NoError(t, err)
+	var verr *core.ValidationError
+	require.ErrorAs(t, result.Documents[0].Error, &verr)
+	assert.Equal(t, "project", verr.Value())
+}
+
+func TestService_Check_Library(t *testing.T) {
+	t.Parallel()
+
+	lib, err := library.LoadLibrary(context.Background(), filepath.Join("..", "..", "test", "fixtures", "library"))
+	require.NoError(t, err)
+
+	result, err := NewService().Check(context.Background(), &Request{Library: lib, Refs: []string{"skill/commit", "agent/reviewer"}})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Documents)
+
+	platformsByRef := make(map[string][]string)
+	for _, d := range result.Documents {
+		require.NoError(t, d.Error, "%s → %s", d.Ref, d.Platform)
+		platformsByRef[d.Ref] = append(platformsByRef[d.Ref], d.Platform)
+	}
+	assert.Contains(t, platformsByRef["skill/commit"], core.PlatformCursor)
+	assert.NotContains(t, platformsByRef["agent/reviewer"], core.PlatformCursor, "cursor has no agents")
+}
+
+func TestService_Check_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewService().Check(context.Background(), &Request{})
+	var valErr *core.ValidationError
+	require.ErrorAs(t, err, &valErr)
+
+	_, err = NewService().Check(context.Background(), &Request{Paths: []string{"notes.md"}})
+	var parseErr *core.ParseError
+	require.ErrorAs(t, err, &parseErr)
+
+	path := filepath.Join(t.TempDir(), "notes.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\ntype: widget\n---\nBody\n"), 0o600))
+	_, err = NewService().Check(context.Background(), &Request{Paths: []string{path}})
+	require.ErrorAs(t, err, &parseErr)
+	assert.Contains(t, parseErr.Error(), "widget is not a document type", "the detection error is reported as-is")
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	doc := &parser.CanonicalAgent{
+		Agent: core.Agent{
+			Name:        "reviewer",
+			Description: "Reviews code",
+			Tools:       []string{"read"},
+			Behavior:    core.AgentBehavior{Steps: 7},
+			Targets:     core.PlatformConfig{core.PlatformClaudeCode: {"skills": []string{"commit"}}},
+		},
+		Content: "Review the diff.\n<!-- germinator:if platform=claude-code -->\nUse the Task tool.\n<!-- germinator:endif -->\n",
+	}
+
+	dropped, drift, err := Check(context.Background(), doc, "reviewer", core.PlatformOpenCode)
+	require.NoError(t, err)
+	assert.Empty(t, drift, "maxSteps survives OpenCode, and blocks for other platforms are not drift")
+	require.NotEmpty(t, dropped)
+	assert.Equal(t, "targets.claude-code.skills", dropped[0].Field)
+}
@@ -23,9 +23,15 @@ package transform
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	"gitlab.com/amoconst/germinator/internal/core"
+	"gitlab.com/amoconst/germinator/internal/install"
+	"gitlab.com/amoconst/germinator/internal/library"
 	"gitlab.com/amoconst/germinator/internal/parser"
 	"gitlab.com/amoconst/germinator/internal/renderer"
 )
@@ -38,7 +44,28 @@ import (
 type Request struct {
 	InputPath  string
 	OutputPath string
-	Platform   string
+	// OutputDir, when set, replaces OutputPath: the document is written
+	// where the platform's layout puts it under OutputDir, named after
+	// the document (or its file), as init does. Documents the platform
+	// keeps in one shared file are merged into it.
+	OutputDir string
+	Platform  string
+	// DocType is the document type; empty means detect it
+	// (parser.Detect).
+	DocType string
+	// Strict fails the transform, without writing, when the target
+	// platform drops any field of the document.
+	Strict bool
+	// Vars are values for the variables the document declares.
+	Vars map[string]string
+	// Content, when set, is the document to transform in place of the
+	// file at InputPath, which then only names it (adapt - reads it
+	// from stdin). DocType must be set as well: there is no file to
+	// detect the type from.
+	Content []byte
+	// Output, when set, receives the rendered document in place of
+	// OutputPath.
+	Output io.Writer
 }
 
 // Service is the per-call contract for document transformation.
@@ -54,9 +81,14 @@ type Service interface {
 // across many Transform calls without rebuilding its orchestrating
 // state. The struct fields are unexported so callers must use
 // NewService to obtain a configured Service.
+//
+// Transform is safe for concurrent use; mergeMu serializes the
+// read-merge-write of shared output files (.mcp.json, AGENTS.md) so
+// documents adapted in parallel do not overwrite each other.
 type transformService struct {
 	parser     *parser.Parser
 	serializer *renderer.Serializer
+	mergeMu    sync.Mutex
 }
 
 // Compile-time confirmation that *transformService satisfies the
@@ -78,8 +110,10 @@ func NewService(p *parser.Parser, s *renderer.Serializer) Service {
 
 // Transform implements Service. Composes parser.LoadDocument →
 // renderer.RenderDocument → os.WriteFile as the canonical
-// transform pipeline. Platform is assumed pre-validated by the
-// caller (cmd/adapt.go's runAdapt validates via core.ValidatePlatform
+// transform pipeline, reporting the fields the platform drops
+// (renderer.DroppedFields) and the unknown frontmatter keys
+// (parser.Options.Warn) on the result. Platform is assumed pre-validated by the
+// caller (cmd/adapt.go's runAdapt validates via platforms.Validate
 // before resolving the Service).
 //
 // On error the chain is wrapped so the cmd layer can dispatch by
@@ -92,7 +126,18 @@ func (t *transformService) Transform(ctx context.Context, req *Request) (*core.T
 		return nil, core.NewValidationError("transform", "request", "", "transform request must not be nil")
 	}
 
-	doc, err := t.parser.LoadDocument(ctx, req.InputPath, req.Platform)
+	detection, err := parser.ResolveType(ctx, req.InputPath, req.DocType)
+	if err != nil {
+		return nil, fmt.Errorf("detecting document type: %w", err)
+	}
+
+	var warnings []error
+	doc, err := t.parser.LoadDocumentWith(ctx, req.InputPath, req.Platform, parser.Options{
+		Type:    detection.Type,
+		Content: req.Content,
+		Vars:    req.Vars,
+		Warn:    func(w *core.ParseError) { warnings = append(warnings, w) },
+	})
 	if err != nil {
 		return nil, fmt.Errorf("loading document: %w", err)
 	}
@@ -102,9 +147,101 @@ func (t *transformService) Transform(ctx context.Context, req *Request) (*core.T
 		return nil, core.NewTransformError("render", req.Platform, "failed to render document", err)
 	}
 
-	if err := os.WriteFile(req.OutputPath, []byte(rendered), 0o644); err != nil { //nolint:gosec // G306: user-owned output path; 0644 is standard readable permission
-		return nil, core.NewFileError(req.OutputPath, "write", "failed to write output file", err)
+	dropped, err := t.serializer.DroppedFields(doc, req.Platform)
+	if err != nil {
+		return nil, fmt.Errorf("detecting dropped fields: %w", err)
+	}
+	if req.Strict {
+		if err := core.CheckLosses(req.Platform, dropped); err != nil {
+			return nil, err //nolint:wrapcheck // typed *core.TransformError for ExitCodeFor dispatch
+		}
+	}
+
+	outputPath := req.OutputPath
+	if req.Output != nil {
+		if _, err := io.WriteString(req.Output, rendered); err != nil {
+			return nil, core.NewFileError(outputPath, "write", "failed to write output", err)
+		}
+	} else if req.OutputDir != "" {
+		outputPath, err = t.writeToLayout(req, doc, detection.Type, rendered)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := os.WriteFile(outputPath, []byte(rendered), 0o644); err != nil { //nolint:gosec // G306: user-owned output path; 0644 is standard readable permission
+		return nil, core.NewFileError(outputPath, "write", "failed to write output file", err)
+	}
+
+	return &core.TransformResult{
+		OutputPath: outputPath,
+		Dropped:    dropped,
+		Warnings:   warnings,
+		DocType:    detection.Type,
+		DetectedBy: detection.Rule,
+	}, nil
+}
+
+// writeToLayout writes rendered to the platform's output path for doc
+// under req.OutputDir and returns that path. Memory is placed by its
+// path scope, as init does.
+func (t *transformService) writeToLayout(req *Request, doc any, docType, rendered string) (string, error) {
+	name := documentName(doc, req.InputPath, docType)
+	var outputPath string
+	var err error
+	if m, ok := doc.(*parser.CanonicalMemory); ok {
+		outputPath, err = library.GetMemoryOutputPath(name, req.Platform, req.OutputDir, m.Paths)
+	} else {
+		outputPath, err = library.GetOutputPath(docType, name, req.Platform, req.OutputDir)
+	}
+	if err != nil {
+		return "", err //nolint:wrapcheck // typed *core.ConfigError propagates as-is
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil { //nolint:gosec // G301: user-owned output directory; 0755 is standard permission
+		return "", core.NewFileError(outputPath, "mkdir", "failed to create output directory", err)
+	}
+	layout, merge := library.MergedOutputLayout(docType, req.Platform)
+	if m, ok := doc.(*parser.CanonicalMemory); ok {
+		layout, merge = library.MergedMemoryLayout(req.Platform, m.Paths)
 	}
+	if merge {
+		t.mergeMu.Lock()
+		defer t.mergeMu.Unlock()
+		rendered, err = install.MergeIntoExisting(outputPath, docType+"/"+name, rendered, layout.MergeJSON, false)
+		if err != nil {
+			return "", err //nolint:wrapcheck // typed *core.FileError propagates as-is
+		}
+	}
+	if err := os.WriteFile(outputPath, []byte(rendered), 0o644); err != nil { //nolint:gosec // G306: user-owned output path; 0644 is standard readable permission
+		return "", core.NewFileError(outputPath, "write", "failed to write output file", err)
+	}
+	return outputPath, nil
+}
 
-	return &core.TransformResult{OutputPath: req.OutputPath}, nil
+// documentName returns the name doc declares or, for documents without
+// one (memory), the base name of inputPath without its extension and
+// any docType- prefix or -docType suffix: memory-go-style.md is
+// go-style.
+func documentName(doc any, inputPath, docType string) string {
+	var name string
+	switch d := doc.(type) {
+	case *parser.CanonicalAgent:
+		name = d.Name
+	case *parser.CanonicalCommand:
+		name = d.Name
+	case *parser.CanonicalSkill:
+		name = d.Name
+	case *parser.CanonicalMCPServer:
+		name = d.Name
+	case *parser.CanonicalHook:
+		name = d.Name
+	case *parser.CanonicalSettings:
+		name = d.Name
+	}
+	if name != "" {
+		return name
+	}
+	base := filepath.Base(inputPath)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	base = strings.TrimPrefix(base, docType+"-")
+	return strings.TrimSuffix(base, "-"+docType)
 }
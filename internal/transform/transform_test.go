@@ -154,3 +154,117 @@ func TestNewService_ImplementsService(t *testing.T) {
 	s := NewService(parser.NewParser(), renderer.NewSerializer())
 	assert.NotNil(t, s, "NewService must return a non-nil Service")
 }
+
+func TestService_Transform_DroppedFields(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	src := filepath.Join(tmp, "agent-reviewer.md")
+	body := "---\nname: reviewer\ndescription: Reviews code\ntargets:\n  claude-code:\n    skills:\n      - commit\n---\n# Body\n"
+	require.NoError(t, os.WriteFile(src, []byte(body), 0o600))
+
+	tests := []struct {
+		name      string
+		platform  string
+		strict    bool
+		wantDrop  []core.FieldLoss
+		wantError bool
+	}{
+		{name: "lossless", platform: core.PlatformClaudeCode},
+		{
+			name:     "reported",
+			platform: core.PlatformOpenCode,
+			wantDrop: []core.FieldLoss{{Field: "targets.claude-code.skills", Value: "commit", Reason: "settings for claude-code do not apply to opencode"}},
+		},
+		{name: "strict fails", platform: core.PlatformOpenCode, strict: true, wantError: true},
+		{name: "strict lossless", platform: core.PlatformClaudeCode, strict: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := filepath.Join(t.TempDir(), "out.md")
+			result, err := newTestService().Transform(context.Background(), &Request{
+				InputPath:  src,
+				OutputPath: out,
+				Platform:   tt.platform,
+				Strict:     tt.strict,
+			})
+			if tt.wantError {
+				var te *core.TransformError
+				require.ErrorAs(t, err, &te)
+				assert.Contains(t, err.Error(), "targets.claude-code.skills")
+				assert.NoFileExists(t, out, "strict must not write a lossy rendering")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDrop, result.Dropped)
+			assert.FileExists(t, out)
+		})
+	}
+}
+
+func TestService_Transform_OutputDir(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	agent := writeCanonicalAgent(t, tmp, "reviewer", "Reviews things")
+	memory := filepath.Join(tmp, "memory-go-style.md")
+	require.NoError(t, os.WriteFile(memory, []byte("---\npaths:\n  - \"**/*.go\"\n---\nUse gofmt.\n"), 0o600))
+	outDir := filepath.Join(tmp, "project")
+
+	svc := newTestService()
+	result, err := svc.Transform(context.Background(), &Request{
+		InputPath: agent,
+		OutputDir: outDir,
+		Platform:  core.PlatformOpenCode,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(outDir, ".opencode", "agents", "reviewer.md"), result.OutputPath)
+	assert.FileExists(t, result.OutputPath)
+
+	result, err = svc.Transform(context.Background(), &Request{
+		InputPath: memory,
+		OutputDir: outDir,
+		Platform:  core.PlatformClaudeCode,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, filepath.ToSlash(result.OutputPath), "go-style", "memory is named after its file")
+	assert.FileExists(t, result.OutputPath)
+}
+
+func TestService_Transform_OutputDirMergesSharedFile(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	outDir := filepath.Join(tmp, "project")
+	var hooks []string
+	for _, name := range []string{"fmt", "lint", "vet", "test"} {
+		path := filepath.Join(tmp, "hook-"+name+".md")
+		body := "---\nname: " + name + "\nevent: PostToolUse\ncommand: go " + name + "\n---\n"
+		require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+		hooks = append(hooks, path)
+	}
+
+	svc := newTestService()
+	done := make(chan error, len(hooks))
+	for _, path := range hooks {
+		go func() {
+			_, err := svc.Transform(context.Background(), &Request{
+				InputPath: path,
+				OutputDir: outDir,
+				Platform:  core.PlatformClaudeCode,
+			})
+			done <- err
+		}()
+	}
+	for range hooks {
+		require.NoError(t, <-done)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outDir, ".claude", "settings.json"))
+	require.NoError(t, err)
+	for _, name := range []string{"fmt", "lint", "vet", "test"} {
+		assert.Contains(t, string(content), "go "+name, "concurrent transforms must not drop each other's hooks")
+	}
+}
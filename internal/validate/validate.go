@@ -14,10 +14,12 @@ package validate
 
 import (
 	"context"
+	"errors"
+	"path/filepath"
 
 	"gitlab.com/amoconst/germinator/internal/core"
-	"gitlab.com/amoconst/germinator/internal/core/opencode"
 	"gitlab.com/amoconst/germinator/internal/parser"
+	"gitlab.com/amoconst/germinator/internal/platforms"
 )
 
 // Request carries the inputs for document validation. Lifted from
@@ -28,12 +30,25 @@ import (
 type Request struct {
 	InputPath string
 	Platform  string
+	// DocType is the document type; empty means detect it
+	// (parser.Detect).
+	DocType string
+	// Content, when set, is the document to validate in place of the
+	// file at InputPath, which then only names it (validate - reads it
+	// from stdin). DocType must be set as well.
+	Content []byte
+	// Vars are values for the variables the document declares.
+	Vars map[string]string
+	// Models are the model aliases the document's model is checked
+	// and resolved against; the aliases of the library holding the
+	// document take precedence over them.
+	Models core.ModelAliases
 }
 
 // Service is the per-call contract for document validation. Returns
 // a *core.ValidateResult; callers inspect result.Valid() to dispatch
 // success vs. error rendering. Platform is assumed pre-validated by
-// the caller (cmd/validate.go validates via core.ValidatePlatform
+// the caller (cmd/validate.go validates via platforms.Validate
 // before resolving the Service).
 type Service interface {
 	Validate(ctx context.Context, req *Request) (*core.ValidateResult, error)
@@ -41,7 +56,7 @@ type Service interface {
 
 // validateService is the production implementation. Zero-size because
 // the orchestrating state (a parser + validators) is held by the
-// functional core / opencode adapters — the service is a thin
+// functional core and the platform registry — the service is a thin
 // dispatch across document types.
 type validateService struct{}
 
@@ -56,65 +71,171 @@ func NewService() Service {
 }
 
 // Validate implements Service. Orchestrates parse → validate across
-// each canonical document type (agent / command / skill / memory) and,
-// when the platform is opencode, adds the platform-specific validators
-// from internal/core/opencode on top of the shared core validators.
+// each canonical document type (agent / command / skill / memory) and
+// adds the platform-specific validators registered for req.Platform
+// (platforms.Platform.Validators) on top of the shared core validators.
 //
 // The errors returned from each validator are joined; we unwrap with
 // unwrapJoinedErrors so the slice lives flat in *core.ValidateResult.Errors.
+// A variable the document requires but req.Vars does not set is a
+// validation error like any other, as is a malformed conditional block
+// in the body, or a model alias without a model for req.Platform. An
+// aliased model is resolved before the platform validators see it.
+// Frontmatter keys the document type has no field for are reported in
+// Warnings and leave the document valid.
+//
 // Fatal errors (unrecognized doc type / parse failure) short-circuit
 // and are returned as *core.ParseError so cmd/cmdutil.ExitCodeFor maps
 // them to exit 1 via errors.As.
 func (validateService) Validate(ctx context.Context, req *Request) (*core.ValidateResult, error) {
-	docType := parser.DetectType(ctx, req.InputPath)
-	if docType == "" {
-		return nil, core.NewParseError(req.InputPath, "unrecognizable filename", nil)
+	detection, err := parser.ResolveType(ctx, req.InputPath, req.DocType)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // typed *core.ParseError for ExitCodeFor dispatch
 	}
+	docType := detection.Type
 
-	doc, parseErr := parser.ParseDocument(ctx, req.InputPath, docType)
+	var warnings []error
+	doc, parseErr := parser.ParseDocumentWith(ctx, req.InputPath, docType, parser.Options{
+		Vars:    req.Vars,
+		Content: req.Content,
+		Warn:    func(w *core.ParseError) { warnings = append(warnings, w) },
+	})
 	if parseErr != nil {
+		var validationErr *core.ValidationError
+		if errors.As(parseErr, &validationErr) {
+			return &core.ValidateResult{Errors: []error{validationErr}}, nil
+		}
+		var positioned *core.ParseError
+		if errors.As(parseErr, &positioned) && positioned.Line() > 0 {
+			return nil, positioned
+		}
 		return nil, core.NewParseError(req.InputPath, "failed to parse document", parseErr)
 	}
 
+	var extra platforms.Validators
 	var errs []error
+	if target, ok := platforms.Lookup(req.Platform); ok {
+		extra = target.Validators()
+		if err := resolveModel(ctx, req, doc, docType, target); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	modelReported := len(errs) > 0
 
 	switch d := doc.(type) {
 	case *parser.CanonicalAgent:
-		if result := core.ValidateAgent(&d.Agent); result.IsError() {
-			errs = append(errs, unwrapJoinedErrors(result.Error)...)
-		}
-		if req.Platform == core.PlatformOpenCode {
-			if result := opencode.ValidateAgentOpenCode(&d.Agent); result.IsError() {
-				errs = append(errs, unwrapJoinedErrors(result.Error)...)
-			}
-		}
+		errs = append(errs, runValidators(&d.Agent, core.ValidateAgent, extra.Agent)...)
 	case *parser.CanonicalCommand:
-		if result := core.ValidateCommand(&d.Command); result.IsError() {
-			errs = append(errs, unwrapJoinedErrors(result.Error)...)
-		}
-		if req.Platform == core.PlatformOpenCode {
-			if result := opencode.ValidateCommandOpenCode(&d.Command); result.IsError() {
-				errs = append(errs, unwrapJoinedErrors(result.Error)...)
-			}
-		}
+		errs = append(errs, runValidators(&d.Command, core.ValidateCommand, extra.Command)...)
 	case *parser.CanonicalMemory:
-		if result := core.ValidateMemory(&d.Memory); result.IsError() {
-			errs = append(errs, unwrapJoinedErrors(result.Error)...)
-		}
+		errs = append(errs, runValidators(&d.Memory, core.ValidateMemory, extra.Memory)...)
 	case *parser.CanonicalSkill:
-		if result := core.ValidateSkill(&d.Skill); result.IsError() {
-			errs = append(errs, unwrapJoinedErrors(result.Error)...)
+		errs = append(errs, runValidators(&d.Skill, core.ValidateSkill, extra.Skill)...)
+	case *parser.CanonicalMCPServer:
+		errs = append(errs, runValidators(&d.MCPServer, core.ValidateMCPServer, extra.MCP)...)
+	case *parser.CanonicalHook:
+		errs = append(errs, runValidators(&d.Hook, core.ValidateHook, extra.Hook)...)
+	case *parser.CanonicalSettings:
+		errs = append(errs, runValidators(&d.Settings, core.ValidateSettings, extra.Settings)...)
+	default:
+		return nil, core.NewParseError(req.InputPath, "unknown document type", nil)
+	}
+	if modelReported {
+		// The model error from resolveModel says it best; the platform
+		// validators would only flag the same model again.
+		errs = append(errs[:1], withoutFieldErrors(errs[1:], "model")...)
+	}
+
+	if _, err := core.SelectPlatformBlocks(documentBody(doc), req.Platform, platforms.IDs()); err != nil {
+		errs = append(errs, core.NewParseError(req.InputPath, "invalid conditional block", err))
+	}
+
+	return &core.ValidateResult{Errors: errs, Warnings: warnings, DocType: docType, DetectedBy: detection.Rule}, nil
+}
+
+// resolveModel checks the model of an agent, command, skill, or settings
+// document against the model aliases (core.ModelAliases.Check) and
+// resolves it to the platform's model id in place. Documents the platform renders without
+// a model are left alone. A library that fails to load contributes no
+// aliases; its errors surface when the library itself is used.
+func resolveModel(ctx context.Context, req *Request, doc any, docType string, target platforms.Platform) error {
+	var model *string
+	switch d := doc.(type) {
+	case *parser.CanonicalAgent:
+		model = &d.Model
+	case *parser.CanonicalCommand:
+		model = &d.Model
+	case *parser.CanonicalSkill:
+		model = &d.Model
+	case *parser.CanonicalSettings:
+		model = &d.Settings.Model
+	}
+	if model == nil || *model == "" || !target.Supports(docType) {
+		return nil
+	}
+	for _, f := range target.Adapter().UnsupportedFields(docType) {
+		if f.Field == "model" {
+			return nil
 		}
-		if req.Platform == core.PlatformOpenCode {
-			if result := opencode.ValidateSkillOpenCode(&d.Skill); result.IsError() {
-				errs = append(errs, unwrapJoinedErrors(result.Error)...)
-			}
+	}
+
+	models := req.Models
+	if abs, err := filepath.Abs(req.InputPath); err == nil {
+		if lib, err := parser.EnclosingLibrary(ctx, abs); err == nil && lib != nil {
+			models = models.Merge(lib.Models)
 		}
+	}
+	if err := models.Check(*model, target.ID(), target.Validators().ModelID); err != nil {
+		return err //nolint:wrapcheck // typed *core.ValidationError reported as is
+	}
+	*model = models.Resolve(*model, target.ID())
+	return nil
+}
+
+// withoutFieldErrors returns errs without the *core.ValidationErrors on
+// field.
+func withoutFieldErrors(errs []error, field string) []error {
+	var kept []error
+	for _, err := range errs {
+		var verr *core.ValidationError
+		if errors.As(err, &verr) && verr.Field() == field {
+			continue
+		}
+		kept = append(kept, err)
+	}
+	return kept
+}
+
+// documentBody returns the Markdown body a platform template renders,
+// or "" for document types whose body is not rendered.
+func documentBody(doc any) string {
+	switch d := doc.(type) {
+	case *parser.CanonicalAgent:
+		return d.Content
+	case *parser.CanonicalCommand:
+		return d.Content
+	case *parser.CanonicalSkill:
+		return d.Content
+	case *parser.CanonicalMemory:
+		return d.Memory.Content
 	default:
-		return nil, core.NewParseError(req.InputPath, "unknown document type", nil)
+		return ""
 	}
+}
 
-	return &core.ValidateResult{Errors: errs}, nil
+// runValidators applies the core validator followed by the optional
+// platform validator and returns their flattened errors.
+func runValidators[T any](doc T, base, platform core.ValidationFunc[T]) []error {
+	var errs []error
+	if result := base(doc); result.IsError() {
+		errs = append(errs, unwrapJoinedErrors(result.Error)...)
+	}
+	if platform != nil {
+		if result := platform(doc); result.IsError() {
+			errs = append(errs, unwrapJoinedErrors(result.Error)...)
+		}
+	}
+	return errs
 }
 
 // unwrapJoinedErrors unwraps a joined error into individual errors.
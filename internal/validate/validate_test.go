@@ -101,6 +101,7 @@ Body`,
 			result, err := svc.Validate(context.Background(), &validate.Request{
 				InputPath: path,
 				Platform:  tt.platform,
+				Models:    core.DefaultModelAliases(),
 			})
 			require.NoError(t, err)
 			require.NotNil(t, result)
@@ -131,7 +132,7 @@ Body`)
 	var perr *core.ParseError
 	require.True(t, errors.As(err, &perr),
 		"fatal error must wrap *core.ParseError")
-	assert.Contains(t, err.Error(), "unrecognizable filename")
+	assert.Contains(t, err.Error(), "cannot detect document type")
 }
 
 func TestService_Validate_MissingFile(t *testing.T) {
@@ -191,3 +192,251 @@ Body`)
 	assert.NotEmpty(t, result.Errors, "missing field must surface as at least one error")
 	assert.False(t, result.Valid())
 }
+
+func TestService_Validate_Vars(t *testing.T) {
+	t.Parallel()
+
+	svc := validate.NewService()
+	path := writeFixture(t, "agent-templated.md", `---
+name: reviewer
+description: Reviews {{ .Vars.project }}
+vars:
+  project:
+---
+Body`)
+
+	result, err := svc.Validate(context.Background(), &validate.Request{InputPath: path, Platform: core.PlatformOpenCode})
+	require.NoError(t, err)
+	require.False(t, result.Valid())
+	var verr *core.ValidationError
+	require.ErrorAs(t, result.Errors[0], &verr)
+	assert.Equal(t, "project", verr.Value())
+
+	result, err = svc.Validate(context.Background(), &validate.Request{
+		InputPath: path,
+		Platform:  core.PlatformOpenCode,
+		Vars:      map[string]string{"project": "germinator"},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Valid())
+}
+
+func TestService_Validate_UnknownFields(t *testing.T) {
+	t.Parallel()
+
+	svc := validate.NewService()
+	path := writeFixture(t, "agent-reviewer.md", `---
+name: reviewer
+description: Reviews code
+permissionMode: plan
+---
+Body`)
+
+	result, err := svc.Validate(context.Background(), &validate.Request{InputPath: path, Platform: core.PlatformOpenCode})
+	require.NoError(t, err)
+	assert.True(t, result.Valid(), "unknown fields do not make a document invalid")
+	require.Len(t, result.Warnings, 1)
+	var perr *core.ParseError
+	require.ErrorAs(t, result.Warnings[0], &perr)
+	assert.Equal(t, 4, perr.Line())
+	assert.Equal(t, []string{"did you mean permissionPolicy?"}, perr.Suggestions())
+}
+
+func TestService_Validate_ParseErrorPosition(t *testing.T) {
+	t.Parallel()
+
+	svc := validate.NewService()
+	path := writeFixture(t, "agent-reviewer.md", `---
+name: reviewer
+description: Reviews code
+behavior:
+  steps: many
+---
+Body`)
+
+	_, err := svc.Validate(context.Background(), &validate.Request{InputPath: path, Platform: core.PlatformOpenCode})
+	var perr *core.ParseError
+	require.ErrorAs(t, err, &perr)
+	assert.Equal(t, path+":5:10", perr.Location())
+}
+
+func TestService_Validate_ConditionalBlocks(t *testing.T) {
+	t.Parallel()
+
+	path := writeFixture(t, "command-explore.md", `---
+name: explore
+description: Explore the code
+---
+<!-- germinator:if platform=vscode -->
+Open the explorer.
+<!-- germinator:endif -->`)
+
+	result, err := validate.NewService().Validate(context.Background(), &validate.Request{InputPath: path, Platform: core.PlatformOpenCode})
+	require.NoError(t, err)
+	require.False(t, result.Valid())
+	assert.Contains(t, result.Errors[0].Error(), `unknown platform "vscode"`)
+}
+
+func TestService_Validate_Models(t *testing.T) {
+	t.Parallel()
+
+	models := core.DefaultModelAliases().Merge(core.ModelAliases{"fast": {core.PlatformOpenCode: "openai/gpt-5-mini"}})
+
+	tests := []struct {
+		name      string
+		model     string
+		platform  string
+		wantError string
+	}{
+		{name: "alias mapped for platform", model: "fast", platform: core.PlatformOpenCode},
+		{name: "built-in alias", model: "opus", platform: core.PlatformOpenCode},
+		{name: "alias not mapped for platform", model: "fast", platform: core.PlatformClaudeCode, wantError: "model alias fast has no claude-code model"},
+		{name: "unknown model", model: "smart", platform: core.PlatformOpenCode, wantError: "unknown model smart: not a model alias or a model id for opencode"},
+		{name: "unknown model on claude-code", model: "smartest", platform: core.PlatformClaudeCode, wantError: "not a model alias or a model id for claude-code"},
+		{name: "unknown model on copilot", model: "smartest", platform: core.PlatformCopilot, wantError: "not a model alias or a model id for copilot"},
+		{name: "platform model id", model: "claude-sonnet-4-5-20250929", platform: core.PlatformClaudeCode},
+		{name: "copilot model name", model: "GPT-4.1", platform: core.PlatformCopilot},
+		{name: "platform drops the model", model: "fast", platform: core.PlatformCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := writeFixture(t, "command-review.md", "---\nname: review\ndescription: Review code\nmodel: "+tt.model+"\n---\nBody")
+
+			result, err := validate.NewService().Validate(context.Background(), &validate.Request{
+				InputPath: path,
+				Platform:  tt.platform,
+				Models:    models,
+			})
+			require.NoError(t, err)
+			if tt.wantError == "" {
+				assert.Empty(t, result.Errors)
+				return
+			}
+			require.Len(t, result.Errors, 1)
+			assert.Contains(t, result.Errors[0].Error(), tt.wantError)
+		})
+	}
+}
+
+func TestService_Validate_SettingsModel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		model     string
+		platform  string
+		wantError string
+	}{
+		{name: "built-in alias on opencode", model: "sonnet", platform: core.PlatformOpenCode},
+		{name: "built-in alias on claude-code", model: "sonnet", platform: core.PlatformClaudeCode},
+		{name: "opencode id", model: "anthropic/claude-sonnet-4-5", platform: core.PlatformOpenCode},
+		{name: "unknown model", model: "smartest", platform: core.PlatformOpenCode, wantError: "not a model alias or a model id for opencode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := writeFixture(t, "settings-project.md", "---\nname: project\nmodel: "+tt.model+"\n---\n")
+
+			result, err := validate.NewService().Validate(context.Background(), &validate.Request{
+				InputPath: path,
+				Platform:  tt.platform,
+				Models:    core.DefaultModelAliases(),
+			})
+			require.NoError(t, err)
+			if tt.wantError == "" {
+				assert.Empty(t, result.Errors)
+				return
+			}
+			require.Len(t, result.Errors, 1)
+			assert.Contains(t, result.Errors[0].Error(), tt.wantError)
+		})
+	}
+}
+
+func TestService_Validate_ClaudeCodeRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		filename  string
+		body      string
+		platform  string
+		wantError string
+	}{
+		{
+			name:      "unknown tool",
+			filename:  "agent-reviewer.md",
+			body:      "---\nname: reviewer\ndescription: Reviews code\ntools:\n  - bash\n  - listdir\n---\nBody",
+			platform:  core.PlatformClaudeCode,
+			wantError: "unknown Claude Code tool Listdir",
+		},
+		{
+			name:     "unknown tool on another platform",
+			filename: "agent-reviewer.md",
+			body:     "---\nname: reviewer\ndescription: Reviews code\ntools:\n  - bash\n  - listdir\n---\nBody",
+			platform: core.PlatformOpenCode,
+		},
+		{
+			name:      "permissionMode override",
+			filename:  "agent-reviewer.md",
+			body:      "---\nname: reviewer\ndescription: Reviews code\ntargets:\n  claude-code:\n    permissionMode: auto\n---\nBody",
+			platform:  core.PlatformClaudeCode,
+			wantError: "permissionMode must be one of",
+		},
+		{
+			name:      "agent without fork",
+			filename:  "skill-release.md",
+			body:      "---\nname: release\ndescription: Cut a release\nexecution:\n  agent: explore\n---\nBody",
+			platform:  core.PlatformClaudeCode,
+			wantError: "execution.agent applies only with execution.context: fork",
+		},
+		{
+			name:      "matcher on a non-tool event",
+			filename:  "hook-notify.md",
+			body:      "---\nname: notify\nevent: Stop\nmatcher: Bash\ncommand: notify-send done\n---\n",
+			platform:  core.PlatformClaudeCode,
+			wantError: "Stop hooks take no matcher on Claude Code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := writeFixture(t, tt.filename, tt.body)
+
+			result, err := validate.NewService().Validate(context.Background(), &validate.Request{
+				InputPath: path,
+				Platform:  tt.platform,
+			})
+			require.NoError(t, err)
+			if tt.wantError == "" {
+				assert.Empty(t, result.Errors)
+				return
+			}
+			require.Len(t, result.Errors, 1)
+			assert.Contains(t, result.Errors[0].Error(), tt.wantError)
+		})
+	}
+}
+
+func TestService_Validate_DocType(t *testing.T) {
+	t.Parallel()
+
+	svc := validate.NewService()
+	detected := writeFixture(t, "reviewer.md", "---\nname: reviewer\ndescription: Reviews code\nbehavior:\n  mode: primary\n---\nBody")
+	result, err := svc.Validate(context.Background(), &validate.Request{InputPath: detected, Platform: core.PlatformOpenCode})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, "agent", result.DocType)
+	assert.Equal(t, "frontmatter field behavior", result.DetectedBy)
+
+	given := writeFixture(t, "release.md", "---\nname: release\ndescription: Cut a release\n---\nBody")
+	result, err = svc.Validate(context.Background(), &validate.Request{InputPath: given, Platform: core.PlatformClaudeCode, DocType: "skill"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, "skill", result.DocType)
+	assert.Empty(t, result.DetectedBy, "a given type is not detected")
+}